@@ -2,9 +2,12 @@ package api
 
 import (
 	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/godbus/dbus/v5"
 	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile/adapter"
 	"github.com/muka/go-bluetooth/bluez/profile/advertising"
 	log "github.com/sirupsen/logrus"
 )
@@ -12,18 +15,13 @@ import (
 // const baseAdvertismentPath = "/org/bluez/%s/apps/advertisement%d"
 const BaseAdvertismentPath = "/go_bluetooth/%s/advertisement/%d"
 
-var advertisingCount int = -1
+// advertisingCount is never decreased, so that paths are not reused while
+// bluez may still reference them
+var advertisingCount uint64
 
 func nextAdvertismentPath(adapterID string) dbus.ObjectPath {
-	advertisingCount++
-	return dbus.ObjectPath(fmt.Sprintf(BaseAdvertismentPath, adapterID, advertisingCount))
-}
-
-func decreaseAdvertismentCounter() {
-	advertisingCount--
-	if advertisingCount < -1 {
-		advertisingCount = -1
-	}
+	n := atomic.AddUint64(&advertisingCount, 1) - 1
+	return dbus.ObjectPath(fmt.Sprintf(BaseAdvertismentPath, adapterID, n))
 }
 
 type Advertisement struct {
@@ -32,6 +30,9 @@ type Advertisement struct {
 	iprops        *DBusProperties
 	conn          *dbus.Conn
 	props         *advertising.LEAdvertisement1Properties
+
+	releaseLock sync.Mutex
+	onRelease   func(*Advertisement)
 }
 
 func (a *Advertisement) DBusConn() *dbus.Conn {
@@ -58,17 +59,55 @@ func (a *Advertisement) Interface() string {
 	return advertising.LEAdvertisement1Interface
 }
 
+// OnRelease set a callback invoked when bluez releases the advertisement
+func (a *Advertisement) OnRelease(fn func(*Advertisement)) {
+	a.releaseLock.Lock()
+	defer a.releaseLock.Unlock()
+	a.onRelease = fn
+}
+
+// Release implements LEAdvertisement1.Release, called by bluez when it removes
+// the advertisement, eg. once its Timeout expired
+func (a *Advertisement) Release() *dbus.Error {
+	log.Debugf("Advertisement %s released", a.path)
+	a.releaseLock.Lock()
+	fn := a.onRelease
+	a.releaseLock.Unlock()
+	if fn != nil {
+		// do not block the D-Bus dispatch, the callback may unexpose the object
+		go fn(a)
+	}
+	return nil
+}
+
+// unexpose remove the advertisement object from the bus
+func (a *Advertisement) unexpose() {
+	a.conn.Export(nil, a.path, a.Interface())
+	a.conn.Export(nil, a.path, bluez.PropertiesInterface)
+	a.conn.Export(nil, a.path, "org.freedesktop.DBus.Introspectable")
+}
+
 func NewAdvertisement(adapterID string, props *advertising.LEAdvertisement1Properties) (*Advertisement, error) {
+	return NewAdvertisementWithConn(nil, adapterID, props)
+}
+
+// NewAdvertisementWithConn create an advertisement exposed on the given connection,
+// the default system bus connection is used if bconn is nil
+func NewAdvertisementWithConn(bconn *bluez.Conn, adapterID string, props *advertising.LEAdvertisement1Properties) (*Advertisement, error) {
 
 	adv := new(Advertisement)
 
 	adv.props = props
 	adv.path = nextAdvertismentPath(adapterID)
 
-	conn, err := dbus.SystemBus()
-	if err != nil {
-		return nil, err
+	if bconn == nil {
+		c, err := bluez.DefaultConn(bluez.SystemBus)
+		if err != nil {
+			return nil, err
+		}
+		bconn = c
 	}
+	conn := bconn.DBus()
 	adv.conn = conn
 
 	om, err := NewDBusObjectManager(conn)
@@ -88,14 +127,32 @@ func NewAdvertisement(adapterID string, props *advertising.LEAdvertisement1Prope
 
 // Expose to bluez an advertisment instance via the adapter advertisement manager
 func ExposeAdvertisement(adapterID string, props *advertising.LEAdvertisement1Properties, discoverableTimeout uint32) (func(), error) {
+	return ExposeAdvertisementWithConn(nil, adapterID, props, discoverableTimeout)
+}
+
+// ExposeAdvertisementWithConn expose an advertisment instance via the adapter advertisement manager reachable on conn
+func ExposeAdvertisementWithConn(conn *bluez.Conn, adapterID string, props *advertising.LEAdvertisement1Properties, discoverableTimeout uint32) (func(), error) {
 
 	log.Tracef("Retrieving adapter instance %s", adapterID)
-	a, err := GetAdapter(adapterID)
+	var (
+		a   *adapter.Adapter1
+		err error
+	)
+	if conn == nil {
+		a, err = GetAdapter(adapterID)
+	} else {
+		a, err = adapter.GetAdapterWithConn(conn, adapterID)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	adv, err := NewAdvertisement(adapterID, props)
+	err = props.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid advertisement: %s", err)
+	}
+
+	adv, err := NewAdvertisementWithConn(conn, adapterID, props)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +178,7 @@ func ExposeAdvertisement(adapterID string, props *advertising.LEAdvertisement1Pr
 	}
 
 	log.Trace("Registering LEAdvertisement1 instance")
-	advManager, err := advertising.NewLEAdvertisingManager1FromAdapterID(adapterID)
+	advManager, err := advertising.NewLEAdvertisingManager1FromAdapterIDWithConn(conn, adapterID)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +189,6 @@ func ExposeAdvertisement(adapterID string, props *advertising.LEAdvertisement1Pr
 	}
 
 	cancel := func() {
-		decreaseAdvertismentCounter()
 		err := advManager.UnregisterAdvertisement(adv.Path())
 		if err != nil {
 			log.Warn(err)
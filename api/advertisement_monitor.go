@@ -0,0 +1,537 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile/advertisement_monitor"
+	log "github.com/sirupsen/logrus"
+)
+
+// BaseAdvertisementMonitorPath is the root of the monitors of an AdvertisementMonitorManager
+const BaseAdvertisementMonitorPath = "/go_bluetooth/%s/monitor/%d"
+
+// ErrMonitorNotRegistered is returned when removing a monitor not managed, or already released
+var ErrMonitorNotRegistered = errors.New("advertisement monitor not registered")
+
+// advertisementMonitorCount is never decreased, so that roots are not reused while
+// bluez may still reference them
+var advertisementMonitorCount uint64
+
+func nextAdvertisementMonitorRoot(adapterID string) dbus.ObjectPath {
+	n := atomic.AddUint64(&advertisementMonitorCount, 1) - 1
+	return dbus.ObjectPath(fmt.Sprintf(BaseAdvertisementMonitorPath, adapterID, n))
+}
+
+// AdvertisementMonitor is an exposed org.bluez.AdvertisementMonitor1, notified by
+// bluez of the devices whose advertisements match its patterns
+type AdvertisementMonitor struct {
+	path          dbus.ObjectPath
+	objectManager *DBusObjectManager
+	iprops        *DBusProperties
+	conn          *dbus.Conn
+	props         *advertisement_monitor.AdvertisementMonitor1Properties
+
+	lock           sync.Mutex
+	samplingPeriod uint16
+	active         bool
+	released       func(*AdvertisementMonitor)
+	onActivate     func()
+	onRelease      func()
+	onDeviceFound  func(device dbus.ObjectPath)
+	onDeviceLost   func(device dbus.ObjectPath)
+}
+
+func (a *AdvertisementMonitor) DBusConn() *dbus.Conn {
+	return a.conn
+}
+
+func (a *AdvertisementMonitor) DBusObjectManager() *DBusObjectManager {
+	return a.objectManager
+}
+
+func (a *AdvertisementMonitor) DBusProperties() *DBusProperties {
+	return a.iprops
+}
+
+func (a *AdvertisementMonitor) GetProperties() bluez.Properties {
+	return a.props
+}
+
+func (a *AdvertisementMonitor) Path() dbus.ObjectPath {
+	return a.path
+}
+
+func (a *AdvertisementMonitor) Interface() string {
+	return advertisement_monitor.AdvertisementMonitor1Interface
+}
+
+// Active report if bluez activated the monitor
+func (a *AdvertisementMonitor) Active() bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.active
+}
+
+// SetRSSISamplingPeriod set how the advertisements of in-range devices are reported,
+// see advertisement_monitor.SamplingPeriodAll. Call it from the setup function of
+// AdvertisementMonitorManager.Add, it is sent to bluez 5.56 and later only
+func (a *AdvertisementMonitor) SetRSSISamplingPeriod(period uint16) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.samplingPeriod = period
+}
+
+// OnActivate set a callback invoked when bluez activates the monitor
+func (a *AdvertisementMonitor) OnActivate(fn func()) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.onActivate = fn
+}
+
+// OnRelease set a callback invoked when bluez releases the monitor, either as
+// it cannot be activated or once deactivated
+func (a *AdvertisementMonitor) OnRelease(fn func()) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.onRelease = fn
+}
+
+// OnDeviceFound set a callback invoked with the device path when a device matches
+// the monitor, and stays above the high RSSI threshold if set
+func (a *AdvertisementMonitor) OnDeviceFound(fn func(device dbus.ObjectPath)) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.onDeviceFound = fn
+}
+
+// OnDeviceLost set a callback invoked with the device path when a found device is
+// no longer seen, or stays below the low RSSI threshold if set
+func (a *AdvertisementMonitor) OnDeviceLost(fn func(device dbus.ObjectPath)) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.onDeviceLost = fn
+}
+
+// Release implements AdvertisementMonitor1.Release
+func (a *AdvertisementMonitor) Release() *dbus.Error {
+	log.Debugf("AdvertisementMonitor %s released", a.path)
+	a.lock.Lock()
+	a.active = false
+	released := a.released
+	fn := a.onRelease
+	a.lock.Unlock()
+	// do not block the D-Bus dispatch, the monitor is unexposed
+	go func() {
+		if released != nil {
+			released(a)
+		}
+		if fn != nil {
+			fn()
+		}
+	}()
+	return nil
+}
+
+// Activate implements AdvertisementMonitor1.Activate
+func (a *AdvertisementMonitor) Activate() *dbus.Error {
+	log.Debugf("AdvertisementMonitor %s activated", a.path)
+	a.lock.Lock()
+	a.active = true
+	fn := a.onActivate
+	a.lock.Unlock()
+	if fn != nil {
+		fn()
+	}
+	return nil
+}
+
+// DeviceFound implements AdvertisementMonitor1.DeviceFound
+func (a *AdvertisementMonitor) DeviceFound(device dbus.ObjectPath) *dbus.Error {
+	log.Tracef("AdvertisementMonitor %s: found %s", a.path, device)
+	a.lock.Lock()
+	fn := a.onDeviceFound
+	a.lock.Unlock()
+	if fn != nil {
+		fn(device)
+	}
+	return nil
+}
+
+// DeviceLost implements AdvertisementMonitor1.DeviceLost
+func (a *AdvertisementMonitor) DeviceLost(device dbus.ObjectPath) *dbus.Error {
+	log.Tracef("AdvertisementMonitor %s: lost %s", a.path, device)
+	a.lock.Lock()
+	fn := a.onDeviceLost
+	a.lock.Unlock()
+	if fn != nil {
+		fn(device)
+	}
+	return nil
+}
+
+// unexpose remove the monitor object from the bus
+func (a *AdvertisementMonitor) unexpose() {
+	a.conn.Export(nil, a.path, a.Interface())
+	a.conn.Export(nil, a.path, bluez.PropertiesInterface)
+	a.conn.Export(nil, a.path, "org.freedesktop.DBus.Introspectable")
+}
+
+// AdvertisementMonitorManager registers a hierarchy of advertisement monitors
+// on an adapter. Monitors are added and removed while the hierarchy is registered,
+// bluez follows the changes with the ObjectManager exposed on the root
+type AdvertisementMonitorManager struct {
+	adapterID string
+	conn      *bluez.Conn
+	manager   *advertisement_monitor.AdvertisementMonitorManager1
+	root      dbus.ObjectPath
+	rssi      rssiLayout
+
+	lock     sync.Mutex
+	count    int
+	monitors map[dbus.ObjectPath]*AdvertisementMonitor
+	closed   bool
+}
+
+// rssiLayout tells the RSSI properties exposed on the monitors
+type rssiLayout uint8
+
+const (
+	// rssiBoth expose both layouts, each daemon ignores the properties it does not know
+	rssiBoth rssiLayout = iota
+	// rssiCombined expose RSSIThresholdsAndTimers, read before advertisement_monitor.RSSIVersion
+	rssiCombined
+	// rssiSeparate expose RSSIHighThreshold and the other properties read since advertisement_monitor.RSSIVersion
+	rssiSeparate
+)
+
+// detectRSSILayout choose the RSSI properties to expose from the daemon version.
+// Only a version reported by the daemon tells an older one, as a version inferred
+// from its API is a lower bound
+func detectRSSILayout(conn *bluez.Conn, systemBus bool) rssiLayout {
+	if systemBus {
+		if version, err := bluez.Version(); err == nil {
+			if bluez.CompareVersions(version, advertisement_monitor.RSSIVersion) >= 0 {
+				return rssiSeparate
+			}
+			return rssiCombined
+		}
+	}
+	if version, err := conn.MinVersion(); err == nil && bluez.CompareVersions(version, advertisement_monitor.RSSIVersion) >= 0 {
+		return rssiSeparate
+	}
+	return rssiBoth
+}
+
+// NewAdvertisementMonitorManager register a monitors hierarchy on an adapter
+func NewAdvertisementMonitorManager(adapterID string) (*AdvertisementMonitorManager, error) {
+	return NewAdvertisementMonitorManagerWithConn(nil, adapterID)
+}
+
+// NewAdvertisementMonitorManagerWithConn register a monitors hierarchy on an adapter
+// using the given connection. The default system bus connection is used if conn is nil
+func NewAdvertisementMonitorManagerWithConn(conn *bluez.Conn, adapterID string) (*AdvertisementMonitorManager, error) {
+
+	systemBus := conn == nil
+	if systemBus {
+		c, err := bluez.DefaultConn(bluez.SystemBus)
+		if err != nil {
+			return nil, err
+		}
+		conn = c
+	}
+
+	manager, err := advertisement_monitor.NewAdvertisementMonitorManager1FromAdapterIDWithConn(conn, adapterID)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &AdvertisementMonitorManager{
+		adapterID: adapterID,
+		conn:      conn,
+		manager:   manager,
+		root:      nextAdvertisementMonitorRoot(adapterID),
+		rssi:      detectRSSILayout(conn, systemBus),
+		monitors:  map[dbus.ObjectPath]*AdvertisementMonitor{},
+	}
+
+	err = m.expose()
+	if err != nil {
+		m.unexpose()
+		return nil, err
+	}
+
+	err = m.manager.RegisterMonitor(m.root)
+	if err != nil {
+		m.unexpose()
+		return nil, fmt.Errorf("RegisterMonitor: %s", err)
+	}
+
+	log.Debugf("Registered advertisement monitors %s", m.root)
+	return m, nil
+}
+
+// expose the ObjectManager of the monitors root
+func (m *AdvertisementMonitorManager) expose() error {
+
+	conn := m.conn.DBus()
+
+	err := conn.ExportMethodTable(map[string]interface{}{
+		"GetManagedObjects": m.getManagedObjects,
+	}, m.root, bluez.ObjectManagerInterface)
+	if err != nil {
+		return err
+	}
+
+	node := &introspect.Node{
+		Interfaces: []introspect.Interface{
+			introspect.IntrospectData,
+			{
+				Name: bluez.ObjectManagerInterface,
+				Methods: []introspect.Method{
+					{
+						Name: "GetManagedObjects",
+						Args: []introspect.Arg{
+							{Name: "objects", Type: "a{oa{sa{sv}}}", Direction: "out"},
+						},
+					},
+				},
+				Signals: []introspect.Signal{
+					{
+						Name: "InterfacesAdded",
+						Args: []introspect.Arg{
+							{Name: "object", Type: "o"},
+							{Name: "interfaces", Type: "a{sa{sv}}"},
+						},
+					},
+					{
+						Name: "InterfacesRemoved",
+						Args: []introspect.Arg{
+							{Name: "object", Type: "o"},
+							{Name: "interfaces", Type: "as"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return conn.Export(introspect.NewIntrospectable(node), m.root, "org.freedesktop.DBus.Introspectable")
+}
+
+// unexpose remove the monitors root from the bus
+func (m *AdvertisementMonitorManager) unexpose() {
+	conn := m.conn.DBus()
+	conn.Export(nil, m.root, bluez.ObjectManagerInterface)
+	conn.Export(nil, m.root, "org.freedesktop.DBus.Introspectable")
+}
+
+// getManagedObjects implements ObjectManager.GetManagedObjects on the monitors root
+func (m *AdvertisementMonitorManager) getManagedObjects() (map[dbus.ObjectPath]map[string]map[string]dbus.Variant, *dbus.Error) {
+	objects := map[dbus.ObjectPath]map[string]map[string]dbus.Variant{}
+	for _, monitor := range m.Monitors() {
+		ifaces, err := monitorInterfaces(monitor, m.rssi)
+		if err != nil {
+			log.Errorf("AdvertisementMonitorManager: %s", err)
+			return nil, dbus.MakeFailedError(err)
+		}
+		objects[monitor.Path()] = ifaces
+	}
+	return objects, nil
+}
+
+// monitorInterfaces return the interfaces and properties of a monitor, as listed by the ObjectManager
+func monitorInterfaces(monitor *AdvertisementMonitor, rssi rssiLayout) (map[string]map[string]dbus.Variant, error) {
+	props, err := monitor.props.ToMap()
+	if err != nil {
+		return nil, err
+	}
+	if rssi == rssiSeparate {
+		delete(props, "RSSIThresholdsAndTimers")
+	}
+	if rssi != rssiCombined {
+		monitor.lock.Lock()
+		samplingPeriod := monitor.samplingPeriod
+		monitor.lock.Unlock()
+		separate, err := monitor.props.RSSIProperties(samplingPeriod)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range separate {
+			props[name] = value
+		}
+	}
+	values := map[string]dbus.Variant{}
+	for name, value := range props {
+		values[name] = dbus.MakeVariant(value)
+	}
+	return map[string]map[string]dbus.Variant{
+		monitor.Interface(): values,
+	}, nil
+}
+
+// Root return the path of the registered hierarchy
+func (m *AdvertisementMonitorManager) Root() dbus.ObjectPath {
+	return m.root
+}
+
+// SupportedMonitorTypes return the monitor types supported by bluez, eg. MonitorTypeOrPatterns
+func (m *AdvertisementMonitorManager) SupportedMonitorTypes() ([]string, error) {
+	return m.manager.GetSupportedMonitorTypes()
+}
+
+// SupportedFeatures return the monitoring features available, eg. FeatureControllerPatterns
+func (m *AdvertisementMonitorManager) SupportedFeatures() ([]string, error) {
+	return m.manager.GetSupportedFeatures()
+}
+
+// Monitors return the monitors, sorted by path
+func (m *AdvertisementMonitorManager) Monitors() []*AdvertisementMonitor {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	list := []*AdvertisementMonitor{}
+	for _, monitor := range m.monitors {
+		list = append(list, monitor)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Path() < list[j].Path()
+	})
+	return list
+}
+
+// Add validate and expose a monitor under the root, bluez activates it once
+// notified by InterfacesAdded. Set the monitor callbacks in setup, before bluez
+// can call them
+func (m *AdvertisementMonitorManager) Add(props *advertisement_monitor.AdvertisementMonitor1Properties, setup func(*AdvertisementMonitor)) (*AdvertisementMonitor, error) {
+
+	err := props.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid advertisement monitor: %s", err)
+	}
+
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return nil, errors.New("AdvertisementMonitorManager is closed")
+	}
+	path := dbus.ObjectPath(fmt.Sprintf("%s/monitor%d", m.root, m.count))
+	m.count++
+	m.lock.Unlock()
+
+	conn := m.conn.DBus()
+	om, err := NewDBusObjectManager(conn)
+	if err != nil {
+		return nil, err
+	}
+	iprops, err := NewDBusProperties(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	monitor := &AdvertisementMonitor{
+		path:           path,
+		objectManager:  om,
+		iprops:         iprops,
+		conn:           conn,
+		props:          props,
+		samplingPeriod: advertisement_monitor.SamplingPeriodUnset,
+		released:       m.released,
+	}
+	if setup != nil {
+		setup(monitor)
+	}
+
+	err = ExposeDBusService(monitor)
+	if err != nil {
+		monitor.unexpose()
+		return nil, err
+	}
+
+	ifaces, err := monitorInterfaces(monitor, m.rssi)
+	if err != nil {
+		monitor.unexpose()
+		return nil, err
+	}
+
+	m.lock.Lock()
+	m.monitors[path] = monitor
+	m.lock.Unlock()
+
+	err = conn.Emit(m.root, bluez.InterfacesAdded, path, ifaces)
+	if err != nil {
+		m.lock.Lock()
+		delete(m.monitors, path)
+		m.lock.Unlock()
+		monitor.unexpose()
+		return nil, fmt.Errorf("InterfacesAdded: %s", err)
+	}
+
+	log.Debugf("Added advertisement monitor %s", path)
+	return monitor, nil
+}
+
+// Remove a monitor, bluez deactivates it once notified by InterfacesRemoved
+func (m *AdvertisementMonitorManager) Remove(monitor *AdvertisementMonitor) error {
+
+	m.lock.Lock()
+	_, ok := m.monitors[monitor.Path()]
+	delete(m.monitors, monitor.Path())
+	m.lock.Unlock()
+	if !ok {
+		return ErrMonitorNotRegistered
+	}
+
+	monitor.unexpose()
+	err := m.conn.DBus().Emit(m.root, bluez.InterfacesRemoved, monitor.Path(), []string{monitor.Interface()})
+	if err != nil {
+		return fmt.Errorf("InterfacesRemoved: %s", err)
+	}
+
+	log.Debugf("Removed advertisement monitor %s", monitor.Path())
+	return nil
+}
+
+// released handle a monitor released by bluez
+func (m *AdvertisementMonitorManager) released(monitor *AdvertisementMonitor) {
+	m.lock.Lock()
+	_, ok := m.monitors[monitor.Path()]
+	delete(m.monitors, monitor.Path())
+	m.lock.Unlock()
+	if ok {
+		monitor.unexpose()
+	}
+}
+
+// Close remove the monitors and unregister the hierarchy
+func (m *AdvertisementMonitorManager) Close() error {
+
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return nil
+	}
+	m.closed = true
+	monitors := m.monitors
+	m.monitors = map[dbus.ObjectPath]*AdvertisementMonitor{}
+	m.lock.Unlock()
+
+	for _, monitor := range monitors {
+		monitor.unexpose()
+	}
+
+	err := m.manager.UnregisterMonitor(m.root)
+	m.unexpose()
+	if err != nil && !errors.Is(err, bluez.ErrDoesNotExist) {
+		return fmt.Errorf("UnregisterMonitor: %s", err)
+	}
+
+	log.Debugf("Unregistered advertisement monitors %s", m.root)
+	return nil
+}
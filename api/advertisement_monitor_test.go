@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile/advertisement_monitor"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMonitor() *advertisement_monitor.AdvertisementMonitor1Properties {
+	props := &advertisement_monitor.AdvertisementMonitor1Properties{
+		Type: advertisement_monitor.MonitorTypeOrPatterns,
+	}
+	props.AddPattern(0, 0xff, []byte{0xff, 0xff})
+	props.SetRSSI(-60, 2, -80, 5)
+	return props
+}
+
+func receivePath(t *testing.T, ch chan dbus.ObjectPath) dbus.ObjectPath {
+	t.Helper()
+	select {
+	case path := <-ch:
+		return path
+	case <-time.After(2 * time.Second):
+		t.Fatal("Callback not called")
+		return ""
+	}
+}
+
+func TestAdvertisementMonitor(t *testing.T) {
+	_, fa, a := startScanFake(t)
+
+	conn, err := a.Client().Conn()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewAdvertisementMonitorManagerWithConn(conn, fa.AdapterID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []dbus.ObjectPath{m.Root()}, fa.MonitorApps())
+
+	types, err := m.SupportedMonitorTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, types, advertisement_monitor.MonitorTypeOrPatterns)
+
+	sub, err := conn.Subscribe(context.Background(), bluez.SignalMatch{
+		Path:   m.Root(),
+		Member: "InterfacesAdded",
+	}, bluez.SubscribeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Cancel()
+
+	_, err = m.Add(&advertisement_monitor.AdvertisementMonitor1Properties{}, nil)
+	assert.Error(t, err)
+
+	found := make(chan dbus.ObjectPath, 1)
+	lost := make(chan dbus.ObjectPath, 1)
+	released := make(chan dbus.ObjectPath, 1)
+	monitor, err := m.Add(newTestMonitor(), func(monitor *AdvertisementMonitor) {
+		monitor.OnDeviceFound(func(device dbus.ObjectPath) {
+			found <- device
+		})
+		monitor.OnDeviceLost(func(device dbus.ObjectPath) {
+			lost <- device
+		})
+		monitor.OnRelease(func() {
+			released <- monitor.Path()
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case sig := <-sub.C():
+		assert.Equal(t, monitor.Path(), sig.Body[0])
+	case <-time.After(2 * time.Second):
+		t.Fatal("InterfacesAdded not received")
+	}
+
+	monitors, err := fa.Monitors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []dbus.ObjectPath{monitor.Path()}, monitors)
+
+	err = fa.ActivateMonitor(monitor.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, monitor.Active())
+
+	device := dbus.ObjectPath(string(fa.Path()) + "/dev_00_11_22_33_44_55")
+	err = fa.MonitorDeviceFound(monitor.Path(), device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, device, receivePath(t, found))
+	err = fa.MonitorDeviceLost(monitor.Path(), device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, device, receivePath(t, lost))
+
+	// a released monitor is removed
+	err = fa.ReleaseMonitor(monitor.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, monitor.Path(), receivePath(t, released))
+	assert.False(t, monitor.Active())
+	assert.Empty(t, m.Monitors())
+	assert.True(t, errors.Is(m.Remove(monitor), ErrMonitorNotRegistered))
+
+	monitor2, err := m.Add(newTestMonitor(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, monitor.Path(), monitor2.Path())
+	err = m.Remove(monitor2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	monitors, err = fa.Monitors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, monitors)
+
+	err = m.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, fa.MonitorApps())
+}
+
+func TestAdvertisementMonitorRSSI(t *testing.T) {
+	monitor := &AdvertisementMonitor{
+		props:          newTestMonitor(),
+		samplingPeriod: advertisement_monitor.SamplingPeriodUnset,
+	}
+
+	props := func(rssi rssiLayout) map[string]dbus.Variant {
+		ifaces, err := monitorInterfaces(monitor, rssi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ifaces[advertisement_monitor.AdvertisementMonitor1Interface]
+	}
+
+	combined := props(rssiCombined)
+	assert.Contains(t, combined, "RSSIThresholdsAndTimers")
+	assert.NotContains(t, combined, "RSSIHighThreshold")
+
+	monitor.SetRSSISamplingPeriod(advertisement_monitor.SamplingPeriodFirst)
+	separate := props(rssiSeparate)
+	assert.NotContains(t, separate, "RSSIThresholdsAndTimers")
+	assert.Equal(t, dbus.MakeVariant(int16(-60)), separate["RSSIHighThreshold"])
+	assert.Equal(t, dbus.MakeVariant(uint16(5)), separate["RSSILowTimeout"])
+	assert.Equal(t, dbus.MakeVariant(uint16(255)), separate["RSSISamplingPeriod"])
+
+	// the daemon version is not known
+	both := props(rssiBoth)
+	assert.Contains(t, both, "RSSIThresholdsAndTimers")
+	assert.Contains(t, both, "RSSIHighThreshold")
+}
@@ -0,0 +1,355 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile/advertising"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrNotRegistered is returned when unregistering an advertisement not managed, or already released
+var ErrNotRegistered = errors.New("advertisement not registered")
+
+// AdvertisingEventType is the type of an AdvertisingEvent
+type AdvertisingEventType uint8
+
+const (
+	// AdvertisementRegistered the advertisement has been registered with bluez
+	AdvertisementRegistered AdvertisingEventType = iota
+	// AdvertisementUnregistered the advertisement has been unregistered
+	AdvertisementUnregistered
+	// AdvertisementReleased bluez removed the advertisement, eg. once its Timeout expired
+	AdvertisementReleased
+)
+
+func (t AdvertisingEventType) String() string {
+	switch t {
+	case AdvertisementRegistered:
+		return "registered"
+	case AdvertisementUnregistered:
+		return "unregistered"
+	case AdvertisementReleased:
+		return "released"
+	}
+	return "unknown"
+}
+
+// AdvertisingEvent is emitted by AdvertisingManager when an advertisement changes state
+type AdvertisingEvent struct {
+	Type          AdvertisingEventType
+	Advertisement *Advertisement
+}
+
+// AdvertisingManager registers multiple advertisements on an adapter, each on a
+// unique path, and tracks them until they are unregistered or released by bluez
+type AdvertisingManager struct {
+	adapterID string
+	conn      *bluez.Conn
+	manager   *advertising.LEAdvertisingManager1
+	events    chan AdvertisingEvent
+
+	lock           sync.Mutex
+	advertisements map[dbus.ObjectPath]*Advertisement
+	rotations      map[*Rotation]bool
+	// closing refuse new registrations, registering tracks the ones in progress
+	closing     bool
+	registering sync.WaitGroup
+	closed      bool
+}
+
+// NewAdvertisingManager create a manager for the advertisements of an adapter
+func NewAdvertisingManager(adapterID string) (*AdvertisingManager, error) {
+	return NewAdvertisingManagerWithConn(nil, adapterID)
+}
+
+// NewAdvertisingManagerWithConn create a manager for the advertisements of an adapter
+// using the given connection. The default system bus connection is used if conn is nil
+func NewAdvertisingManagerWithConn(conn *bluez.Conn, adapterID string) (*AdvertisingManager, error) {
+
+	if conn == nil {
+		c, err := bluez.DefaultConn(bluez.SystemBus)
+		if err != nil {
+			return nil, err
+		}
+		conn = c
+	}
+
+	manager, err := advertising.NewLEAdvertisingManager1FromAdapterIDWithConn(conn, adapterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdvertisingManager{
+		adapterID:      adapterID,
+		conn:           conn,
+		manager:        manager,
+		events:         make(chan AdvertisingEvent, 32),
+		advertisements: map[dbus.ObjectPath]*Advertisement{},
+		rotations:      map[*Rotation]bool{},
+	}, nil
+}
+
+// Events return the advertisements changes. Events are dropped if the channel is not consumed
+func (m *AdvertisingManager) Events() <-chan AdvertisingEvent {
+	return m.events
+}
+
+// SupportedInstances return the number of advertisements that can still be registered
+func (m *AdvertisingManager) SupportedInstances() (byte, error) {
+	return m.manager.GetSupportedInstances()
+}
+
+// ActiveInstances return the number of advertisements registered on the adapter, by any application
+func (m *AdvertisingManager) ActiveInstances() (byte, error) {
+	return m.manager.GetActiveInstances()
+}
+
+// Advertisements return the registered advertisements, sorted by path
+func (m *AdvertisingManager) Advertisements() []*Advertisement {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	list := []*Advertisement{}
+	for _, adv := range m.advertisements {
+		list = append(list, adv)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Path() < list[j].Path()
+	})
+	return list
+}
+
+// Register validate, expose and register an advertisement
+func (m *AdvertisingManager) Register(props *advertising.LEAdvertisement1Properties) (*Advertisement, error) {
+
+	err := props.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid advertisement: %s", err)
+	}
+
+	m.lock.Lock()
+	if m.closing {
+		m.lock.Unlock()
+		return nil, errors.New("AdvertisingManager is closed")
+	}
+	// Close waits for the registration to complete before unregistering
+	m.registering.Add(1)
+	m.lock.Unlock()
+	defer m.registering.Done()
+
+	adv, err := NewAdvertisementWithConn(m.conn, m.adapterID, props)
+	if err != nil {
+		return nil, err
+	}
+	adv.OnRelease(m.released)
+
+	err = ExposeDBusService(adv)
+	if err != nil {
+		adv.unexpose()
+		return nil, err
+	}
+
+	// track it before registering, bluez may release it right away
+	m.lock.Lock()
+	m.advertisements[adv.Path()] = adv
+	m.lock.Unlock()
+
+	err = m.manager.RegisterAdvertisement(adv.Path(), map[string]interface{}{})
+	if err != nil {
+		m.lock.Lock()
+		delete(m.advertisements, adv.Path())
+		m.lock.Unlock()
+		adv.unexpose()
+		return nil, fmt.Errorf("RegisterAdvertisement: %s", err)
+	}
+
+	log.Debugf("Registered advertisement %s", adv.Path())
+	m.emit(AdvertisingEvent{Type: AdvertisementRegistered, Advertisement: adv})
+	return adv, nil
+}
+
+// Unregister an advertisement and remove it from the bus
+func (m *AdvertisingManager) Unregister(adv *Advertisement) error {
+
+	m.lock.Lock()
+	_, ok := m.advertisements[adv.Path()]
+	delete(m.advertisements, adv.Path())
+	m.lock.Unlock()
+	if !ok {
+		return ErrNotRegistered
+	}
+
+	err := m.manager.UnregisterAdvertisement(adv.Path())
+	adv.unexpose()
+	if err != nil && !errors.Is(err, bluez.ErrDoesNotExist) {
+		return fmt.Errorf("UnregisterAdvertisement: %s", err)
+	}
+
+	log.Debugf("Unregistered advertisement %s", adv.Path())
+	m.emit(AdvertisingEvent{Type: AdvertisementUnregistered, Advertisement: adv})
+	return nil
+}
+
+// released handle an advertisement released by bluez
+func (m *AdvertisingManager) released(adv *Advertisement) {
+	m.lock.Lock()
+	_, ok := m.advertisements[adv.Path()]
+	delete(m.advertisements, adv.Path())
+	m.lock.Unlock()
+	if !ok {
+		return
+	}
+	adv.unexpose()
+	m.emit(AdvertisingEvent{Type: AdvertisementReleased, Advertisement: adv})
+}
+
+func (m *AdvertisingManager) emit(ev AdvertisingEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.closed {
+		return
+	}
+	select {
+	case m.events <- ev:
+	default:
+		log.Warnf("AdvertisingManager: events queue full, dropping %s event of %s", ev.Type, ev.Advertisement.Path())
+	}
+}
+
+// Close stop the rotations, unregister the advertisements and close the Events channel
+func (m *AdvertisingManager) Close() {
+
+	m.lock.Lock()
+	m.closing = true
+	rotations := []*Rotation{}
+	for r := range m.rotations {
+		rotations = append(rotations, r)
+	}
+	m.lock.Unlock()
+
+	for _, r := range rotations {
+		r.Stop()
+	}
+	// the advertisements being registered are unregistered below
+	m.registering.Wait()
+	for _, adv := range m.Advertisements() {
+		err := m.Unregister(adv)
+		if err != nil {
+			log.Warnf("AdvertisingManager: %s", err)
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if !m.closed {
+		m.closed = true
+		close(m.events)
+	}
+}
+
+// Rotation cycles advertisements, registering one at a time
+type Rotation struct {
+	manager  *AdvertisingManager
+	list     []*advertising.LEAdvertisement1Properties
+	interval time.Duration
+
+	done chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// Rotate register the advertisements in turn, replacing the current one every
+// interval. A single instance is used, so rotating works on adapters supporting one
+func (m *AdvertisingManager) Rotate(interval time.Duration, list ...*advertising.LEAdvertisement1Properties) (*Rotation, error) {
+
+	if len(list) == 0 {
+		return nil, errors.New("Rotate: no advertisement")
+	}
+	if interval <= 0 {
+		return nil, errors.New("Rotate: interval must be positive")
+	}
+	for i, props := range list {
+		err := props.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("Rotate: advertisement %d: %s", i, err)
+		}
+	}
+
+	current, err := m.Register(list[0])
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Rotation{
+		manager:  m,
+		list:     list,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+
+	m.lock.Lock()
+	if m.closing {
+		m.lock.Unlock()
+		m.Unregister(current)
+		return nil, errors.New("AdvertisingManager is closed")
+	}
+	m.rotations[r] = true
+	m.lock.Unlock()
+
+	r.wg.Add(1)
+	go r.run(current)
+
+	return r, nil
+}
+
+// Stop the rotation, unregistering the current advertisement
+func (r *Rotation) Stop() {
+	r.once.Do(func() {
+		close(r.done)
+		r.wg.Wait()
+
+		r.manager.lock.Lock()
+		delete(r.manager.rotations, r)
+		r.manager.lock.Unlock()
+	})
+}
+
+func (r *Rotation) run(current *Advertisement) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	next := 1 % len(r.list)
+	for {
+		select {
+		case <-ticker.C:
+			if current != nil {
+				err := r.manager.Unregister(current)
+				if err != nil && !errors.Is(err, ErrNotRegistered) {
+					log.Warnf("Rotation: %s", err)
+				}
+			}
+			adv, err := r.manager.Register(r.list[next])
+			if err != nil {
+				log.Warnf("Rotation: %s", err)
+			}
+			current = adv
+			next = (next + 1) % len(r.list)
+		case <-r.done:
+			if current != nil {
+				err := r.manager.Unregister(current)
+				if err != nil && !errors.Is(err, ErrNotRegistered) {
+					log.Warnf("Rotation: %s", err)
+				}
+			}
+			return
+		}
+	}
+}
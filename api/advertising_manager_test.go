@@ -0,0 +1,167 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez/fake"
+	"github.com/muka/go-bluetooth/bluez/profile/advertising"
+	"github.com/stretchr/testify/assert"
+)
+
+func startAdvertisingFake(t *testing.T) (*fake.Adapter, *AdvertisingManager) {
+	_, fa, a := startScanFake(t)
+	conn, err := a.Client().Conn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewAdvertisingManagerWithConn(conn, fa.AdapterID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fa, m
+}
+
+func receiveAdvertisingEvent(t *testing.T, m *AdvertisingManager) AdvertisingEvent {
+	t.Helper()
+	select {
+	case ev := <-m.Events():
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("Event not received")
+		return AdvertisingEvent{}
+	}
+}
+
+func newTestAdvertisement(name string) *advertising.LEAdvertisement1Properties {
+	props, _, _ := advertising.NewBuilder().LocalName(name).ServiceUUID("180f").Build()
+	return props
+}
+
+func TestAdvertisingManager(t *testing.T) {
+	fa, m := startAdvertisingFake(t)
+	defer m.Close()
+
+	supported, err := m.SupportedInstances()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adv1, err := m.Register(newTestAdvertisement("adv1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	adv2, err := m.Register(newTestAdvertisement("adv2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, adv1.Path(), adv2.Path())
+	assert.Equal(t, AdvertisementRegistered, receiveAdvertisingEvent(t, m).Type)
+	assert.Equal(t, AdvertisementRegistered, receiveAdvertisingEvent(t, m).Type)
+
+	active, err := m.ActiveInstances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, byte(2), active)
+	left, err := m.SupportedInstances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, supported-2, left)
+
+	// unregistering in any order never reuses a path
+	err = m.Unregister(adv1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, AdvertisementUnregistered, receiveAdvertisingEvent(t, m).Type)
+	assert.True(t, errors.Is(m.Unregister(adv1), ErrNotRegistered))
+
+	adv3, err := m.Register(newTestAdvertisement("adv3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiveAdvertisingEvent(t, m)
+	assert.NotEqual(t, adv1.Path(), adv3.Path())
+	assert.NotEqual(t, adv2.Path(), adv3.Path())
+	assert.ElementsMatch(t, []dbus.ObjectPath{adv2.Path(), adv3.Path()}, fa.Advertisements())
+
+	// bluez releasing an advertisement
+	err = fa.ReleaseAdvertisement(adv2.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := receiveAdvertisingEvent(t, m)
+	assert.Equal(t, AdvertisementReleased, ev.Type)
+	assert.Equal(t, adv2, ev.Advertisement)
+	assert.Equal(t, []*Advertisement{adv3}, m.Advertisements())
+
+	// invalid advertisements are refused before registering
+	_, err = m.Register(&advertising.LEAdvertisement1Properties{
+		ManufacturerData: map[uint16]interface{}{0xffff: make([]byte, 40)},
+	})
+	assert.Error(t, err)
+
+	m.Close()
+	assert.Empty(t, fa.Advertisements())
+	_, ok := <-m.Events()
+	for ok {
+		_, ok = <-m.Events()
+	}
+}
+
+func TestAdvertisingRotation(t *testing.T) {
+	fa, m := startAdvertisingFake(t)
+	defer m.Close()
+
+	r, err := m.Rotate(50*time.Millisecond, newTestAdvertisement("adv1"), newTestAdvertisement("adv2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{}
+	for len(names) < 3 {
+		ev := receiveAdvertisingEvent(t, m)
+		if ev.Type != AdvertisementRegistered {
+			continue
+		}
+		names = append(names, ev.Advertisement.props.LocalName)
+		assert.Len(t, fa.Advertisements(), 1)
+	}
+	assert.Equal(t, []string{"adv1", "adv2", "adv1"}, names)
+
+	r.Stop()
+	assert.Empty(t, fa.Advertisements())
+	assert.Empty(t, m.Advertisements())
+}
+
+func TestAdvertisingCloseWhileRegistering(t *testing.T) {
+	fa, m := startAdvertisingFake(t)
+
+	registering := make(chan bool)
+	fa.OnCall("RegisterAdvertisement", func() *dbus.Error {
+		close(registering)
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	})
+
+	res := make(chan error, 1)
+	go func() {
+		_, err := m.Register(newTestAdvertisement("adv1"))
+		res <- err
+	}()
+
+	<-registering
+	m.Close()
+
+	// the advertisement registered while closing is unregistered
+	assert.NoError(t, <-res)
+	assert.Empty(t, fa.Advertisements())
+	assert.Empty(t, m.Advertisements())
+
+	_, err := m.Register(newTestAdvertisement("adv2"))
+	assert.Error(t, err)
+}
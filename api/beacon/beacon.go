@@ -2,9 +2,9 @@ package beacon
 
 import (
 	"context"
-	"strings"
 
 	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
 	"github.com/muka/go-bluetooth/bluez/profile/advertising"
 	"github.com/muka/go-bluetooth/bluez/profile/device"
 )
@@ -153,14 +153,12 @@ func (b *Beacon) parserIBeacon(manufacturerData map[uint16]interface{}) bool {
 
 func (b *Beacon) parserEddystone(UUIDs []string, serviceData map[string]interface{}) bool {
 	for _, uuid := range UUIDs {
-		// 0000feaa-
-		srcUUID := uuid
-		if len(uuid) > 8 {
-			uuid = uuid[4:8]
+		if !bluez.UUID(uuid).Equal(eddystoneSrvcUid) {
+			continue
 		}
-
-		if strings.ToUpper(uuid) == eddystoneSrvcUid {
-			if data, ok := serviceData[srcUUID]; ok {
+		// service data keys may use a different UUID form, eg. 0000feaa-...
+		for key, data := range serviceData {
+			if bluez.UUID(key).Equal(eddystoneSrvcUid) {
 				// log.Debug("Found Eddystone")
 				b.Type = BeaconTypeEddystone
 				// log.Debugf("Eddystone data: %d", data)
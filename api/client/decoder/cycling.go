@@ -0,0 +1,71 @@
+package decoder
+
+import (
+	"encoding/binary"
+)
+
+// CSC Measurement flags
+const (
+	cscFlagWheel uint8 = 0x01
+	cscFlagCrank uint8 = 0x02
+)
+
+// WheelRevolutionData are the cumulative wheel revolutions of a CSCMeasurement
+type WheelRevolutionData struct {
+	Revolutions uint32
+	// LastEventTime in 1/1024 seconds
+	LastEventTime uint16
+}
+
+// CrankRevolutionData are the cumulative crank revolutions of a CSCMeasurement
+type CrankRevolutionData struct {
+	Revolutions uint16
+	// LastEventTime in 1/1024 seconds
+	LastEventTime uint16
+}
+
+// CSCMeasurement is a Cycling Speed and Cadence measurement
+type CSCMeasurement struct {
+	// Wheel nil when not reported
+	Wheel *WheelRevolutionData
+	// Crank nil when not reported
+	Crank *CrankRevolutionData
+}
+
+// DecodeCSCMeasurement decode a CSC Measurement (0x2A5B) value
+func DecodeCSCMeasurement(value []byte) (CSCMeasurement, error) {
+	m := CSCMeasurement{}
+
+	err := checkLength("CSC Measurement", value, 1)
+	if err != nil {
+		return m, err
+	}
+
+	flags := value[0]
+	value = value[1:]
+
+	if flags&cscFlagWheel != 0 {
+		err = checkLength("CSC Measurement wheel data", value, 6)
+		if err != nil {
+			return m, err
+		}
+		m.Wheel = &WheelRevolutionData{
+			Revolutions:   binary.LittleEndian.Uint32(value),
+			LastEventTime: binary.LittleEndian.Uint16(value[4:]),
+		}
+		value = value[6:]
+	}
+
+	if flags&cscFlagCrank != 0 {
+		err = checkLength("CSC Measurement crank data", value, 4)
+		if err != nil {
+			return m, err
+		}
+		m.Crank = &CrankRevolutionData{
+			Revolutions:   binary.LittleEndian.Uint16(value),
+			LastEventTime: binary.LittleEndian.Uint16(value[2:]),
+		}
+	}
+
+	return m, nil
+}
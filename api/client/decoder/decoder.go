@@ -0,0 +1,85 @@
+// Package decoder converts GATT characteristic values to typed Go values,
+// with a registry of decoders keyed by characteristic UUID
+package decoder
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/muka/go-bluetooth/bluez"
+)
+
+// ErrNoDecoder is returned when no decoder is registered for a UUID
+var ErrNoDecoder = errors.New("no decoder")
+
+// Decoder converts a characteristic value
+type Decoder func(value []byte) (interface{}, error)
+
+// Registry holds decoders by characteristic UUID
+type Registry struct {
+	lock     sync.RWMutex
+	decoders map[string]Decoder
+}
+
+// NewRegistry create an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		decoders: map[string]Decoder{},
+	}
+}
+
+// DefaultRegistry holds the decoders of the standard characteristics
+var DefaultRegistry = newDefaultRegistry()
+
+// Register a decoder for a characteristic UUID, in the 16, 32 or 128 bit form.
+// A decoder already registered for the UUID is replaced
+func (r *Registry) Register(uuid string, d Decoder) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.decoders[bluez.UUID(uuid).String()] = d
+}
+
+// Lookup return the decoder for a characteristic UUID
+func (r *Registry) Lookup(uuid string) (Decoder, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	d, ok := r.decoders[bluez.UUID(uuid).String()]
+	return d, ok
+}
+
+// Decode a value of the characteristic with uuid
+func (r *Registry) Decode(uuid string, value []byte) (interface{}, error) {
+	d, ok := r.Lookup(uuid)
+	if !ok {
+		return nil, fmt.Errorf("Characteristic %s: %w", uuid, ErrNoDecoder)
+	}
+	v, err := d(value)
+	if err != nil {
+		return nil, fmt.Errorf("Characteristic %s: %s", uuid, err)
+	}
+	return v, nil
+}
+
+// Register a decoder in the DefaultRegistry
+func Register(uuid string, d Decoder) {
+	DefaultRegistry.Register(uuid, d)
+}
+
+// Lookup a decoder in the DefaultRegistry
+func Lookup(uuid string) (Decoder, bool) {
+	return DefaultRegistry.Lookup(uuid)
+}
+
+// Decode a value with the DefaultRegistry
+func Decode(uuid string, value []byte) (interface{}, error) {
+	return DefaultRegistry.Decode(uuid, value)
+}
+
+// checkLength return an error if value is shorter than length
+func checkLength(name string, value []byte, length int) error {
+	if len(value) < length {
+		return fmt.Errorf("%s: expected at least %d bytes, got %d", name, length, len(value))
+	}
+	return nil
+}
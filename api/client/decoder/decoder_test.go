@@ -0,0 +1,161 @@
+package decoder
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/muka/go-bluetooth/api/standard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+
+	_, err := r.Decode("2A19", []byte{1})
+	assert.True(t, errors.Is(err, ErrNoDecoder))
+
+	r.Register("2a19", func(value []byte) (interface{}, error) {
+		return len(value), nil
+	})
+
+	for _, uuid := range []string{"2A19", "00002A19", "00002a19-0000-1000-8000-00805f9b34fb"} {
+		v, err := r.Decode(uuid, []byte{1, 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 2, v)
+	}
+
+	r.Register("2A19", func(value []byte) (interface{}, error) {
+		return nil, errors.New("invalid")
+	})
+	_, err = r.Decode("2A19", []byte{1})
+	assert.Error(t, err)
+
+	_, ok := r.Lookup("2A1C")
+	assert.False(t, ok)
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	contact := true
+	energy := uint16(0x0102)
+	bodyType := uint8(TemperatureTypeBody)
+	timestamp := time.Date(2020, time.March, 1, 10, 20, 30, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		uuid     string
+		value    []byte
+		expected interface{}
+	}{
+		{"device name", "2A00", []byte("sensor"), "sensor"},
+		{"manufacturer", "2A29", []byte("ACME"), "ACME"},
+		{"battery level", "2A19", []byte{87}, uint8(87)},
+		{"body sensor location", "2A38", []byte{standard.BodySensorLocationChest}, standard.BodySensorLocationChest},
+		{
+			"heart rate uint8",
+			"2A37",
+			[]byte{0x06, 72},
+			standard.HeartRateMeasurement{HeartRate: 72, SensorContact: &contact},
+		},
+		{
+			"heart rate uint16 energy rr",
+			"2A37",
+			[]byte{0x19, 0x2c, 0x01, 0x02, 0x01, 0x00, 0x04},
+			standard.HeartRateMeasurement{HeartRate: 300, EnergyExpended: &energy, RRIntervals: []uint16{1024}},
+		},
+		{
+			"current time",
+			"2A2B",
+			[]byte{0xe4, 0x07, 3, 1, 10, 20, 30, 7, 0, standard.AdjustReasonManualUpdate},
+			CurrentTime{timestamp, standard.AdjustReasonManualUpdate},
+		},
+		{
+			"temperature measurement",
+			"2A1C",
+			[]byte{0x06, 0x6c, 0x01, 0x00, 0xff, 0xe4, 0x07, 3, 1, 10, 20, 30, 2},
+			TemperatureMeasurement{Value: 36.4, Timestamp: &timestamp, Type: &bodyType},
+		},
+		{
+			"intermediate temperature fahrenheit",
+			"2A1E",
+			[]byte{0x01, 0xda, 0x03, 0x00, 0xff},
+			TemperatureMeasurement{Value: 98.6, Fahrenheit: true},
+		},
+		{
+			"csc wheel and crank",
+			"2A5B",
+			[]byte{0x03, 0x02, 0x01, 0x00, 0x00, 0x00, 0x04, 0x10, 0x00, 0x00, 0x02},
+			CSCMeasurement{
+				Wheel: &WheelRevolutionData{Revolutions: 258, LastEventTime: 1024},
+				Crank: &CrankRevolutionData{Revolutions: 16, LastEventTime: 512},
+			},
+		},
+		{
+			"csc crank",
+			"2A5B",
+			[]byte{0x02, 0x10, 0x00, 0x00, 0x02},
+			CSCMeasurement{Crank: &CrankRevolutionData{Revolutions: 16, LastEventTime: 512}},
+		},
+		{"temperature", "2A6E", []byte{0x28, 0x0a}, Temperature(26)},
+		{"negative temperature", "2A6E", []byte{0xe6, 0xfb}, Temperature(-10.5)},
+		{"humidity", "2A6F", []byte{0xc6, 0x11}, Humidity(45.5)},
+		{"pressure", "2A6D", []byte{0x02, 0x76, 0x0f, 0x00}, Pressure(101325)},
+		{"elevation", "2A6C", []byte{0x85, 0xff, 0xff}, Elevation(-1.23)},
+		{"uv index", "2A76", []byte{5}, UVIndex(5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Decode(tt.uuid, tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestDefaultRegistryInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		uuid  string
+		value []byte
+	}{
+		{"battery level", "2A19", []byte{}},
+		{"heart rate", "2A37", []byte{0x01, 72}},
+		{"current time", "2A2B", []byte{0xe4, 0x07, 3}},
+		{"temperature measurement", "2A1C", []byte{0x00, 0x6c, 0x01}},
+		{"temperature timestamp", "2A1C", []byte{0x02, 0x6c, 0x01, 0x00, 0xff, 0xe4}},
+		{"temperature type", "2A1C", []byte{0x04, 0x6c, 0x01, 0x00, 0xff}},
+		{"csc empty", "2A5B", []byte{}},
+		{"csc wheel", "2A5B", []byte{0x01, 0x02, 0x01}},
+		{"csc crank", "2A5B", []byte{0x03, 0x02, 0x01, 0x00, 0x00, 0x00, 0x04, 0x10}},
+		{"temperature", "2A6E", []byte{0x28}},
+		{"humidity", "2A6F", []byte{}},
+		{"pressure", "2A6D", []byte{0x02, 0x76}},
+		{"elevation", "2A6C", []byte{0x85, 0xff}},
+		{"uv index", "2A76", []byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.uuid, tt.value)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEnvironmentalUnknown(t *testing.T) {
+	temp, err := DecodeTemperature([]byte{0x00, 0x80})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, math.IsNaN(float64(temp)))
+
+	hum, err := DecodeHumidity([]byte{0xff, 0xff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, math.IsNaN(float64(hum)))
+}
@@ -0,0 +1,75 @@
+package decoder
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Temperature is an Environmental Sensing temperature in Celsius, NaN when unknown
+type Temperature float64
+
+// Humidity is an Environmental Sensing relative humidity in percent, NaN when unknown
+type Humidity float64
+
+// Pressure is an Environmental Sensing pressure in Pascal
+type Pressure float64
+
+// Elevation is an Environmental Sensing elevation in meters
+type Elevation float64
+
+// UVIndex is an Environmental Sensing UV index
+type UVIndex uint8
+
+// DecodeTemperature decode a Temperature (0x2A6E) value, in 0.01 degrees Celsius
+func DecodeTemperature(value []byte) (Temperature, error) {
+	err := checkLength("Temperature", value, 2)
+	if err != nil {
+		return 0, err
+	}
+	raw := binary.LittleEndian.Uint16(value)
+	if raw == 0x8000 {
+		return Temperature(math.NaN()), nil
+	}
+	return Temperature(float64(int16(raw)) / 100), nil
+}
+
+// DecodeHumidity decode a Humidity (0x2A6F) value, in 0.01 percent
+func DecodeHumidity(value []byte) (Humidity, error) {
+	err := checkLength("Humidity", value, 2)
+	if err != nil {
+		return 0, err
+	}
+	raw := binary.LittleEndian.Uint16(value)
+	if raw == 0xffff {
+		return Humidity(math.NaN()), nil
+	}
+	return Humidity(float64(raw) / 100), nil
+}
+
+// DecodePressure decode a Pressure (0x2A6D) value, in 0.1 Pascal
+func DecodePressure(value []byte) (Pressure, error) {
+	err := checkLength("Pressure", value, 4)
+	if err != nil {
+		return 0, err
+	}
+	return Pressure(float64(binary.LittleEndian.Uint32(value)) / 10), nil
+}
+
+// DecodeElevation decode an Elevation (0x2A6C) value, a signed 24 bit value in 0.01 meters
+func DecodeElevation(value []byte) (Elevation, error) {
+	err := checkLength("Elevation", value, 3)
+	if err != nil {
+		return 0, err
+	}
+	raw := int32(uint32(value[0])|uint32(value[1])<<8|uint32(value[2])<<16) << 8 >> 8
+	return Elevation(float64(raw) / 100), nil
+}
+
+// DecodeUVIndex decode a UV Index (0x2A76) value
+func DecodeUVIndex(value []byte) (UVIndex, error) {
+	err := checkLength("UV Index", value, 1)
+	if err != nil {
+		return 0, err
+	}
+	return UVIndex(value[0]), nil
+}
@@ -0,0 +1,65 @@
+package decoder
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// DecodeFloat decode a little endian IEEE-11073 32 bit FLOAT: a 24 bit signed
+// mantissa and an 8 bit signed exponent. NaN, NRes and the reserved values are
+// returned as NaN, the infinities as math.Inf
+func DecodeFloat(b []byte) (float64, error) {
+	err := checkLength("FLOAT", b, 4)
+	if err != nil {
+		return 0, err
+	}
+
+	raw := binary.LittleEndian.Uint32(b)
+	mantissa := int32(raw&0x00ffffff) << 8 >> 8
+	exponent := int8(raw >> 24)
+
+	switch mantissa {
+	case 0x007fffff, -0x00800000, -0x007fffff:
+		// NaN, NRes, reserved
+		return math.NaN(), nil
+	case 0x007ffffe:
+		return math.Inf(1), nil
+	case -0x007ffffe:
+		return math.Inf(-1), nil
+	}
+
+	return scale(float64(mantissa), int(exponent)), nil
+}
+
+// DecodeSFloat decode a little endian IEEE-11073 16 bit SFLOAT: a 12 bit signed
+// mantissa and a 4 bit signed exponent. Special values are handled as in DecodeFloat
+func DecodeSFloat(b []byte) (float64, error) {
+	err := checkLength("SFLOAT", b, 2)
+	if err != nil {
+		return 0, err
+	}
+
+	raw := binary.LittleEndian.Uint16(b)
+	mantissa := int16(raw<<4) >> 4
+	exponent := int8(raw>>8) >> 4
+
+	switch mantissa {
+	case 0x07ff, -0x0800, -0x07ff:
+		return math.NaN(), nil
+	case 0x07fe:
+		return math.Inf(1), nil
+	case -0x07fe:
+		return math.Inf(-1), nil
+	}
+
+	return scale(float64(mantissa), int(exponent)), nil
+}
+
+// scale return mantissa * 10^exponent, dividing for negative exponents to
+// keep values like 36.4 exact
+func scale(mantissa float64, exponent int) float64 {
+	if exponent < 0 {
+		return mantissa / math.Pow10(-exponent)
+	}
+	return mantissa * math.Pow10(exponent)
+}
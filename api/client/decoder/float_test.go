@@ -0,0 +1,80 @@
+package decoder
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    []byte
+		expected float64
+	}{
+		{"36.4", []byte{0x6c, 0x01, 0x00, 0xff}, 36.4},
+		{"negative", []byte{0x94, 0xfe, 0xff, 0xfe}, -3.64},
+		{"positive exponent", []byte{0x02, 0x00, 0x00, 0x03}, 2000},
+		{"+INF", []byte{0xfe, 0xff, 0x7f, 0x00}, math.Inf(1)},
+		{"-INF", []byte{0x02, 0x00, 0x80, 0x00}, math.Inf(-1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := DecodeFloat(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+
+	for _, nan := range [][]byte{
+		{0xff, 0xff, 0x7f, 0x00},
+		{0x00, 0x00, 0x80, 0x00},
+		{0x01, 0x00, 0x80, 0x00},
+	} {
+		v, err := DecodeFloat(nan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, math.IsNaN(v))
+	}
+
+	_, err := DecodeFloat([]byte{0x6c, 0x01, 0x00})
+	assert.Error(t, err)
+}
+
+func TestDecodeSFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    []byte
+		expected float64
+	}{
+		{"11.4", []byte{0x72, 0xf0}, 11.4},
+		{"negative", []byte{0xff, 0x0f}, -1},
+		{"positive exponent", []byte{0x78, 0x20}, 12000},
+		{"+INF", []byte{0xfe, 0x07}, math.Inf(1)},
+		{"-INF", []byte{0x02, 0x08}, math.Inf(-1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := DecodeSFloat(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+
+	for _, nan := range [][]byte{{0xff, 0x07}, {0x00, 0x08}, {0x01, 0x08}} {
+		v, err := DecodeSFloat(nan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, math.IsNaN(v))
+	}
+
+	_, err := DecodeSFloat([]byte{0x72})
+	assert.Error(t, err)
+}
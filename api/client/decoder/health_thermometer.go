@@ -0,0 +1,98 @@
+package decoder
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// Temperature Measurement flags
+const (
+	temperatureFlagFahrenheit uint8 = 0x01
+	temperatureFlagTimestamp  uint8 = 0x02
+	temperatureFlagType       uint8 = 0x04
+)
+
+// Temperature types of a TemperatureMeasurement
+const (
+	TemperatureTypeArmpit           uint8 = 1
+	TemperatureTypeBody             uint8 = 2
+	TemperatureTypeEar              uint8 = 3
+	TemperatureTypeFinger           uint8 = 4
+	TemperatureTypeGastroIntestinal uint8 = 5
+	TemperatureTypeMouth            uint8 = 6
+	TemperatureTypeRectum           uint8 = 7
+	TemperatureTypeToe              uint8 = 8
+	TemperatureTypeTympanum         uint8 = 9
+)
+
+// TemperatureMeasurement is a Health Thermometer Temperature Measurement
+// or Intermediate Temperature value
+type TemperatureMeasurement struct {
+	// Value in Celsius, or Fahrenheit when Fahrenheit is true
+	Value      float64
+	Fahrenheit bool
+	// Timestamp in local time, nil when not reported
+	Timestamp *time.Time
+	// Type one of the TemperatureType values, nil when not reported
+	Type *uint8
+}
+
+// DecodeTemperatureMeasurement decode a Temperature Measurement (0x2A1C) or
+// Intermediate Temperature (0x2A1E) value
+func DecodeTemperatureMeasurement(value []byte) (TemperatureMeasurement, error) {
+	m := TemperatureMeasurement{}
+
+	err := checkLength("Temperature Measurement", value, 5)
+	if err != nil {
+		return m, err
+	}
+
+	flags := value[0]
+	m.Fahrenheit = flags&temperatureFlagFahrenheit != 0
+	m.Value, err = DecodeFloat(value[1:5])
+	if err != nil {
+		return m, err
+	}
+	value = value[5:]
+
+	if flags&temperatureFlagTimestamp != 0 {
+		t, err := decodeDateTime(value)
+		if err != nil {
+			return m, err
+		}
+		m.Timestamp = &t
+		value = value[dateTimeLength:]
+	}
+
+	if flags&temperatureFlagType != 0 {
+		err = checkLength("Temperature Type", value, 1)
+		if err != nil {
+			return m, err
+		}
+		t := value[0]
+		m.Type = &t
+	}
+
+	return m, nil
+}
+
+// dateTimeLength is the length of a Date Time value
+const dateTimeLength = 7
+
+// decodeDateTime decode a Date Time (0x2A08) value in local time
+func decodeDateTime(value []byte) (time.Time, error) {
+	err := checkLength("Date Time", value, dateTimeLength)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(
+		int(binary.LittleEndian.Uint16(value)),
+		time.Month(value[2]),
+		int(value[3]),
+		int(value[4]),
+		int(value[5]),
+		int(value[6]),
+		0,
+		time.Local,
+	), nil
+}
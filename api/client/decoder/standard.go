@@ -0,0 +1,102 @@
+package decoder
+
+import (
+	"time"
+
+	"github.com/muka/go-bluetooth/api/standard"
+)
+
+// CurrentTime is a Current Time value
+type CurrentTime struct {
+	// Time in local time
+	Time time.Time
+	// AdjustReason a combination of the standard.AdjustReason flags
+	AdjustReason uint8
+}
+
+// DecodeCurrentTime decode a Current Time (0x2A2B) value
+func DecodeCurrentTime(value []byte) (CurrentTime, error) {
+	t, reason, err := standard.DecodeCurrentTime(value, time.Local)
+	if err != nil {
+		return CurrentTime{}, err
+	}
+	return CurrentTime{t, reason}, nil
+}
+
+// DecodeUint8 decode a single byte value, eg. Battery Level (0x2A19)
+func DecodeUint8(value []byte) (uint8, error) {
+	err := checkLength("uint8", value, 1)
+	if err != nil {
+		return 0, err
+	}
+	return value[0], nil
+}
+
+// DecodeString decode an UTF-8 string value, eg. Device Name (0x2A00)
+func DecodeString(value []byte) (string, error) {
+	return string(value), nil
+}
+
+// newDefaultRegistry create a registry with the decoders of the standard characteristics
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+
+	for _, uuid := range []string{
+		"2A00", // Device Name
+		standard.ModelNumberUUID,
+		standard.SerialNumberUUID,
+		standard.FirmwareRevisionUUID,
+		standard.HardwareRevisionUUID,
+		standard.SoftwareRevisionUUID,
+		standard.ManufacturerNameUUID,
+	} {
+		r.Register(uuid, func(value []byte) (interface{}, error) {
+			return DecodeString(value)
+		})
+	}
+
+	for _, uuid := range []string{
+		standard.BatteryLevelUUID,
+		standard.BodySensorLocationUUID,
+	} {
+		r.Register(uuid, func(value []byte) (interface{}, error) {
+			return DecodeUint8(value)
+		})
+	}
+
+	r.Register(standard.CurrentTimeUUID, func(value []byte) (interface{}, error) {
+		return DecodeCurrentTime(value)
+	})
+	r.Register(standard.HeartRateMeasurementUUID, func(value []byte) (interface{}, error) {
+		return standard.DecodeHeartRateMeasurement(value)
+	})
+
+	// Health Thermometer Temperature Measurement and Intermediate Temperature
+	for _, uuid := range []string{"2A1C", "2A1E"} {
+		r.Register(uuid, func(value []byte) (interface{}, error) {
+			return DecodeTemperatureMeasurement(value)
+		})
+	}
+
+	r.Register("2A5B", func(value []byte) (interface{}, error) {
+		return DecodeCSCMeasurement(value)
+	})
+
+	r.Register("2A6C", func(value []byte) (interface{}, error) {
+		return DecodeElevation(value)
+	})
+	r.Register("2A6D", func(value []byte) (interface{}, error) {
+		return DecodePressure(value)
+	})
+	r.Register("2A6E", func(value []byte) (interface{}, error) {
+		return DecodeTemperature(value)
+	})
+	r.Register("2A6F", func(value []byte) (interface{}, error) {
+		return DecodeHumidity(value)
+	})
+	r.Register("2A76", func(value []byte) (interface{}, error) {
+		return DecodeUVIndex(value)
+	})
+
+	return r
+}
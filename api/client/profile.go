@@ -0,0 +1,230 @@
+// Package client wraps the GATT services exposed by a remote device
+// in a Service -> Characteristic -> Descriptor tree
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile/device"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
+)
+
+// ErrNotFound is returned when a service, characteristic or descriptor is not available
+var ErrNotFound = errors.New("not found")
+
+// Profile is the GATT tree of a remote device
+type Profile struct {
+	device   *device.Device1
+	services []*Service
+}
+
+// GetProfile wait for the device services to be resolved and load its GATT tree.
+// The device should be connected, or about to connect, to resolve the services
+func GetProfile(ctx context.Context, dev *device.Device1) (*Profile, error) {
+
+	err := WaitServicesResolved(ctx, dev)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewProfile(dev)
+}
+
+// NewProfile load the GATT tree of a device from the ObjectManager data,
+// without waiting for the services to be resolved
+func NewProfile(dev *device.Device1) (*Profile, error) {
+
+	om, err := dev.Client().ObjectManager()
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := om.GetManagedObjects()
+	if err != nil {
+		return nil, fmt.Errorf("GetManagedObjects: %s", err)
+	}
+
+	conn := dev.Client().Config.Conn
+	prefix := string(dev.Path()) + "/"
+
+	servicePaths := []dbus.ObjectPath{}
+	charPaths := []dbus.ObjectPath{}
+	descrPaths := []dbus.ObjectPath{}
+	for path, ifaces := range objects {
+		if !strings.HasPrefix(string(path), prefix) {
+			continue
+		}
+		if _, ok := ifaces[gatt.GattService1Interface]; ok {
+			servicePaths = append(servicePaths, path)
+		}
+		if _, ok := ifaces[gatt.GattCharacteristic1Interface]; ok {
+			charPaths = append(charPaths, path)
+		}
+		if _, ok := ifaces[gatt.GattDescriptor1Interface]; ok {
+			descrPaths = append(descrPaths, path)
+		}
+	}
+
+	p := &Profile{
+		device:   dev,
+		services: []*Service{},
+	}
+
+	services := map[dbus.ObjectPath]*Service{}
+	for _, path := range sortPaths(servicePaths) {
+		s, err := gatt.NewGattService1WithConn(conn, path)
+		if err != nil {
+			return nil, fmt.Errorf("NewGattService1 %s: %s", path, err)
+		}
+		service := &Service{
+			GattService1: s,
+			profile:      p,
+			chars:        []*Char{},
+		}
+		services[path] = service
+		p.services = append(p.services, service)
+	}
+
+	chars := map[dbus.ObjectPath]*Char{}
+	for _, path := range sortPaths(charPaths) {
+		c, err := gatt.NewGattCharacteristic1WithConn(conn, path)
+		if err != nil {
+			return nil, fmt.Errorf("NewGattCharacteristic1 %s: %s", path, err)
+		}
+		service, ok := services[c.Properties.Service]
+		if !ok {
+			continue
+		}
+		char := &Char{
+			GattCharacteristic1: c,
+			service:             service,
+			descr:               []*Descr{},
+		}
+		chars[path] = char
+		service.chars = append(service.chars, char)
+	}
+
+	for _, path := range sortPaths(descrPaths) {
+		d, err := gatt.NewGattDescriptor1WithConn(conn, path)
+		if err != nil {
+			return nil, fmt.Errorf("NewGattDescriptor1 %s: %s", path, err)
+		}
+		char, ok := chars[d.Properties.Characteristic]
+		if !ok {
+			continue
+		}
+		char.descr = append(char.descr, &Descr{
+			GattDescriptor1: d,
+			char:            char,
+		})
+	}
+
+	return p, nil
+}
+
+// WaitServicesResolved block until the device ServicesResolved property is true
+// or the context is done
+func WaitServicesResolved(ctx context.Context, dev *device.Device1) error {
+
+	client := dev.Client()
+	ch, err := client.Register(dev.Path(), bluez.PropertiesInterface)
+	if err != nil {
+		return err
+	}
+	defer client.Unregister(dev.Path(), bluez.PropertiesInterface, ch)
+
+	resolved, err := dev.GetServicesResolved()
+	if err != nil {
+		return err
+	}
+	if resolved {
+		return nil
+	}
+
+	for {
+		select {
+		case sig, ok := <-ch:
+			if !ok {
+				return errors.New("Connection closed")
+			}
+			if sig == nil || sig.Name != bluez.PropertiesChanged || sig.Path != dev.Path() {
+				continue
+			}
+			if len(sig.Body) < 2 {
+				continue
+			}
+			changes, ok := sig.Body[1].(map[string]dbus.Variant)
+			if !ok {
+				continue
+			}
+			if v, ok := changes["ServicesResolved"]; ok {
+				if resolved, ok := v.Value().(bool); ok && resolved {
+					return nil
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Device return the device exposing the profile
+func (p *Profile) Device() *device.Device1 {
+	return p.device
+}
+
+// Services return the device services, sorted by object path
+func (p *Profile) Services() []*Service {
+	return p.services
+}
+
+// Service return the first service matching the UUID. UUIDs can be in the 16, 32 or 128 bit form
+func (p *Profile) Service(uuid string) (*Service, error) {
+	for _, s := range p.services {
+		if matchUUID(s.Properties.UUID, uuid) {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("Service %s: %w", uuid, ErrNotFound)
+}
+
+// Char return a characteristic by UUID, scoped to the service with serviceUUID
+func (p *Profile) Char(serviceUUID, charUUID string) (*Char, error) {
+	s, err := p.Service(serviceUUID)
+	if err != nil {
+		return nil, err
+	}
+	return s.Char(charUUID)
+}
+
+// Close the services, characteristics and descriptors clients
+func (p *Profile) Close() {
+	for _, s := range p.services {
+		for _, c := range s.chars {
+			for _, d := range c.descr {
+				d.Close()
+			}
+			c.Close()
+		}
+		s.Close()
+	}
+}
+
+// sortPaths sort paths for a stable tree order
+func sortPaths(paths []dbus.ObjectPath) []dbus.ObjectPath {
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i] < paths[j]
+	})
+	return paths
+}
+
+// matchUUID compare two UUIDs in any form
+func matchUUID(a, b string) bool {
+	return bluez.UUID(a).Equal(bluez.UUID(b))
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muka/go-bluetooth/bluez/fake"
+	"github.com/muka/go-bluetooth/bluez/profile/device"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	batteryService = "0000180f-0000-1000-8000-00805f9b34fb"
+	batteryLevel   = "00002a19-0000-1000-8000-00805f9b34fb"
+	deviceInfo     = "0000180a-0000-1000-8000-00805f9b34fb"
+	manufacturer   = "00002a29-0000-1000-8000-00805f9b34fb"
+	cccd           = "00002902-0000-1000-8000-00805f9b34fb"
+	userDescr      = "00002901-0000-1000-8000-00805f9b34fb"
+)
+
+func startFake(t *testing.T) *fake.Device {
+	_, fa := fake.StartAdapterT(t, "hci0", map[string]interface{}{"Powered": true})
+	fd, err := fa.AddDevice("11:22:33:44:55:66", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs, err := fd.AddService(batteryService, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc, err := fs.AddCharacteristic(batteryLevel, []string{"read", "notify"}, []byte{80})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fc.AddDescriptor(cccd, []string{"read", "write"}, []byte{0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fc.AddDescriptor(userDescr, []string{"read"}, []byte("level"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs2, err := fd.AddService(deviceInfo, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fs2.AddCharacteristic(manufacturer, []string{"read"}, []byte("acme"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// same characteristic UUID in another service
+	_, err = fs2.AddCharacteristic(batteryLevel, []string{"read"}, []byte{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fd
+}
+
+func TestGetProfile(t *testing.T) {
+	fd := startFake(t)
+
+	dev, err := device.NewDevice("hci0", "11:22:33:44:55:66")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		time.Sleep(time.Millisecond * 100)
+		fd.Set("Connected", true)
+		fd.Set("ServicesResolved", true)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+
+	p, err := GetProfile(ctx, dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	assert.Len(t, p.Services(), 2)
+
+	s, err := p.Service("180f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, s.Chars(), 1)
+	assert.Equal(t, p, s.Profile())
+
+	c, err := p.Char(batteryService, "2A19")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, s, c.Service())
+
+	value, err := c.ReadValue(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{80}, value)
+
+	decoded, err := c.ReadDecoded(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint8(80), decoded)
+
+	assert.Len(t, c.Descriptors(), 2)
+	d, err := c.Descr("2901")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, c, d.Char())
+
+	// lookup is scoped to the service
+	c2, err := p.Char(deviceInfo, batteryLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, c.Path(), c2.Path())
+
+	_, err = p.Char(deviceInfo, "2a00")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	_, err = p.Service("1800")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestGetProfileTimeout(t *testing.T) {
+	startFake(t)
+
+	dev, err := device.NewDevice("hci0", "11:22:33:44:55:66")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	_, err = GetProfile(ctx, dev)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestDeviceDescriptorList(t *testing.T) {
+	startFake(t)
+
+	dev, err := device.NewDevice("hci0", "11:22:33:44:55:66")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chars, err := dev.GetCharacteristicsList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, chars, 3)
+
+	descr, err := dev.GetDescriptorList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, descr, 2)
+
+	char, err := dev.GetCharByUUID(manufacturer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = dev.GetDescriptors(char)
+	assert.Error(t, err)
+}
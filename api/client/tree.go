@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/muka/go-bluetooth/api/client/decoder"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
+)
+
+// Service is a remote GATT service
+type Service struct {
+	*gatt.GattService1
+	profile *Profile
+	chars   []*Char
+}
+
+// Profile return the profile the service belongs to
+func (s *Service) Profile() *Profile {
+	return s.profile
+}
+
+// Chars return the service characteristics, sorted by object path
+func (s *Service) Chars() []*Char {
+	return s.chars
+}
+
+// Char return the first characteristic of the service matching the UUID
+func (s *Service) Char(uuid string) (*Char, error) {
+	for _, c := range s.chars {
+		if matchUUID(c.Properties.UUID, uuid) {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("Characteristic %s: %w", uuid, ErrNotFound)
+}
+
+// Char is a remote GATT characteristic
+type Char struct {
+	*gatt.GattCharacteristic1
+	service *Service
+	descr   []*Descr
+}
+
+// Service return the service exposing the characteristic
+func (c *Char) Service() *Service {
+	return c.service
+}
+
+// Descriptors return the characteristic descriptors, sorted by object path
+func (c *Char) Descriptors() []*Descr {
+	return c.descr
+}
+
+// Descr return the first descriptor of the characteristic matching the UUID
+func (c *Char) Descr(uuid string) (*Descr, error) {
+	for _, d := range c.descr {
+		if matchUUID(d.Properties.UUID, uuid) {
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("Descriptor %s: %w", uuid, ErrNotFound)
+}
+
+// ReadDecoded read the characteristic value and convert it with the decoder
+// registered for its UUID in decoder.DefaultRegistry
+func (c *Char) ReadDecoded(ctx context.Context) (interface{}, error) {
+	value, err := c.ReadValueContext(ctx, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	return decoder.Decode(c.Properties.UUID, value)
+}
+
+// Descr is a remote GATT descriptor
+type Descr struct {
+	*gatt.GattDescriptor1
+	char *Char
+}
+
+// Char return the characteristic exposing the descriptor
+func (d *Descr) Char() *Char {
+	return d.char
+}
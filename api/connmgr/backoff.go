@@ -0,0 +1,47 @@
+package connmgr
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// backoff computes exponential delays with jitter
+type backoff struct {
+	min    time.Duration
+	max    time.Duration
+	jitter float64
+
+	lock sync.Mutex
+	rand *rand.Rand
+}
+
+func newBackoff(min, max time.Duration, jitter float64) *backoff {
+	return &backoff{
+		min:    min,
+		max:    max,
+		jitter: jitter,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// delay return the delay after attempt consecutive failures, starting from 1:
+// min doubled on each attempt up to max, then randomized by +/- jitter
+func (b *backoff) delay(attempt int) time.Duration {
+	d := b.min
+	for i := 1; i < attempt && d < b.max; i++ {
+		d *= 2
+	}
+	if d > b.max {
+		d = b.max
+	}
+	if b.jitter <= 0 {
+		return d
+	}
+
+	b.lock.Lock()
+	r := b.rand.Float64()
+	b.lock.Unlock()
+
+	return d + time.Duration(float64(d)*b.jitter*(2*r-1))
+}
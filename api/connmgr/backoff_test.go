@@ -0,0 +1,25 @@
+package connmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	b := newBackoff(time.Second, 10*time.Second, 0)
+	assert.Equal(t, time.Second, b.delay(1))
+	assert.Equal(t, 2*time.Second, b.delay(2))
+	assert.Equal(t, 8*time.Second, b.delay(4))
+	assert.Equal(t, 10*time.Second, b.delay(5))
+	assert.Equal(t, 10*time.Second, b.delay(100))
+}
+
+func TestBackoffJitter(t *testing.T) {
+	b := newBackoff(time.Second, time.Minute, 0.5)
+	for i := 0; i < 100; i++ {
+		d := b.delay(2)
+		assert.True(t, d >= time.Second && d <= 3*time.Second, "delay %s out of range", d)
+	}
+}
@@ -0,0 +1,643 @@
+// Package connmgr keeps a set of devices connected, reconnecting them with
+// exponential backoff and restoring their notification subscriptions
+package connmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/api/client"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile/adapter"
+	"github.com/muka/go-bluetooth/bluez/profile/device"
+	log "github.com/sirupsen/logrus"
+)
+
+// Default Options values
+const (
+	DefaultMaxConcurrent  = 2
+	DefaultMinBackoff     = time.Second
+	DefaultMaxBackoff     = time.Minute
+	DefaultJitter         = 0.2
+	DefaultConnectTimeout = 30 * time.Second
+	DefaultMinUptime      = 10 * time.Second
+	DefaultEventQueueSize = 64
+)
+
+// disconnectTimeout bounds the Disconnect call cancelling a timed out connection attempt
+var disconnectTimeout = 5 * time.Second
+
+var (
+	// ErrClosed is returned when using a closed Manager
+	ErrClosed = errors.New("connection manager closed")
+	// ErrNotManaged is returned for an address not added to the Manager
+	ErrNotManaged = errors.New("device not managed")
+)
+
+// Options configure a Manager, zero values are replaced by the defaults
+type Options struct {
+	// AdapterID the adapter to connect from, default to the default adapter
+	AdapterID string
+	// Conn the bus connection, nil to use the shared system bus connection
+	Conn *bluez.Conn
+	// MaxConcurrent limit the connection attempts in progress at the same time
+	MaxConcurrent int
+	// MinBackoff is the delay after the first failed attempt, doubled on each failure
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between attempts
+	MaxBackoff time.Duration
+	// Jitter randomize delays by +/- the given fraction. Set a negative value to disable it
+	Jitter float64
+	// ConnectTimeout is the maximum duration of a connection attempt
+	ConnectTimeout time.Duration
+	// MinUptime is the time a connection must stay up to reset the backoff, devices
+	// disconnecting earlier are reconnected after the next backoff delay
+	MinUptime time.Duration
+	// EventQueueSize is the Events buffer size. Events are dropped when the buffer is full
+	EventQueueSize int
+}
+
+// NotifyHandler receives the values notified by a device characteristic
+type NotifyHandler func(address string, value []byte)
+
+// Manager keeps the added devices connected
+type Manager struct {
+	options Options
+	backoff *backoff
+	// slots limit the concurrent connection attempts
+	slots  chan struct{}
+	events chan Event
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	lock    sync.Mutex
+	closed  bool
+	devices map[string]*managedDevice
+}
+
+// New create a Manager. Devices are connected once added with Add
+func New(options Options) (*Manager, error) {
+
+	if options.AdapterID == "" {
+		options.AdapterID = adapter.GetDefaultAdapterID()
+	}
+	if options.MaxConcurrent <= 0 {
+		options.MaxConcurrent = DefaultMaxConcurrent
+	}
+	if options.MinBackoff <= 0 {
+		options.MinBackoff = DefaultMinBackoff
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = DefaultMaxBackoff
+	}
+	if options.MaxBackoff < options.MinBackoff {
+		return nil, fmt.Errorf("options.MaxBackoff %s is lower than options.MinBackoff %s", options.MaxBackoff, options.MinBackoff)
+	}
+	if options.Jitter == 0 {
+		options.Jitter = DefaultJitter
+	}
+	if options.Jitter >= 1 {
+		return nil, fmt.Errorf("options.Jitter must be lower than 1, got %f", options.Jitter)
+	}
+	if options.ConnectTimeout <= 0 {
+		options.ConnectTimeout = DefaultConnectTimeout
+	}
+	if options.MinUptime <= 0 {
+		options.MinUptime = DefaultMinUptime
+	}
+	if options.EventQueueSize <= 0 {
+		options.EventQueueSize = DefaultEventQueueSize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Manager{
+		options: options,
+		backoff: newBackoff(options.MinBackoff, options.MaxBackoff, options.Jitter),
+		slots:   make(chan struct{}, options.MaxConcurrent),
+		events:  make(chan Event, options.EventQueueSize),
+		ctx:     ctx,
+		cancel:  cancel,
+		devices: map[string]*managedDevice{},
+	}
+	return m, nil
+}
+
+// Events return the connection events of the managed devices.
+// The channel is closed by Close
+func (m *Manager) Events() <-chan Event {
+	return m.events
+}
+
+// Add start managing a device, connecting it in background. Adding
+// an address already managed has no effect
+func (m *Manager) Add(address string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.closed {
+		return ErrClosed
+	}
+	if _, ok := m.devices[address]; ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	d := &managedDevice{
+		m:       m,
+		address: address,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	m.devices[address] = d
+
+	m.wg.Add(1)
+	go d.run()
+
+	return nil
+}
+
+// Remove stop managing a device and drop its subscriptions.
+// The device is left in its current connection state
+func (m *Manager) Remove(address string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	d, ok := m.devices[address]
+	if !ok {
+		return fmt.Errorf("%s: %w", address, ErrNotManaged)
+	}
+	delete(m.devices, address)
+	d.cancel()
+
+	return nil
+}
+
+// Addresses return the managed devices addresses
+func (m *Manager) Addresses() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	addresses := []string{}
+	for address := range m.devices {
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// Device return a managed device, nil if the address is not managed
+// or the device has not been found yet
+func (m *Manager) Device(address string) *device.Device1 {
+	m.lock.Lock()
+	d, ok := m.devices[address]
+	m.lock.Unlock()
+	if !ok {
+		return nil
+	}
+	return d.getDevice()
+}
+
+// Subscribe register fn to receive the notifications of a characteristic of a managed
+// device. The subscription is started as soon as the services are resolved and restored
+// on each reconnection. An empty serviceUUID matches the first characteristic with charUUID
+func (m *Manager) Subscribe(address string, serviceUUID, charUUID bluez.UUID, fn NotifyHandler) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.closed {
+		return ErrClosed
+	}
+	d, ok := m.devices[address]
+	if !ok {
+		return fmt.Errorf("%s: %w", address, ErrNotManaged)
+	}
+
+	sub := &subscription{
+		service: serviceUUID,
+		char:    charUUID,
+		fn:      fn,
+	}
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		d.addSubscription(sub)
+	}()
+
+	return nil
+}
+
+// Close stop managing all the devices and close the Events channel
+func (m *Manager) Close() {
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return
+	}
+	m.closed = true
+	m.devices = map[string]*managedDevice{}
+	m.lock.Unlock()
+
+	m.cancel()
+	m.wg.Wait()
+	close(m.events)
+}
+
+// emit queue an event, dropping it if the queue is full
+func (m *Manager) emit(ev Event) {
+	select {
+	case m.events <- ev:
+	default:
+		log.Warnf("connmgr: event queue full, dropping %s event of %s", ev.Type, ev.Address)
+	}
+}
+
+// connect run a connection attempt, waiting for a free slot
+func (m *Manager) connect(ctx context.Context, dev *device.Device1) error {
+
+	select {
+	case m.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-m.slots }()
+
+	connectCtx, cancel := context.WithTimeout(ctx, m.options.ConnectTimeout)
+	defer cancel()
+
+	err := dev.ConnectContext(connectCtx)
+	if err != nil && connectCtx.Err() != nil {
+		// cancel the pending connection on the daemon side, without
+		// holding the slot if the daemon does not answer
+		disconnectCtx, cancelDisconnect := context.WithTimeout(context.Background(), disconnectTimeout)
+		defer cancelDisconnect()
+		dev.DisconnectContext(disconnectCtx)
+		return fmt.Errorf("Connect: %s", connectCtx.Err())
+	}
+	return err
+}
+
+// connectDevice connect a device unknown to bluez with Adapter1.ConnectDevice, waiting for a free slot
+func (m *Manager) connectDevice(ctx context.Context, address string) error {
+
+	select {
+	case m.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-m.slots }()
+
+	a, err := adapter.NewAdapter1FromAdapterIDWithConn(m.options.Conn, m.options.AdapterID)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	connectCtx, cancel := context.WithTimeout(ctx, m.options.ConnectTimeout)
+	defer cancel()
+
+	_, err = a.ConnectDeviceContext(connectCtx, map[string]interface{}{
+		"Address": address,
+	})
+	if err != nil {
+		return fmt.Errorf("ConnectDevice: %s", err)
+	}
+	return nil
+}
+
+// subscription is a notification handler registered with Subscribe
+type subscription struct {
+	service bluez.UUID
+	char    bluez.UUID
+	fn      NotifyHandler
+}
+
+// session holds the GATT state of a connection with resolved services
+type session struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	profile *client.Profile
+}
+
+// managedDevice runs the connection loop of a device
+type managedDevice struct {
+	m       *Manager
+	address string
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	lock          sync.Mutex
+	dev           *device.Device1
+	subscriptions []*subscription
+	session       *session
+}
+
+func (d *managedDevice) getDevice() *device.Device1 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.dev
+}
+
+// run connect the device until the manager is closed or the device removed
+func (d *managedDevice) run() {
+	defer d.m.wg.Done()
+	defer d.close()
+
+	attempt := 0
+	for {
+		uptime, err := d.connection()
+		if d.ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			if uptime >= d.m.options.MinUptime {
+				// disconnected after a stable connection, reconnect right away
+				attempt = 0
+				continue
+			}
+			err = fmt.Errorf("Disconnected after %s", uptime)
+		}
+
+		attempt++
+		delay := d.m.backoff.delay(attempt)
+		log.Debugf("connmgr: %s attempt %d failed, retry in %s: %s", d.address, attempt, delay, err)
+		d.m.emit(Event{
+			Type:    EventConnectFailed,
+			Address: d.address,
+			Device:  d.getDevice(),
+			Err:     err,
+			Attempt: attempt,
+			Delay:   delay,
+		})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-d.ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// load return the device, looking it up when not cached. Devices unknown to
+// bluez, eg. never discovered or removed, are connected with Adapter1.ConnectDevice
+func (d *managedDevice) load() (*device.Device1, error) {
+	d.lock.Lock()
+	dev := d.dev
+	d.lock.Unlock()
+	if dev != nil {
+		return dev, nil
+	}
+
+	dev, err := device.NewDeviceWithConn(d.m.options.Conn, d.m.options.AdapterID, d.address)
+	if err != nil {
+		cerr := d.m.connectDevice(d.ctx, d.address)
+		if cerr != nil {
+			return nil, fmt.Errorf("NewDevice: %s, %s", err, cerr)
+		}
+		dev, err = device.NewDeviceWithConn(d.m.options.Conn, d.m.options.AdapterID, d.address)
+		if err != nil {
+			return nil, fmt.Errorf("NewDevice: %s", err)
+		}
+	}
+
+	d.lock.Lock()
+	d.dev = dev
+	d.lock.Unlock()
+	return dev, nil
+}
+
+// forget drop the cached device, it is looked up again on the next attempt
+func (d *managedDevice) forget(dev *device.Device1) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.dev == dev {
+		d.dev = nil
+		dev.Close()
+	}
+}
+
+// connection connect the device and follow its state until it disconnects, returning nil
+// and the connection uptime, or the device is removed. An error is returned if the device
+// cannot be connected or is removed from bluez
+func (d *managedDevice) connection() (time.Duration, error) {
+
+	dev, err := d.load()
+	if err != nil {
+		return 0, err
+	}
+
+	// watch for changes before connecting, not to miss any. InterfacesRemoved
+	// is delivered to the same channel once matched
+	c := dev.Client()
+	signals, err := c.Register(dev.Path(), bluez.PropertiesInterface)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Unregister(dev.Path(), bluez.PropertiesInterface, signals)
+	err = c.AddMatch("/", bluez.ObjectManagerInterface)
+	if err != nil {
+		return 0, err
+	}
+	defer c.RemoveMatch("/", bluez.ObjectManagerInterface)
+
+	connected, err := dev.GetConnected()
+	if err != nil {
+		// the device may have been removed
+		d.forget(dev)
+		return 0, err
+	}
+	if !connected {
+		err = d.m.connect(d.ctx, dev)
+		if err != nil && !errors.Is(err, bluez.ErrAlreadyConnected) {
+			return 0, err
+		}
+		connected, err = dev.GetConnected()
+		if err != nil {
+			d.forget(dev)
+			return 0, err
+		}
+		if !connected {
+			return 0, errors.New("Device disconnected while connecting")
+		}
+	}
+
+	connectedAt := time.Now()
+	d.m.emit(Event{Type: EventConnected, Address: d.address, Device: dev})
+	defer d.endSession()
+
+	resolved, err := dev.GetServicesResolved()
+	if err != nil {
+		// removed right after connecting
+		d.forget(dev)
+		d.m.emit(Event{Type: EventDisconnected, Address: d.address, Device: dev})
+		return 0, err
+	}
+	if resolved {
+		d.startSession(dev)
+	}
+
+	for {
+		select {
+		case sig, ok := <-signals:
+			if !ok {
+				return 0, errors.New("Connection closed")
+			}
+			if sig != nil && sig.Name == bluez.InterfacesRemoved && len(sig.Body) > 0 {
+				if path, ok := sig.Body[0].(dbus.ObjectPath); ok && path == dev.Path() {
+					d.endSession()
+					d.forget(dev)
+					d.m.emit(Event{Type: EventDisconnected, Address: d.address, Device: dev})
+					return 0, errors.New("Device removed")
+				}
+				continue
+			}
+			if sig == nil || sig.Name != bluez.PropertiesChanged || sig.Path != dev.Path() || len(sig.Body) < 2 {
+				continue
+			}
+			changes, ok := sig.Body[1].(map[string]dbus.Variant)
+			if !ok {
+				continue
+			}
+			if v, ok := changes["Connected"]; ok {
+				if connected, ok := v.Value().(bool); ok && !connected {
+					d.endSession()
+					d.m.emit(Event{Type: EventDisconnected, Address: d.address, Device: dev})
+					return time.Since(connectedAt), nil
+				}
+			}
+			if v, ok := changes["ServicesResolved"]; ok {
+				if resolved, ok := v.Value().(bool); ok {
+					if resolved {
+						d.startSession(dev)
+					} else {
+						d.endSession()
+					}
+				}
+			}
+		case <-d.ctx.Done():
+			return time.Since(connectedAt), nil
+		}
+	}
+}
+
+// startSession load the device GATT tree and start the subscriptions
+func (d *managedDevice) startSession(dev *device.Device1) {
+
+	// sessions are started and ended by the run goroutine only
+	d.lock.Lock()
+	started := d.session != nil
+	d.lock.Unlock()
+	if started {
+		return
+	}
+
+	profile, err := client.NewProfile(dev)
+	if err != nil {
+		d.m.emit(Event{Type: EventSubscribeFailed, Address: d.address, Device: dev, Err: err})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(d.ctx)
+	s := &session{
+		ctx:     ctx,
+		cancel:  cancel,
+		profile: profile,
+	}
+
+	d.lock.Lock()
+	d.session = s
+	subscriptions := make([]*subscription, len(d.subscriptions))
+	copy(subscriptions, d.subscriptions)
+	d.lock.Unlock()
+
+	d.m.emit(Event{Type: EventServicesResolved, Address: d.address, Device: dev})
+
+	for _, sub := range subscriptions {
+		d.subscribe(s, sub)
+	}
+}
+
+// endSession stop the subscriptions of the current session, if any
+func (d *managedDevice) endSession() {
+	d.lock.Lock()
+	s := d.session
+	d.session = nil
+	d.lock.Unlock()
+
+	if s == nil {
+		return
+	}
+	s.cancel()
+	s.profile.Close()
+}
+
+// addSubscription store a subscription, starting it if the services are resolved
+func (d *managedDevice) addSubscription(sub *subscription) {
+	d.lock.Lock()
+	d.subscriptions = append(d.subscriptions, sub)
+	s := d.session
+	d.lock.Unlock()
+
+	if s != nil {
+		d.subscribe(s, sub)
+	}
+}
+
+// subscribe start notifications for sub in session s, passing values to the handler
+// until the session ends
+func (d *managedDevice) subscribe(s *session, sub *subscription) {
+
+	char, err := findChar(s.profile, sub)
+	var values <-chan []byte
+	if err == nil {
+		values, err = char.Subscribe(s.ctx)
+	}
+	if err != nil {
+		d.m.emit(Event{
+			Type:    EventSubscribeFailed,
+			Address: d.address,
+			Device:  s.profile.Device(),
+			Err:     fmt.Errorf("Subscribe %s: %s", sub.char, err),
+		})
+		return
+	}
+
+	d.m.wg.Add(1)
+	go func() {
+		defer d.m.wg.Done()
+		for value := range values {
+			sub.fn(d.address, value)
+		}
+	}()
+}
+
+// close release the device client
+func (d *managedDevice) close() {
+	d.endSession()
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.dev != nil {
+		d.dev.Close()
+	}
+}
+
+// findChar return the characteristic of a subscription
+func findChar(p *client.Profile, sub *subscription) (*client.Char, error) {
+	if sub.service != "" {
+		return p.Char(string(sub.service), string(sub.char))
+	}
+	for _, s := range p.Services() {
+		c, err := s.Char(string(sub.char))
+		if err == nil {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("Characteristic %s: %w", sub.char, client.ErrNotFound)
+}
@@ -0,0 +1,356 @@
+package connmgr
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/fake"
+	"github.com/muka/go-bluetooth/bluez/profile"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	address1       = "11:22:33:44:55:66"
+	address2       = "11:22:33:44:55:77"
+	batteryService = bluez.UUID("180f")
+	batteryLevel   = bluez.UUID("2a19")
+)
+
+func startFake(t *testing.T) (*fake.Bluez, *fake.Adapter) {
+	b, fa := fake.StartAdapterT(t, "hci0", map[string]interface{}{"Powered": true})
+	return b, fa
+}
+
+func newManager(t *testing.T, b *fake.Bluez, options Options) *Manager {
+	conn, err := b.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	options.AdapterID = "hci0"
+	options.Conn = conn
+	options.MinBackoff = 10 * time.Millisecond
+	options.MaxBackoff = 50 * time.Millisecond
+	m, err := New(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// waitEvent return the next event of type evType, skipping the others
+func waitEvent(t *testing.T, m *Manager, evType EventType) Event {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case ev, ok := <-m.Events():
+			if !ok {
+				t.Fatal("Events closed")
+			}
+			if ev.Type == evType {
+				return ev
+			}
+		case <-timeout:
+			t.Fatalf("Event %s not received", evType)
+		}
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	b, fa := startFake(t)
+
+	fd, err := fa.AddDevice(address1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newManager(t, b, Options{})
+	defer m.Close()
+
+	err = m.Add(address1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev := waitEvent(t, m, EventConnected)
+	assert.Equal(t, address1, ev.Address)
+	assert.NotNil(t, ev.Device)
+	waitEvent(t, m, EventServicesResolved)
+	assert.True(t, fd.Get("Connected").(bool))
+	assert.NotNil(t, m.Device(address1))
+
+	// the remote device goes away
+	fd.Set("ServicesResolved", false)
+	fd.Set("Connected", false)
+
+	waitEvent(t, m, EventDisconnected)
+	waitEvent(t, m, EventConnected)
+	waitEvent(t, m, EventServicesResolved)
+	assert.True(t, fd.Get("Connected").(bool))
+
+	err = m.Remove(address1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Error(t, m.Remove(address1))
+	assert.Empty(t, m.Addresses())
+}
+
+func TestConnectBackoff(t *testing.T) {
+	b, fa := startFake(t)
+
+	fd, err := fa.AddDevice(address1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var failures int32 = 2
+	fd.OnCall("Connect", func() *dbus.Error {
+		if atomic.AddInt32(&failures, -1) >= 0 {
+			return &profile.ErrFailed
+		}
+		return nil
+	})
+
+	m := newManager(t, b, Options{Jitter: -1})
+	defer m.Close()
+
+	err = m.Add(address1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev := waitEvent(t, m, EventConnectFailed)
+	assert.Equal(t, 1, ev.Attempt)
+	assert.Equal(t, 10*time.Millisecond, ev.Delay)
+	assert.True(t, errors.Is(ev.Err, bluez.ErrFailed))
+
+	ev = waitEvent(t, m, EventConnectFailed)
+	assert.Equal(t, 2, ev.Attempt)
+	assert.Equal(t, 20*time.Millisecond, ev.Delay)
+
+	waitEvent(t, m, EventConnected)
+}
+
+func TestConnectUnknownDevice(t *testing.T) {
+	b, fa := startFake(t)
+
+	m := newManager(t, b, Options{})
+	defer m.Close()
+
+	// not known to bluez, connected with Adapter1.ConnectDevice
+	err := m.Add(address1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := waitEvent(t, m, EventConnected)
+	assert.NotNil(t, ev.Device)
+	fd := fa.Device(address1)
+	if assert.NotNil(t, fd) {
+		assert.True(t, fd.Get("Connected").(bool))
+	}
+}
+
+func TestConnectUnknownDeviceFailed(t *testing.T) {
+	b, fa := startFake(t)
+
+	fa.OnCall("ConnectDevice", func() *dbus.Error {
+		return &profile.ErrNotSupported
+	})
+
+	m := newManager(t, b, Options{})
+	defer m.Close()
+
+	err := m.Add(address1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := waitEvent(t, m, EventConnectFailed)
+	assert.Error(t, ev.Err)
+	assert.Nil(t, ev.Device)
+}
+
+func TestShortConnectionBackoff(t *testing.T) {
+	b, fa := startFake(t)
+
+	fd, err := fa.AddDevice(address1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newManager(t, b, Options{Jitter: -1, MinUptime: time.Hour})
+	defer m.Close()
+
+	err = m.Add(address1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the link drops right after connecting, the backoff keeps increasing
+	for attempt := 1; attempt <= 2; attempt++ {
+		waitEvent(t, m, EventConnected)
+		fd.Set("Connected", false)
+		ev := waitEvent(t, m, EventConnectFailed)
+		assert.Equal(t, attempt, ev.Attempt)
+		assert.Error(t, ev.Err)
+	}
+}
+
+func TestDeviceRemoved(t *testing.T) {
+	b, fa := startFake(t)
+
+	fd, err := fa.AddDevice(address1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newManager(t, b, Options{})
+	defer m.Close()
+
+	err = m.Add(address1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitEvent(t, m, EventConnected)
+	waitEvent(t, m, EventServicesResolved)
+
+	derr := fa.RemoveDevice(fd.Path())
+	if derr != nil {
+		t.Fatal(derr)
+	}
+
+	// the device is looked up again and reconnected
+	waitEvent(t, m, EventDisconnected)
+	ev := waitEvent(t, m, EventConnected)
+	assert.NotNil(t, ev.Device)
+	fd = fa.Device(address1)
+	if assert.NotNil(t, fd) {
+		assert.True(t, fd.Get("Connected").(bool))
+	}
+}
+
+func TestMaxConcurrent(t *testing.T) {
+	b, fa := startFake(t)
+
+	var running, max int32
+	for _, address := range []string{address1, address2} {
+		fd, err := fa.AddDevice(address, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fd.OnCall("Connect", func() *dbus.Error {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				m := atomic.LoadInt32(&max)
+				if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+					break
+				}
+			}
+			time.Sleep(100 * time.Millisecond)
+			return nil
+		})
+	}
+
+	m := newManager(t, b, Options{MaxConcurrent: 1})
+	defer m.Close()
+
+	for _, address := range []string{address1, address2} {
+		err := m.Add(address)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitEvent(t, m, EventConnected)
+	waitEvent(t, m, EventConnected)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&max))
+}
+
+func TestResubscribe(t *testing.T) {
+	b, fa := startFake(t)
+
+	fd, err := fa.AddDevice(address1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs, err := fd.AddService(batteryService.String(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc, err := fs.AddCharacteristic(batteryLevel.String(), []string{"read", "notify"}, []byte{80})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newManager(t, b, Options{})
+	defer m.Close()
+
+	err = m.Add(address1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(chan []byte, 10)
+	err = m.Subscribe(address1, "", batteryLevel, func(address string, value []byte) {
+		values <- value
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, errors.Is(m.Subscribe(address2, "", batteryLevel, nil), ErrNotManaged))
+
+	notify := func(value byte) {
+		t.Helper()
+		timeout := time.After(2 * time.Second)
+		for {
+			// the subscription may not be active yet
+			if fc.Get("Notifying").(bool) && fc.Notify([]byte{value}) == nil {
+				select {
+				case v := <-values:
+					assert.Equal(t, []byte{value}, v)
+					return
+				case <-time.After(50 * time.Millisecond):
+				}
+			}
+			select {
+			case <-timeout:
+				t.Fatalf("Value %d not received", value)
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}
+
+	waitEvent(t, m, EventServicesResolved)
+	notify(81)
+
+	fd.Set("ServicesResolved", false)
+	fd.Set("Connected", false)
+	fc.Set("Notifying", false)
+	waitEvent(t, m, EventDisconnected)
+	waitEvent(t, m, EventServicesResolved)
+	notify(82)
+}
+
+func TestClose(t *testing.T) {
+	b, fa := startFake(t)
+
+	_, err := fa.AddDevice(address1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newManager(t, b, Options{})
+	err = m.Add(address1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitEvent(t, m, EventConnected)
+
+	m.Close()
+	for range m.Events() {
+	}
+	assert.Equal(t, ErrClosed, m.Add(address2))
+	m.Close()
+}
@@ -0,0 +1,55 @@
+package connmgr
+
+import (
+	"time"
+
+	"github.com/muka/go-bluetooth/bluez/profile/device"
+)
+
+// EventType is the type of a connection Event
+type EventType int
+
+// Connection events
+const (
+	// EventConnected the device connected
+	EventConnected EventType = iota
+	// EventServicesResolved the device services are resolved, subscriptions are active
+	EventServicesResolved
+	// EventDisconnected the device disconnected, a reconnection is scheduled
+	EventDisconnected
+	// EventConnectFailed a connection attempt failed or the connection dropped before
+	// Options.MinUptime, the next attempt starts after Delay
+	EventConnectFailed
+	// EventSubscribeFailed a notification subscription could not be restored
+	EventSubscribeFailed
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventConnected:
+		return "connected"
+	case EventServicesResolved:
+		return "services-resolved"
+	case EventDisconnected:
+		return "disconnected"
+	case EventConnectFailed:
+		return "connect-failed"
+	case EventSubscribeFailed:
+		return "subscribe-failed"
+	}
+	return "unknown"
+}
+
+// Event is a connection state change of a managed device
+type Event struct {
+	Type    EventType
+	Address string
+	// Device is nil until the device is found
+	Device *device.Device1
+	// Err the failure reason, for EventConnectFailed and EventSubscribeFailed
+	Err error
+	// Attempt the number of consecutive failed attempts, for EventConnectFailed
+	Attempt int
+	// Delay before the next attempt, for EventConnectFailed
+	Delay time.Duration
+}
@@ -5,7 +5,7 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-// Discover start device discovery
+// Discover start device discovery. Use Scan to receive the devices advertisement data
 func Discover(
 	a *adapter.Adapter1, filter *adapter.DiscoveryFilter,
 ) (
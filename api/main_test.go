@@ -0,0 +1,32 @@
+package api
+
+import (
+	"os"
+	"testing"
+
+	"github.com/muka/go-bluetooth/bluez/fake"
+	log "github.com/sirupsen/logrus"
+)
+
+// TestMain run the tests against a fake bluez daemon, if available
+func TestMain(m *testing.M) {
+
+	b, err := fake.Start()
+	if err != nil {
+		log.Warnf("Fake bluez not available, using system bus: %s", err)
+		os.Exit(m.Run())
+	}
+
+	_, err = b.AddAdapter(GetDefaultAdapterID(), map[string]interface{}{
+		"Address": "00:1A:7D:DA:71:13",
+		"Powered": true,
+	})
+	if err != nil {
+		b.Close()
+		log.Fatalf("Fake bluez setup: %s", err)
+	}
+
+	code := m.Run()
+	b.Close()
+	os.Exit(code)
+}
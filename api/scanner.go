@@ -0,0 +1,400 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile/adapter"
+	"github.com/muka/go-bluetooth/bluez/profile/device"
+	log "github.com/sirupsen/logrus"
+)
+
+// advertisementProperties are the Device1 properties updated by advertisements
+var advertisementProperties = map[string]bool{
+	"Name":             true,
+	"RSSI":             true,
+	"TxPower":          true,
+	"ManufacturerData": true,
+	"ServiceData":      true,
+	"UUIDs":            true,
+}
+
+// AdvertisementReport is the advertised state of a device, reported when
+// the device is found and when its advertisement data changes
+type AdvertisementReport struct {
+	Path        dbus.ObjectPath
+	Address     string
+	AddressType string
+	Name        string
+	RSSI        int16
+	// TxPower is nil if not advertised
+	TxPower          *int16
+	ManufacturerData map[uint16][]byte
+	ServiceData      map[bluez.UUID][]byte
+	UUIDs            []bluez.UUID
+	Timestamp        time.Time
+	// Added is set on the first report of a device during the scan
+	Added bool
+	// Changed lists the properties updated since the previous report
+	Changed []string
+}
+
+// sameData compare the advertised data of two reports, ignoring the RSSI
+func (r *AdvertisementReport) sameData(o *AdvertisementReport) bool {
+	return r.Name == o.Name &&
+		reflect.DeepEqual(r.TxPower, o.TxPower) &&
+		reflect.DeepEqual(r.ManufacturerData, o.ManufacturerData) &&
+		reflect.DeepEqual(r.ServiceData, o.ServiceData) &&
+		reflect.DeepEqual(r.UUIDs, o.UUIDs)
+}
+
+// ScanOptions configure a Scanner
+type ScanOptions struct {
+	// DiscoveryFilter is applied by bluez, nil to discover any device
+	DiscoveryFilter *adapter.DiscoveryFilter
+	// Filter drops the reports for which it returns false
+	Filter func(*AdvertisementReport) bool
+	// Dedup suppress reports whose data, RSSI excluded, did not change since
+	// the last report of the same device
+	Dedup bool
+	// DedupWindow report a duplicate anyway once the window elapsed since the
+	// last report of the device, as a presence notification. 0 suppress duplicates
+	DedupWindow time.Duration
+	// Timeout stop the scan after the duration, 0 to scan until Stop is called
+	Timeout time.Duration
+	// QueueSize is the Reports buffer size
+	QueueSize int
+}
+
+// scannedDevice is the state of a device seen by the Scanner
+type scannedDevice struct {
+	props    map[string]dbus.Variant
+	reported bool
+	last     *AdvertisementReport
+}
+
+// Scanner runs a discovery session, streaming the devices advertisements
+type Scanner struct {
+	adapter *adapter.Adapter1
+	options ScanOptions
+	conn    *bluez.Conn
+	om      *bluez.ObjectManager
+	prefix  string
+	match   string
+
+	signals chan *dbus.Signal
+	reports chan AdvertisementReport
+	done    chan struct{}
+	wg      sync.WaitGroup
+	stop    sync.Once
+
+	devices map[dbus.ObjectPath]*scannedDevice
+}
+
+// Scan start a discovery session on the adapter
+func Scan(a *adapter.Adapter1, options ScanOptions) (*Scanner, error) {
+
+	conn, err := a.Client().Conn()
+	if err != nil {
+		return nil, err
+	}
+	om, err := conn.ObjectManager()
+	if err != nil {
+		return nil, err
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = 32
+	}
+
+	s := &Scanner{
+		adapter: a,
+		options: options,
+		conn:    conn,
+		om:      om,
+		prefix:  string(a.Path()) + "/",
+		match: fmt.Sprintf(
+			"type='signal',interface='%s',member='PropertiesChanged',arg0='%s',path_namespace='%s'",
+			bluez.PropertiesInterface, device.Device1Interface, a.Path(),
+		),
+		reports: make(chan AdvertisementReport, options.QueueSize),
+		done:    make(chan struct{}),
+		devices: map[dbus.ObjectPath]*scannedDevice{},
+	}
+
+	err = s.start()
+	if err != nil {
+		s.release()
+		return nil, err
+	}
+
+	s.wg.Add(1)
+	go s.watch()
+
+	if options.Timeout > 0 {
+		go func() {
+			timer := time.NewTimer(options.Timeout)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				s.Stop()
+			case <-s.done:
+			}
+		}()
+	}
+
+	return s, nil
+}
+
+// start watch the devices and start the discovery
+func (s *Scanner) start() error {
+
+	err := s.adapter.SetPowered(true)
+	if err != nil {
+		return err
+	}
+
+	filterMap := make(map[string]interface{})
+	if s.options.DiscoveryFilter != nil {
+		filterMap = s.options.DiscoveryFilter.ToMap()
+	}
+	err = s.adapter.SetDiscoveryFilter(filterMap)
+	if err != nil {
+		return err
+	}
+
+	err = s.conn.DBus().BusObject().Call("org.freedesktop.DBus.AddMatch", 0, s.match).Err
+	if err != nil {
+		return fmt.Errorf("AddMatch: %s", err)
+	}
+	s.signals, err = s.om.Register()
+	if err != nil {
+		return err
+	}
+
+	// devices already known are reported when an advertisement updates them
+	objects, err := s.om.GetManagedObjects()
+	if err != nil {
+		return fmt.Errorf("GetManagedObjects: %s", err)
+	}
+	for path, ifaces := range objects {
+		if props, ok := ifaces[device.Device1Interface]; ok && s.owns(path) {
+			s.devices[path] = &scannedDevice{props: props}
+		}
+	}
+
+	return s.adapter.StartDiscovery()
+}
+
+// release remove the signal registrations
+func (s *Scanner) release() {
+	s.conn.DBus().BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, s.match)
+	if s.signals != nil {
+		s.om.Unregister(s.signals)
+	}
+}
+
+// Reports return the advertisement reports. The channel is closed when the scan stops
+func (s *Scanner) Reports() <-chan AdvertisementReport {
+	return s.reports
+}
+
+// Stop the discovery and close the Reports channel
+func (s *Scanner) Stop() {
+	s.stop.Do(func() {
+		close(s.done)
+		s.wg.Wait()
+
+		err := s.adapter.StopDiscovery()
+		if err != nil {
+			log.Warnf("Error stopping discovery: %s", err)
+		}
+		s.release()
+		close(s.reports)
+	})
+}
+
+// owns report if path is a device of the scanned adapter
+func (s *Scanner) owns(path dbus.ObjectPath) bool {
+	return strings.HasPrefix(string(path), s.prefix)
+}
+
+// watch process the signals until the scan stops
+func (s *Scanner) watch() {
+	defer s.wg.Done()
+	for {
+		select {
+		case sig, ok := <-s.signals:
+			if !ok {
+				return
+			}
+			if sig == nil {
+				continue
+			}
+			report := s.handle(sig)
+			if report == nil {
+				continue
+			}
+			select {
+			case s.reports <- *report:
+			case <-s.done:
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// handle update the devices state from a signal, returning a report to emit if any
+func (s *Scanner) handle(sig *dbus.Signal) *AdvertisementReport {
+
+	switch sig.Name {
+	case bluez.InterfacesAdded:
+		if len(sig.Body) < 2 {
+			return nil
+		}
+		path, ok := sig.Body[0].(dbus.ObjectPath)
+		if !ok || !s.owns(path) {
+			return nil
+		}
+		ifaces, ok := sig.Body[1].(map[string]map[string]dbus.Variant)
+		if !ok {
+			return nil
+		}
+		props, ok := ifaces[device.Device1Interface]
+		if !ok {
+			return nil
+		}
+		d := &scannedDevice{props: props}
+		s.devices[path] = d
+		return s.report(path, d, nil)
+
+	case bluez.InterfacesRemoved:
+		if len(sig.Body) < 2 {
+			return nil
+		}
+		path, ok := sig.Body[0].(dbus.ObjectPath)
+		if !ok {
+			return nil
+		}
+		ifaces, ok := sig.Body[1].([]string)
+		if !ok {
+			return nil
+		}
+		for _, iface := range ifaces {
+			if iface == device.Device1Interface {
+				delete(s.devices, path)
+			}
+		}
+		return nil
+
+	case bluez.PropertiesChanged:
+		if !s.owns(sig.Path) || len(sig.Body) < 2 {
+			return nil
+		}
+		if iface, ok := sig.Body[0].(string); !ok || iface != device.Device1Interface {
+			return nil
+		}
+		changes, ok := sig.Body[1].(map[string]dbus.Variant)
+		if !ok {
+			return nil
+		}
+
+		d, ok := s.devices[sig.Path]
+		if !ok {
+			d = &scannedDevice{props: map[string]dbus.Variant{}}
+			s.devices[sig.Path] = d
+		}
+		changed := []string{}
+		for name, value := range changes {
+			d.props[name] = value
+			if advertisementProperties[name] {
+				changed = append(changed, name)
+			}
+		}
+		if len(sig.Body) > 2 {
+			if invalidated, ok := sig.Body[2].([]string); ok {
+				for _, name := range invalidated {
+					delete(d.props, name)
+				}
+			}
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+		sort.Strings(changed)
+		return s.report(sig.Path, d, changed)
+	}
+
+	return nil
+}
+
+// report build the report of a device, applying the filter and the duplicates suppression
+func (s *Scanner) report(path dbus.ObjectPath, d *scannedDevice, changed []string) *AdvertisementReport {
+
+	r := newAdvertisementReport(path, d.props)
+	r.Added = !d.reported
+	r.Changed = changed
+
+	if s.options.Filter != nil && !s.options.Filter(r) {
+		return nil
+	}
+
+	if s.options.Dedup && d.last != nil && r.sameData(d.last) {
+		if s.options.DedupWindow <= 0 || r.Timestamp.Sub(d.last.Timestamp) < s.options.DedupWindow {
+			return nil
+		}
+	}
+
+	d.reported = true
+	d.last = r
+	return r
+}
+
+// newAdvertisementReport read a report from the device properties
+func newAdvertisementReport(path dbus.ObjectPath, props map[string]dbus.Variant) *AdvertisementReport {
+
+	r := &AdvertisementReport{
+		Path:             path,
+		ManufacturerData: map[uint16][]byte{},
+		ServiceData:      map[bluez.UUID][]byte{},
+		UUIDs:            []bluez.UUID{},
+		Timestamp:        time.Now(),
+	}
+
+	r.Address, _ = props["Address"].Value().(string)
+	r.AddressType, _ = props["AddressType"].Value().(string)
+	r.Name, _ = props["Name"].Value().(string)
+	r.RSSI, _ = props["RSSI"].Value().(int16)
+	if txPower, ok := props["TxPower"].Value().(int16); ok {
+		r.TxPower = &txPower
+	}
+
+	if data, ok := props["ManufacturerData"].Value().(map[uint16]dbus.Variant); ok {
+		for id, v := range data {
+			if b, ok := v.Value().([]byte); ok {
+				r.ManufacturerData[id] = b
+			}
+		}
+	}
+	if data, ok := props["ServiceData"].Value().(map[string]dbus.Variant); ok {
+		for uuid, v := range data {
+			if b, ok := v.Value().([]byte); ok {
+				r.ServiceData[bluez.UUID(bluez.UUID(uuid).String())] = b
+			}
+		}
+	}
+	if uuids, ok := props["UUIDs"].Value().([]string); ok {
+		for _, uuid := range uuids {
+			r.UUIDs = append(r.UUIDs, bluez.UUID(bluez.UUID(uuid).String()))
+		}
+	}
+
+	return r
+}
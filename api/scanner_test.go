@@ -0,0 +1,167 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/fake"
+	"github.com/muka/go-bluetooth/bluez/profile/adapter"
+	"github.com/stretchr/testify/assert"
+)
+
+func startScanFake(t *testing.T) (*fake.Bluez, *fake.Adapter, *adapter.Adapter1) {
+	b, fa := fake.StartAdapterT(t, "hci0", map[string]interface{}{"Powered": true})
+	conn, err := b.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := adapter.NewAdapter1FromAdapterIDWithConn(conn, "hci0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, fa, a
+}
+
+func receiveReport(t *testing.T, s *Scanner) AdvertisementReport {
+	t.Helper()
+	select {
+	case r, ok := <-s.Reports():
+		if !ok {
+			t.Fatal("Reports closed")
+		}
+		return r
+	case <-time.After(2 * time.Second):
+		t.Fatal("Report not received")
+		return AdvertisementReport{}
+	}
+}
+
+func noReport(t *testing.T, s *Scanner) {
+	t.Helper()
+	select {
+	case r := <-s.Reports():
+		t.Fatalf("Unexpected report %+v", r)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestScan(t *testing.T) {
+	_, fa, a := startScanFake(t)
+
+	s, err := Scan(a, ScanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+	assert.True(t, fa.Get("Discovering").(bool))
+
+	fd, err := fa.AddDevice("11:22:33:44:55:66", map[string]interface{}{
+		"Name": "sensor",
+		"RSSI": int16(-60),
+		"ManufacturerData": map[uint16]dbus.Variant{
+			0x004c: dbus.MakeVariant([]byte{1, 2}),
+		},
+		"ServiceData": map[string]dbus.Variant{
+			"0000FEAA-0000-1000-8000-00805F9B34FB": dbus.MakeVariant([]byte{3}),
+		},
+		"UUIDs": []string{"0000180F-0000-1000-8000-00805F9B34FB"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := receiveReport(t, s)
+	assert.True(t, r.Added)
+	assert.Equal(t, fd.Path(), r.Path)
+	assert.Equal(t, "11:22:33:44:55:66", r.Address)
+	assert.Equal(t, "sensor", r.Name)
+	assert.Equal(t, int16(-60), r.RSSI)
+	assert.Equal(t, []byte{1, 2}, r.ManufacturerData[0x004c])
+	assert.Equal(t, []byte{3}, r.ServiceData[bluez.UUID("0000feaa-0000-1000-8000-00805f9b34fb")])
+	assert.Equal(t, []bluez.UUID{"0000180f-0000-1000-8000-00805f9b34fb"}, r.UUIDs)
+
+	fd.Set("RSSI", int16(-70))
+	r = receiveReport(t, s)
+	assert.False(t, r.Added)
+	assert.Equal(t, []string{"RSSI"}, r.Changed)
+	assert.Equal(t, int16(-70), r.RSSI)
+	assert.Equal(t, "sensor", r.Name)
+
+	// not an advertisement property
+	fd.Set("Connected", true)
+	noReport(t, s)
+
+	s.Stop()
+	_, ok := <-s.Reports()
+	assert.False(t, ok)
+	assert.False(t, fa.Get("Discovering").(bool))
+}
+
+func TestScanDedup(t *testing.T) {
+	_, fa, a := startScanFake(t)
+
+	s, err := Scan(a, ScanOptions{
+		Dedup:       true,
+		DedupWindow: 300 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+
+	fd, err := fa.AddDevice("11:22:33:44:55:66", map[string]interface{}{"RSSI": int16(-60)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, receiveReport(t, s).Added)
+
+	// RSSI only changes are duplicates
+	fd.Set("RSSI", int16(-61))
+	noReport(t, s)
+
+	fd.Set("ManufacturerData", map[uint16]dbus.Variant{1: dbus.MakeVariant([]byte{1})})
+	r := receiveReport(t, s)
+	assert.Equal(t, []byte{1}, r.ManufacturerData[1])
+
+	// reported again once the window elapsed
+	time.Sleep(300 * time.Millisecond)
+	fd.Set("RSSI", int16(-62))
+	r = receiveReport(t, s)
+	assert.Equal(t, int16(-62), r.RSSI)
+}
+
+func TestScanFilterTimeout(t *testing.T) {
+	_, fa, a := startScanFake(t)
+
+	s, err := Scan(a, ScanOptions{
+		Filter: func(r *AdvertisementReport) bool {
+			return r.RSSI > -80
+		},
+		Timeout: 500 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+
+	_, err = fa.AddDevice("11:22:33:44:55:66", map[string]interface{}{"RSSI": int16(-90)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fa.AddDevice("11:22:33:44:55:77", map[string]interface{}{"RSSI": int16(-50)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "11:22:33:44:55:77", receiveReport(t, s).Address)
+
+	// the scan stops after the timeout
+	select {
+	case _, ok := <-s.Reports():
+		assert.False(t, ok)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Scan not stopped")
+	}
+	assert.False(t, fa.Get("Discovering").(bool))
+}
@@ -9,5 +9,8 @@ func (app *App) createAgent() (agent.Agent1Client, error) {
 
 // Expose app agent on DBus
 func (app *App) ExposeAgent(caps string, setAsDefaultAgent bool) error {
+	if app.Options.Conn != nil {
+		return agent.ExposeAgentWithConn(app.Options.Conn, app.agent, caps, setAsDefaultAgent)
+	}
 	return agent.ExposeAgent(app.DBusConn(), app.agent, caps, setAsDefaultAgent)
 }
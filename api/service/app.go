@@ -28,6 +28,8 @@ type AppOptions struct {
 	AgentSetAsDefault bool
 	UUIDSuffix        string
 	UUID              string
+	// Conn connection to bluez, the default system bus connection if nil
+	Conn *bluez.Conn
 }
 
 // NewApp initialize a new bluetooth service (app)
@@ -92,7 +94,7 @@ func (app *App) init() error {
 	// log.Tracef("Exposing %s", app.Path())
 
 	// log.Trace("Load adapter")
-	a, err := adapter.NewAdapter1FromAdapterID(app.adapterID)
+	a, err := adapter.NewAdapter1FromAdapterIDWithConn(app.Options.Conn, app.adapterID)
 	if err != nil {
 		return err
 	}
@@ -104,11 +106,14 @@ func (app *App) init() error {
 	}
 	app.agent = agent1
 
-	conn, err := dbus.SystemBus()
-	if err != nil {
-		return err
+	conn := app.Options.Conn
+	if conn == nil {
+		conn, err = bluez.DefaultConn(bluez.SystemBus)
+		if err != nil {
+			return err
+		}
 	}
-	app.conn = conn
+	app.conn = conn.DBus()
 
 	om, err := api.NewDBusObjectManager(app.DBusConn())
 	if err != nil {
@@ -119,13 +124,18 @@ func (app *App) init() error {
 	return err
 }
 
-// GenerateUUID generate a 128bit UUID
+// GenerateUUID generate a 128bit UUID in the canonical lower case form. 16 bit
+// UUIDs are prefixed with Options.UUID, 32 bit UUIDs completed with Options.UUIDSuffix
 func (app *App) GenerateUUID(uuidVal string) string {
+	// already a 128bit UUID
+	if len(uuidVal) == 36 {
+		return bluez.UUID(uuidVal).String()
+	}
 	base := app.Options.UUID
 	if len(uuidVal) == 8 {
 		base = ""
 	}
-	return base + uuidVal + app.Options.UUIDSuffix
+	return bluez.UUID(base + uuidVal + app.Options.UUIDSuffix).String()
 }
 
 // GetAdapter return the adapter in use
@@ -200,7 +210,7 @@ func (app *App) Run() (err error) {
 		return fmt.Errorf("ExposeAgent: %s", err)
 	}
 
-	gm, err := gatt.NewGattManager1FromAdapterID(app.adapterID)
+	gm, err := gatt.NewGattManager1FromAdapterIDWithConn(app.Options.Conn, app.adapterID)
 	if err != nil {
 		return err
 	}
@@ -217,7 +227,7 @@ func (app *App) Close() {
 
 	if app.agent != nil {
 
-		err := agent.RemoveAgent(app.agent)
+		err := agent.RemoveAgentWithConn(app.Options.Conn, app.agent)
 		if err != nil {
 			log.Warnf("RemoveAgent: %s", err)
 		}
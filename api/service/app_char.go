@@ -2,9 +2,11 @@ package service
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/godbus/dbus/v5"
 	"github.com/muka/go-bluetooth/api"
+	"github.com/muka/go-bluetooth/assigned/uuid"
 	"github.com/muka/go-bluetooth/bluez"
 	"github.com/muka/go-bluetooth/bluez/profile/gatt"
 	log "github.com/sirupsen/logrus"
@@ -13,19 +15,41 @@ import (
 type CharReadCallback func(c *Char, options map[string]interface{}) ([]byte, error)
 type CharWriteCallback func(c *Char, value []byte) ([]byte, error)
 
+// CharNotifyCallback is called when notifications are enabled or disabled
+type CharNotifyCallback func(c *Char)
+
+// CharAcquireCallback receives the socket acquired by a client with AcquireWrite or AcquireNotify,
+// return an error to reject the request
+type CharAcquireCallback func(c *Char, sock *gatt.Socket, options map[string]interface{}) error
+
 type Char struct {
 	UUID    string
 	app     *App
 	service *Service
 
+	// MaxLength maximum length of the value accepted by writes, MaxValueLength if 0
+	MaxLength uint16
+
 	path  dbus.ObjectPath
 	descr map[dbus.ObjectPath]*Descr
 
 	Properties *gatt.GattCharacteristic1Properties
 	iprops     *api.DBusProperties
 
-	readCallback  CharReadCallback
-	writeCallback CharWriteCallback
+	readCallback          CharReadRequestCallback
+	writeCallback         CharWriteRequestCallback
+	acquireWriteCallback  CharAcquireCallback
+	acquireNotifyCallback CharAcquireCallback
+
+	subscribeCallback   CharNotifyCallback
+	unsubscribeCallback CharNotifyCallback
+
+	notifyLock sync.Mutex
+	// notifyStop is closed when notifications are disabled, nil when not notifying
+	notifyStop chan struct{}
+	// confirm is closed when the pending indication is confirmed
+	confirm      chan struct{}
+	indicateLock sync.Mutex
 }
 
 func (s *Char) Path() dbus.ObjectPath {
@@ -143,20 +167,82 @@ func (s *Char) AddDescr(descr *Descr) error {
 		return err
 	}
 
-	log.Tracef("Added GATT Descriptor UUID=%s %s", descr.UUID, descr.Path())
+	log.Tracef("Added GATT Descriptor UUID=%s (%s) %s", descr.UUID, uuid.Name(descr.UUID), descr.Path())
 
 	err = s.App().ExportTree()
 	return err
 }
 
-// OnRead Set the Read callback, called when a client attempt to read
+// OnRead Set the Read callback, called when a client attempt to read.
+// fx returns the whole value, the read offset is applied to it
 func (s *Char) OnRead(fx CharReadCallback) *Char {
+	if fx == nil {
+		return s.OnReadRequest(nil)
+	}
+	return s.OnReadRequest(func(c *Char, req ReadRequest) ([]byte, error) {
+		b, err := fx(c, req.Options)
+		if err != nil {
+			return nil, err
+		}
+		return fragmentRead(b, req.Offset)
+	})
+}
+
+// OnWrite Set the Write callback, called when a client attempt to write.
+// fx receives the written fragment and returns the fragment to store at the write offset
+func (s *Char) OnWrite(fx CharWriteCallback) *Char {
+	if fx == nil {
+		return s.OnWriteRequest(nil)
+	}
+	return s.OnWriteRequest(func(c *Char, req WriteRequest, value []byte) ([]byte, error) {
+		b, err := fx(c, value[req.Offset:])
+		if err != nil {
+			return nil, err
+		}
+		return fragmentWrite(value, b, req.Offset), nil
+	})
+}
+
+// OnReadRequest Set the Read callback, called with the request options when a client attempt to read.
+// fx must return the value starting at req.Offset, the offset is not applied to the returned value
+func (s *Char) OnReadRequest(fx CharReadRequestCallback) *Char {
 	s.readCallback = fx
 	return s
 }
 
-// OnWrite Set the Write callback, called when a client attempt to write
-func (s *Char) OnWrite(fx CharWriteCallback) *Char {
+// OnWriteRequest Set the Write callback, called with the request options when a client attempt to write.
+// fx receives the current value with the fragment written at req.Offset and returns the value to store
+func (s *Char) OnWriteRequest(fx CharWriteRequestCallback) *Char {
 	s.writeCallback = fx
 	return s
 }
+
+// OnAcquireWrite Set the AcquireWrite callback, called when a client acquire a socket to
+// write without response. Set it before adding the char to expose the WriteAcquired property
+func (s *Char) OnAcquireWrite(fx CharAcquireCallback) *Char {
+	s.acquireWriteCallback = fx
+	s.Properties.WriteAcquired = fx != nil
+	return s
+}
+
+// OnAcquireNotify Set the AcquireNotify callback, called when a client acquire a socket to
+// receive notifications. Set it before adding the char to expose the NotifyAcquired property
+func (s *Char) OnAcquireNotify(fx CharAcquireCallback) *Char {
+	s.acquireNotifyCallback = fx
+	s.Properties.NotifyAcquired = fx != nil
+	return s
+}
+
+// OnSubscribe Set the callback called when notifications are enabled. bluez
+// enables notifications when the first client subscribes
+func (s *Char) OnSubscribe(fx CharNotifyCallback) *Char {
+	s.subscribeCallback = fx
+	return s
+}
+
+// OnUnsubscribe Set the callback called when notifications are disabled. bluez
+// disables notifications when the last client unsubscribes or disconnects
+func (s *Char) OnUnsubscribe(fx CharNotifyCallback) *Char {
+	s.unsubscribeCallback = fx
+	return s
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
+)
+
+// ErrNotNotifying is returned by Notify and Indicate when notifications are not enabled
+var ErrNotNotifying = errors.New("Notifications are not enabled")
+
+// ErrIndicateNotSupported is returned by Indicate when the char has not the indicate flag
+var ErrIndicateNotSupported = errors.New("Indications are not supported")
+
+// Notifying return true if a client enabled notifications
+func (s *Char) Notifying() bool {
+	s.notifyLock.Lock()
+	defer s.notifyLock.Unlock()
+	return s.notifyStop != nil
+}
+
+// Notify update the characteristic value, sending it to the subscribed clients
+func (s *Char) Notify(value []byte) error {
+	if !s.Notifying() {
+		return ErrNotNotifying
+	}
+	return s.setValue(value)
+}
+
+// Indicate update the characteristic value, sending it to the subscribed clients, and
+// wait for bluez to confirm the indication was received. A single indication is sent
+// at a time, concurrent calls wait for the pending one to complete
+func (s *Char) Indicate(ctx context.Context, value []byte) error {
+
+	if !s.hasFlag(gatt.FlagCharacteristicIndicate) {
+		return ErrIndicateNotSupported
+	}
+
+	s.indicateLock.Lock()
+	defer s.indicateLock.Unlock()
+
+	s.notifyLock.Lock()
+	stop := s.notifyStop
+	if stop == nil {
+		s.notifyLock.Unlock()
+		return ErrNotNotifying
+	}
+	confirm := make(chan struct{})
+	s.confirm = confirm
+	s.notifyLock.Unlock()
+
+	defer func() {
+		s.notifyLock.Lock()
+		if s.confirm == confirm {
+			s.confirm = nil
+		}
+		s.notifyLock.Unlock()
+	}()
+
+	err := s.setValue(value)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-confirm:
+		return nil
+	case <-stop:
+		return ErrNotNotifying
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetValue update the characteristic value, sending it to the subscribed clients
+// when notifications are enabled
+func (s *Char) SetValue(value []byte) error {
+	return s.setValue(value)
+}
+
+// setValue update the Value property, emitting PropertiesChanged
+func (s *Char) setValue(value []byte) error {
+	s.Properties.Lock()
+	defer s.Properties.Unlock()
+
+	s.Properties.Value = value
+	if s.iprops == nil || s.iprops.Instance() == nil {
+		return nil
+	}
+	derr := s.iprops.Instance().Set(s.Interface(), "Value", dbus.MakeVariant(value))
+	if derr != nil {
+		return derr
+	}
+	return nil
+}
+
+// setNotifying update the Notifying state, return true if it changed
+func (s *Char) setNotifying(notifying bool) bool {
+	s.notifyLock.Lock()
+	defer s.notifyLock.Unlock()
+
+	if (s.notifyStop != nil) == notifying {
+		return false
+	}
+
+	if notifying {
+		s.notifyStop = make(chan struct{})
+	} else {
+		close(s.notifyStop)
+		s.notifyStop = nil
+	}
+
+	s.Properties.Notifying = notifying
+	if s.iprops != nil && s.iprops.Instance() != nil {
+		s.iprops.Instance().SetMust(s.Interface(), "Notifying", notifying)
+	}
+
+	return true
+}
+
+// hasFlag check if the char has a flag
+func (s *Char) hasFlag(flag string) bool {
+	for _, f := range s.Properties.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
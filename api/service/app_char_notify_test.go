@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
+	"github.com/stretchr/testify/assert"
+)
+
+// startCharApp expose a char on a fake bluez, returning it with a connection acting as bluez.
+// setup is called before exposing the char
+func startCharApp(t *testing.T, setup func(c *Char), flags ...string) (*Char, dbus.BusObject, chan *dbus.Signal) {
+	b, app := createFakeApp(t)
+
+	s, err := app.NewService("2233")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = app.AddService(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := s.NewChar("3344")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Properties.Flags = flags
+	if setup != nil {
+		setup(c)
+	}
+	err = s.AddChar(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote, err := b.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := fmt.Sprintf("type='signal',interface='%s',path='%s'", bluez.PropertiesInterface, c.Path())
+	err = remote.DBus().BusObject().Call("org.freedesktop.DBus.AddMatch", 0, rule).Err
+	if err != nil {
+		t.Fatal(err)
+	}
+	signals := make(chan *dbus.Signal, 10)
+	remote.DBus().Signal(signals)
+
+	obj := remote.DBus().Object(app.DBusConn().Names()[0], c.Path())
+
+	return c, obj, signals
+}
+
+// receiveValue wait for a Value change
+func receiveValue(t *testing.T, signals chan *dbus.Signal) []byte {
+	t.Helper()
+	timeout := time.After(time.Second * 2)
+	for {
+		select {
+		case sig := <-signals:
+			if sig.Name != bluez.PropertiesChanged || len(sig.Body) < 2 {
+				continue
+			}
+			changes, ok := sig.Body[1].(map[string]dbus.Variant)
+			if !ok {
+				continue
+			}
+			if v, ok := changes["Value"]; ok {
+				return v.Value().([]byte)
+			}
+		case <-timeout:
+			t.Fatal("Value not received")
+			return nil
+		}
+	}
+}
+
+func TestCharNotify(t *testing.T) {
+	subscribed := make(chan bool, 2)
+	c, obj, signals := startCharApp(t, func(c *Char) {
+		c.OnSubscribe(func(c *Char) {
+			subscribed <- true
+		})
+		c.OnUnsubscribe(func(c *Char) {
+			subscribed <- false
+		})
+	}, gatt.FlagCharacteristicNotify)
+
+	assert.Equal(t, ErrNotNotifying, c.Notify([]byte{1}))
+
+	err := obj.Call(gatt.GattCharacteristic1Interface+".StartNotify", 0).Err
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, <-subscribed)
+	assert.True(t, c.Notifying())
+
+	notifying, err := obj.GetProperty(gatt.GattCharacteristic1Interface + ".Notifying")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, true, notifying.Value())
+
+	err = c.Notify([]byte{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{2}, receiveValue(t, signals))
+
+	err = obj.Call(gatt.GattCharacteristic1Interface+".StopNotify", 0).Err
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, <-subscribed)
+	assert.False(t, c.Notifying())
+	assert.Equal(t, ErrNotNotifying, c.Notify([]byte{3}))
+}
+
+func TestCharNotifyNotSupported(t *testing.T) {
+	c, obj, _ := startCharApp(t, nil, gatt.FlagCharacteristicRead)
+
+	err := obj.Call(gatt.GattCharacteristic1Interface+".StartNotify", 0).Err
+	assert.Error(t, err)
+	assert.False(t, c.Notifying())
+	assert.Equal(t, ErrIndicateNotSupported, c.Indicate(context.Background(), []byte{1}))
+}
+
+func TestCharIndicate(t *testing.T) {
+	c, obj, signals := startCharApp(t, nil, gatt.FlagCharacteristicIndicate)
+
+	err := obj.Call(gatt.GattCharacteristic1Interface+".StartNotify", 0).Err
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := make(chan error, 1)
+	go func() {
+		res <- c.Indicate(context.Background(), []byte{1})
+	}()
+
+	assert.Equal(t, []byte{1}, receiveValue(t, signals))
+	select {
+	case <-res:
+		t.Fatal("Indicate returned before confirmation")
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	err = obj.Call(gatt.GattCharacteristic1Interface+".Confirm", 0).Err
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, <-res)
+
+	// not confirmed before the timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, c.Indicate(ctx, []byte{2}))
+	assert.Equal(t, []byte{2}, receiveValue(t, signals))
+
+	// disabling notifications aborts the pending indication
+	go func() {
+		res <- c.Indicate(context.Background(), []byte{3})
+	}()
+	assert.Equal(t, []byte{3}, receiveValue(t, signals))
+	err = obj.Call(gatt.GattCharacteristic1Interface+".StopNotify", 0).Err
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ErrNotNotifying, <-res)
+}
@@ -2,15 +2,27 @@ package service
 
 import (
 	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez/profile"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
 	log "github.com/sirupsen/logrus"
 )
 
 // Confirm This method doesn't expect a reply so it is just a
-// confirmation that value was received.
+// confirmation that value was received. It completes the pending
+// Indicate call.
 //
 // Possible Errors: org.bluez.Error.Failed
 func (s *Char) Confirm() *dbus.Error {
 	log.Debug("Char.Confirm")
+
+	s.notifyLock.Lock()
+	defer s.notifyLock.Unlock()
+
+	if s.confirm != nil {
+		close(s.confirm)
+		s.confirm = nil
+	}
+
 	return nil
 }
 
@@ -23,6 +35,15 @@ func (s *Char) Confirm() *dbus.Error {
 // 		 org.bluez.Error.NotSupported
 func (s *Char) StartNotify() *dbus.Error {
 	log.Debug("Char.StartNotify")
+
+	if !s.hasFlag(gatt.FlagCharacteristicNotify) && !s.hasFlag(gatt.FlagCharacteristicIndicate) {
+		return &profile.ErrNotSupported
+	}
+
+	if s.setNotifying(true) && s.subscribeCallback != nil {
+		s.subscribeCallback(s)
+	}
+
 	return nil
 }
 
@@ -34,6 +55,11 @@ func (s *Char) StartNotify() *dbus.Error {
 // Possible Errors: org.bluez.Error.Failed
 func (s *Char) StopNotify() *dbus.Error {
 	log.Debug("Char.StopNotify")
+
+	if s.setNotifying(false) && s.unsubscribeCallback != nil {
+		s.unsubscribeCallback(s)
+	}
+
 	return nil
 }
 
@@ -54,14 +80,16 @@ func (s *Char) ReadValue(options map[string]interface{}) ([]byte, *dbus.Error) {
 
 	log.Debug("Characteristic.ReadValue")
 	if s.readCallback != nil {
-		b, err := s.readCallback(s, options)
+		b, err := s.readCallback(s, NewReadRequest(options))
 		if err != nil {
-			return nil, dbus.MakeFailedError(err)
+			return nil, callbackError(err)
 		}
 		return b, nil
 	}
 
-	return s.Properties.Value, nil
+	s.Properties.Lock()
+	defer s.Properties.Unlock()
+	return readOffset(s.Properties.Value, NewReadRequest(options).Offset)
 }
 
 //WriteValue Issues a request to write the value of the
@@ -78,27 +106,100 @@ func (s *Char) ReadValue(options map[string]interface{}) ([]byte, *dbus.Error) {
 // 		 org.bluez.Error.InProgress
 // 		 org.bluez.Error.NotPermitted
 // 		 org.bluez.Error.InvalidValueLength
+// 		 org.bluez.Error.InvalidOffset
 // 		 org.bluez.Error.NotAuthorized
 // 		 org.bluez.Error.NotSupported
 func (s *Char) WriteValue(value []byte, options map[string]interface{}) *dbus.Error {
 
 	log.Trace("Characteristic.WriteValue")
 
-	val := value
+	req := NewWriteRequest(options)
+
+	// merge the fragment written at offset, as for long and reliable writes
+	s.Properties.Lock()
+	val, derr := writeOffset(s.Properties.Value, value, req.Offset, s.MaxLength)
+	s.Properties.Unlock()
+	if derr != nil {
+		return derr
+	}
+
 	if s.writeCallback != nil {
 		log.Trace("Used write callback")
-		b, err := s.writeCallback(s, value)
+		b, err := s.writeCallback(s, req, val)
 		val = b
 		if err != nil {
-			return dbus.MakeFailedError(err)
+			return callbackError(err)
 		}
 	} else {
 		log.Trace("Store directly to value (no callback)")
 	}
 
-	// TODO update on Properties interface
+	// authorization only, the value is written by a following request
+	if req.PrepareAuthorize {
+		return nil
+	}
+
+	s.Properties.Lock()
+	defer s.Properties.Unlock()
 	s.Properties.Value = val
-	err := s.iprops.Instance().Set(s.Interface(), "Value", dbus.MakeVariant(value))
+	err := s.iprops.Instance().Set(s.Interface(), "Value", dbus.MakeVariant(val))
 
 	return err
 }
+
+// AcquireWrite Acquire file descriptor and MTU for writing. The
+// socket is passed to the OnAcquireWrite callback, packets written
+// by the client are received reading from it.
+//
+// Possible options: "device": Object Device (Server only)
+// 			"mtu": Exchanged MTU (Server only)
+// 			"link": Link type (Server only)
+//
+// Possible Errors: org.bluez.Error.Failed
+// 		 org.bluez.Error.NotSupported
+func (s *Char) AcquireWrite(options map[string]interface{}) (dbus.UnixFD, uint16, *dbus.Error) {
+	log.Trace("Characteristic.AcquireWrite")
+	return s.acquire(s.acquireWriteCallback, options)
+}
+
+// AcquireNotify Acquire file descriptor and MTU for notify. The
+// socket is passed to the OnAcquireNotify callback, packets written
+// to it are sent as notifications. Notifications stop when the
+// client closes the socket.
+//
+// Possible options: "device": Object Device (Server only)
+// 			"mtu": Exchanged MTU (Server only)
+// 			"link": Link type (Server only)
+//
+// Possible Errors: org.bluez.Error.Failed
+// 		 org.bluez.Error.NotSupported
+func (s *Char) AcquireNotify(options map[string]interface{}) (dbus.UnixFD, uint16, *dbus.Error) {
+	log.Trace("Characteristic.AcquireNotify")
+	return s.acquire(s.acquireNotifyCallback, options)
+}
+
+// acquire create a socket pair, passing the local end to the callback and returning the other to bluez
+func (s *Char) acquire(fx CharAcquireCallback, options map[string]interface{}) (dbus.UnixFD, uint16, *dbus.Error) {
+
+	if fx == nil {
+		return 0, 0, &profile.ErrNotSupported
+	}
+
+	mtu, ok := options["mtu"].(uint16)
+	if !ok {
+		mtu = gatt.DefaultMTU
+	}
+
+	sock, fd, err := gatt.NewSocketPair(mtu)
+	if err != nil {
+		return 0, 0, dbus.MakeFailedError(err)
+	}
+
+	err = fx(s, sock, options)
+	if err != nil {
+		sock.Close()
+		return 0, 0, dbus.MakeFailedError(err)
+	}
+
+	return fd, mtu, nil
+}
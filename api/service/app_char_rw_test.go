@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez/profile"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
+	"github.com/muka/go-bluetooth/props"
+	"github.com/stretchr/testify/assert"
+)
+
+// acquiredSocket wrap a copy of the fd returned to bluez, as it is closed after the reply is sent
+func acquiredSocket(t *testing.T, fd dbus.UnixFD, mtu uint16) *gatt.Socket {
+	dup, err := syscall.Dup(int(fd))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sock, err := gatt.NewSocket(dbus.UnixFD(dup), mtu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sock
+}
+
+func TestCharAcquireWrite(t *testing.T) {
+
+	c := &Char{
+		Properties: NewGattCharacteristic1Properties("3344"),
+	}
+
+	_, _, derr := c.AcquireWrite(map[string]interface{}{})
+	assert.Equal(t, profile.ErrNotSupported.Name, derr.Name)
+
+	socks := make(chan *gatt.Socket, 1)
+	c.OnAcquireWrite(func(c *Char, sock *gatt.Socket, options map[string]interface{}) error {
+		socks <- sock
+		return nil
+	})
+	assert.True(t, c.Properties.WriteAcquired)
+	assert.False(t, props.ParseProperties(c.Properties)["WriteAcquired"].Skip)
+	assert.True(t, props.ParseProperties(c.Properties)["NotifyAcquired"].Skip)
+
+	fd, mtu, derr := c.AcquireWrite(map[string]interface{}{"mtu": uint16(100)})
+	if derr != nil {
+		t.Fatal(derr)
+	}
+	assert.Equal(t, uint16(100), mtu)
+
+	remote := acquiredSocket(t, fd, mtu)
+	defer remote.Close()
+	sock := <-socks
+	defer sock.Close()
+	assert.Equal(t, uint16(100), sock.MTU())
+
+	_, err := remote.Write([]byte{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, sock.MTU())
+	n, err := sock.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{1, 2, 3}, buf[:n])
+}
+
+func TestCharAcquireNotify(t *testing.T) {
+
+	c := &Char{
+		Properties: NewGattCharacteristic1Properties("3344"),
+	}
+
+	c.OnAcquireNotify(func(c *Char, sock *gatt.Socket, options map[string]interface{}) error {
+		return errors.New("rejected")
+	})
+	_, _, derr := c.AcquireNotify(map[string]interface{}{})
+	assert.NotNil(t, derr)
+
+	socks := make(chan *gatt.Socket, 1)
+	c.OnAcquireNotify(func(c *Char, sock *gatt.Socket, options map[string]interface{}) error {
+		socks <- sock
+		return nil
+	})
+
+	fd, mtu, derr := c.AcquireNotify(map[string]interface{}{})
+	if derr != nil {
+		t.Fatal(derr)
+	}
+	assert.Equal(t, uint16(gatt.DefaultMTU), mtu)
+
+	remote := acquiredSocket(t, fd, mtu)
+	defer remote.Close()
+	sock := <-socks
+	defer sock.Close()
+
+	_, err := sock.Write([]byte{4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sock.Write(make([]byte, mtu+1))
+	assert.Equal(t, gatt.ErrMTUExceeded, err)
+
+	buf := make([]byte, mtu)
+	n, err := remote.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{4}, buf[:n])
+}
@@ -15,13 +15,16 @@ type Descr struct {
 	app  *App
 	char *Char
 
+	// MaxLength maximum length of the value accepted by writes, MaxValueLength if 0
+	MaxLength uint16
+
 	path dbus.ObjectPath
 
 	Properties *gatt.GattDescriptor1Properties
 	iprops     *api.DBusProperties
 
-	readCallback  DescrReadCallback
-	writeCallback DescrWriteCallback
+	readCallback  DescrReadRequestCallback
+	writeCallback DescrWriteRequestCallback
 }
 
 func (s *Descr) DBusProperties() *api.DBusProperties {
@@ -5,14 +5,46 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-// Set the Read callback, called when a client attempt to read
+// OnRead Set the Read callback, called when a client attempt to read.
+// fx returns the whole value, the read offset is applied to it
 func (s *Descr) OnRead(fx DescrReadCallback) *Descr {
+	if fx == nil {
+		return s.OnReadRequest(nil)
+	}
+	return s.OnReadRequest(func(d *Descr, req ReadRequest) ([]byte, error) {
+		b, err := fx(d, req.Options)
+		if err != nil {
+			return nil, err
+		}
+		return fragmentRead(b, req.Offset)
+	})
+}
+
+// OnWrite Set the Write callback, called when a client attempt to write.
+// fx receives the written fragment and returns the fragment to store at the write offset
+func (s *Descr) OnWrite(fx DescrWriteCallback) *Descr {
+	if fx == nil {
+		return s.OnWriteRequest(nil)
+	}
+	return s.OnWriteRequest(func(d *Descr, req WriteRequest, value []byte) ([]byte, error) {
+		b, err := fx(d, value[req.Offset:])
+		if err != nil {
+			return nil, err
+		}
+		return fragmentWrite(value, b, req.Offset), nil
+	})
+}
+
+// OnReadRequest Set the Read callback, called with the request options when a client attempt to read.
+// fx must return the value starting at req.Offset, the offset is not applied to the returned value
+func (s *Descr) OnReadRequest(fx DescrReadRequestCallback) *Descr {
 	s.readCallback = fx
 	return s
 }
 
-// Set the Write callback, called when a client attempt to write
-func (s *Descr) OnWrite(fx DescrWriteCallback) *Descr {
+// OnWriteRequest Set the Write callback, called with the request options when a client attempt to write.
+// fx receives the current value with the fragment written at req.Offset and returns the value to store
+func (s *Descr) OnWriteRequest(fx DescrWriteRequestCallback) *Descr {
 	s.writeCallback = fx
 	return s
 }
@@ -23,14 +55,16 @@ func (s *Descr) ReadValue(options map[string]interface{}) ([]byte, *dbus.Error)
 	log.Trace("Descr.ReadValue")
 
 	if s.readCallback != nil {
-		b, err := s.readCallback(s, options)
+		b, err := s.readCallback(s, NewReadRequest(options))
 		if err != nil {
-			return nil, dbus.MakeFailedError(err)
+			return nil, callbackError(err)
 		}
 		return b, nil
 	}
 
-	return s.Properties.Value, nil
+	s.Properties.Lock()
+	defer s.Properties.Unlock()
+	return readOffset(s.Properties.Value, NewReadRequest(options).Offset)
 }
 
 //WriteValue write a value
@@ -38,21 +72,36 @@ func (s *Descr) WriteValue(value []byte, options map[string]interface{}) *dbus.E
 
 	log.Trace("Descr.WriteValue")
 
-	val := value
+	req := NewWriteRequest(options)
+
+	// merge the fragment written at offset, as for long and reliable writes
+	s.Properties.Lock()
+	val, derr := writeOffset(s.Properties.Value, value, req.Offset, s.MaxLength)
+	s.Properties.Unlock()
+	if derr != nil {
+		return derr
+	}
+
 	if s.writeCallback != nil {
 		log.Trace("Used write callback")
-		b, err := s.writeCallback(s, value)
+		b, err := s.writeCallback(s, req, val)
 		val = b
 		if err != nil {
-			return dbus.MakeFailedError(err)
+			return callbackError(err)
 		}
 	} else {
 		log.Trace("Store directly to value (no callback)")
 	}
 
-	// TODO update on Properties interface
+	// authorization only, the value is written by a following request
+	if req.PrepareAuthorize {
+		return nil
+	}
+
+	s.Properties.Lock()
+	defer s.Properties.Unlock()
 	s.Properties.Value = val
-	err := s.iprops.Instance().Set(s.Interface(), "Value", dbus.MakeVariant(value))
+	err := s.iprops.Instance().Set(s.Interface(), "Value", dbus.MakeVariant(val))
 
 	return err
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/godbus/dbus/v5"
 	"github.com/muka/go-bluetooth/api"
+	"github.com/muka/go-bluetooth/assigned/uuid"
 	"github.com/muka/go-bluetooth/bluez"
 	"github.com/muka/go-bluetooth/bluez/profile/gatt"
 	log "github.com/sirupsen/logrus"
@@ -115,7 +116,7 @@ func (s *Service) AddChar(char *Char) error {
 		return err
 	}
 
-	log.Tracef("Added GATT Characteristic UUID=%s %s", char.UUID, char.Path())
+	log.Tracef("Added GATT Characteristic UUID=%s (%s) %s", char.UUID, uuid.Name(char.UUID), char.Path())
 
 	err = s.App().ExportTree()
 	return err
@@ -6,6 +6,7 @@ import (
 
 	"github.com/godbus/dbus/v5"
 	"github.com/muka/go-bluetooth/api"
+	"github.com/muka/go-bluetooth/assigned/uuid"
 	"github.com/muka/go-bluetooth/bluez"
 	log "github.com/sirupsen/logrus"
 )
@@ -49,7 +50,7 @@ func (app *App) AddService(s *Service) error {
 		return err
 	}
 
-	log.Tracef("Added GATT Service UUID=%s %s", s.UUID, s.Path())
+	log.Tracef("Added GATT Service UUID=%s (%s) %s", s.UUID, uuid.Name(s.UUID), s.Path())
 
 	return nil
 }
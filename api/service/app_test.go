@@ -3,21 +3,37 @@ package service
 import (
 	"testing"
 
-	"github.com/muka/go-bluetooth/api"
-	log "github.com/sirupsen/logrus"
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez/fake"
+	"github.com/muka/go-bluetooth/bluez/profile/agent"
+	"github.com/stretchr/testify/assert"
 )
 
-func createTestApp(t *testing.T) *App {
+// createFakeApp create an app connected to a fake bluez with an hci0 adapter
+func createFakeApp(t *testing.T) (*fake.Bluez, *App) {
+	b, _ := fake.StartAdapterT(t, "hci0", nil)
 
-	log.SetLevel(log.TraceLevel)
+	conn, err := b.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	a, err := NewApp(AppOptions{
-		AdapterID: api.GetDefaultAdapterID(),
+	app, err := NewApp(AppOptions{
+		AdapterID: "hci0",
+		Conn:      conn,
 	})
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	return b, app
+}
+
+// createTestApp create and run an app exposing a service with a characteristic and a descriptor
+func createTestApp(t *testing.T) (*fake.Bluez, *App) {
+
+	b, a := createFakeApp(t)
+
 	s1, err := a.NewService("2233")
 	if err != nil {
 		t.Fatal(err)
@@ -61,10 +77,21 @@ func createTestApp(t *testing.T) *App {
 		t.Fatal(err)
 	}
 
-	return a
+	return b, a
 }
 
 func TestApp(t *testing.T) {
-	a := createTestApp(t)
-	defer a.Close()
+	b, a := createTestApp(t)
+
+	fa := b.Adapter("hci0")
+	assert.Equal(t, []dbus.ObjectPath{a.Path()}, fa.Applications())
+
+	am := b.AgentManager()
+	assert.Equal(t, []dbus.ObjectPath{a.Agent().Path()}, am.Agents())
+	assert.Equal(t, agent.CapKeyboardDisplay, am.Capability(a.Agent().Path()))
+	assert.Equal(t, dbus.ObjectPath(""), am.DefaultAgent())
+
+	a.Close()
+	assert.Empty(t, fa.Applications())
+	assert.Empty(t, am.Agents())
 }
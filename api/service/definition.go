@@ -0,0 +1,639 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// Definition describes a GATT database. It can be loaded from YAML or JSON
+// with ParseDefinition, or built from a tagged struct with DefinitionFromStruct.
+//
+// UUIDs are 16 or 32 bit assigned numbers, eg. 180F, or 128 bit UUIDs.
+type Definition struct {
+	Services []ServiceDefinition `json:"services" yaml:"services"`
+}
+
+// ServiceDefinition describes a GATT service
+type ServiceDefinition struct {
+	UUID string `json:"uuid" yaml:"uuid"`
+	// Secondary set for a secondary service, services are primary by default
+	Secondary       bool             `json:"secondary,omitempty" yaml:"secondary,omitempty"`
+	Characteristics []CharDefinition `json:"characteristics,omitempty" yaml:"characteristics,omitempty"`
+}
+
+// CharDefinition describes a GATT characteristic
+type CharDefinition struct {
+	UUID  string   `json:"uuid" yaml:"uuid"`
+	Flags []string `json:"flags,omitempty" yaml:"flags,omitempty"`
+	// Value initial value as text
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+	// Hex initial value hex encoded, alternative to Value
+	Hex string `json:"hex,omitempty" yaml:"hex,omitempty"`
+	// MaxLength maximum length of the value, MaxValueLength if 0
+	MaxLength uint16 `json:"max_length,omitempty" yaml:"max_length,omitempty"`
+	// Handler name of the handler bound to the characteristic, see Handlers
+	Handler     string            `json:"handler,omitempty" yaml:"handler,omitempty"`
+	Descriptors []DescrDefinition `json:"descriptors,omitempty" yaml:"descriptors,omitempty"`
+}
+
+// DescrDefinition describes a GATT descriptor
+type DescrDefinition struct {
+	UUID string `json:"uuid" yaml:"uuid"`
+	// Flags read and write if empty
+	Flags []string `json:"flags,omitempty" yaml:"flags,omitempty"`
+	// Value initial value as text
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+	// Hex initial value hex encoded, alternative to Value
+	Hex string `json:"hex,omitempty" yaml:"hex,omitempty"`
+	// MaxLength maximum length of the value, MaxValueLength if 0
+	MaxLength uint16 `json:"max_length,omitempty" yaml:"max_length,omitempty"`
+	// Handler name of the handler bound to the descriptor, see Handlers
+	Handler string `json:"handler,omitempty" yaml:"handler,omitempty"`
+}
+
+// Handlers binds the handler names referenced by a definition. A characteristic handler
+// is a CharReadRequestCallback, CharWriteRequestCallback or a value implementing one or
+// more of CharReader, CharWriter and CharSubscriber. A descriptor handler is a
+// DescrReadRequestCallback, DescrWriteRequestCallback or a value implementing DescrReader
+// and/or DescrWriter.
+type Handlers map[string]interface{}
+
+// CharReader handle the reads of a characteristic
+type CharReader interface {
+	ReadChar(c *Char, req ReadRequest) ([]byte, error)
+}
+
+// CharWriter handle the writes of a characteristic
+type CharWriter interface {
+	WriteChar(c *Char, req WriteRequest, value []byte) ([]byte, error)
+}
+
+// CharSubscriber is notified when notifications are enabled or disabled
+type CharSubscriber interface {
+	Subscribe(c *Char)
+	Unsubscribe(c *Char)
+}
+
+// DescrReader handle the reads of a descriptor
+type DescrReader interface {
+	ReadDescr(d *Descr, req ReadRequest) ([]byte, error)
+}
+
+// DescrWriter handle the writes of a descriptor
+type DescrWriter interface {
+	WriteDescr(d *Descr, req WriteRequest, value []byte) ([]byte, error)
+}
+
+var charFlags = []string{
+	gatt.FlagCharacteristicBroadcast,
+	gatt.FlagCharacteristicRead,
+	gatt.FlagCharacteristicWriteWithoutResponse,
+	gatt.FlagCharacteristicWrite,
+	gatt.FlagCharacteristicNotify,
+	gatt.FlagCharacteristicIndicate,
+	gatt.FlagCharacteristicAuthenticatedSignedWrites,
+	gatt.FlagCharacteristicReliableWrite,
+	gatt.FlagCharacteristicWritableAuxiliaries,
+	gatt.FlagCharacteristicEncryptRead,
+	gatt.FlagCharacteristicEncryptWrite,
+	gatt.FlagCharacteristicEncryptAuthenticatedRead,
+	gatt.FlagCharacteristicEncryptAuthenticatedWrite,
+	gatt.FlagCharacteristicSecureRead,
+	gatt.FlagCharacteristicSecureWrite,
+	gatt.FlagCharacteristicAuthorize,
+}
+
+var descrFlags = []string{
+	gatt.FlagDescriptorRead,
+	gatt.FlagDescriptorWrite,
+	gatt.FlagDescriptorEncryptRead,
+	gatt.FlagDescriptorEncryptWrite,
+	gatt.FlagDescriptorEncryptAuthenticatedRead,
+	gatt.FlagDescriptorEncryptAuthenticatedWrite,
+	gatt.FlagDescriptorSecureRead,
+	gatt.FlagDescriptorSecureWrite,
+	gatt.FlagDescriptorAuthorize,
+}
+
+// ParseDefinition parse a YAML or JSON definition
+func ParseDefinition(data []byte) (*Definition, error) {
+	def := new(Definition)
+	err := yaml.Unmarshal(data, def)
+	if err != nil {
+		return nil, fmt.Errorf("Parse definition: %s", err)
+	}
+	err = def.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// ParseDefinitionFile parse a YAML or JSON definition file
+func ParseDefinitionFile(path string) (*Definition, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDefinition(data)
+}
+
+// Validate check the definition UUIDs, flags and values
+func (def *Definition) Validate() error {
+	for i, s := range def.Services {
+		if !validUUID(s.UUID) {
+			return fmt.Errorf("services[%d]: invalid UUID %q", i, s.UUID)
+		}
+		for j, c := range s.Characteristics {
+			prefix := fmt.Sprintf("services[%d].characteristics[%d]", i, j)
+			err := validateAttribute(c.UUID, c.Flags, charFlags, c.Value, c.Hex)
+			if err != nil {
+				return fmt.Errorf("%s: %s", prefix, err)
+			}
+			for k, d := range c.Descriptors {
+				err := validateAttribute(d.UUID, d.Flags, descrFlags, d.Value, d.Hex)
+				if err != nil {
+					return fmt.Errorf("%s.descriptors[%d]: %s", prefix, k, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateAttribute check the fields shared by characteristics and descriptors
+func validateAttribute(uuid string, flags []string, known []string, value string, hexValue string) error {
+	if !validUUID(uuid) {
+		return fmt.Errorf("invalid UUID %q", uuid)
+	}
+	for _, flag := range flags {
+		if !contains(known, flag) {
+			return fmt.Errorf("unknown flag %q", flag)
+		}
+	}
+	if value != "" && hexValue != "" {
+		return fmt.Errorf("value and hex are exclusive")
+	}
+	if _, err := hex.DecodeString(hexValue); err != nil {
+		return fmt.Errorf("hex: %s", err)
+	}
+	return nil
+}
+
+// validUUID check for a 16, 32 or 128 bit UUID
+func validUUID(uuid string) bool {
+	switch len(uuid) {
+	case 4, 8:
+		_, err := strconv.ParseUint(uuid, 16, 32)
+		return err == nil
+	case 36:
+		parts := strings.Split(uuid, "-")
+		if len(parts) != 5 {
+			return false
+		}
+		_, err := hex.DecodeString(strings.Join(parts, ""))
+		return err == nil
+	}
+	return false
+}
+
+// definitionUUID return the 128 bit form of an UUID, 16 and 32 bit UUIDs are
+// assigned numbers and always use the Bluetooth Base UUID, not AppOptions.UUIDSuffix
+func definitionUUID(uuid string) string {
+	return bluez.UUID(uuid).String()
+}
+
+// definitionValue return the initial value of an attribute
+func definitionValue(value string, hexValue string) []byte {
+	if hexValue != "" {
+		b, _ := hex.DecodeString(hexValue)
+		return b
+	}
+	return []byte(value)
+}
+
+// loadedService holds the objects created by Load, in definition order
+type loadedService struct {
+	service *Service
+	chars   []loadedChar
+}
+
+type loadedChar struct {
+	char  *Char
+	descr []*Descr
+}
+
+// Load add the services of a definition to the app, binding the named handlers
+func (app *App) Load(def *Definition, handlers Handlers) error {
+	_, err := app.load(def, handlers)
+	return err
+}
+
+func (app *App) load(def *Definition, handlers Handlers) ([]loadedService, error) {
+
+	err := def.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// check the handlers before exposing anything
+	err = def.checkHandlers(handlers)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := []loadedService{}
+	added := []*Service{}
+	for _, sdef := range def.Services {
+
+		s, err := app.NewService(definitionUUID(sdef.UUID))
+		if err != nil {
+			app.unload(added)
+			return nil, err
+		}
+		s.Properties.Primary = !sdef.Secondary
+
+		added = append(added, s)
+		err = app.AddService(s)
+		if err != nil {
+			app.unload(added)
+			return nil, fmt.Errorf("AddService %s: %s", sdef.UUID, err)
+		}
+
+		ls := loadedService{service: s}
+		for _, cdef := range sdef.Characteristics {
+			lc, err := s.loadChar(cdef, handlers)
+			if err != nil {
+				app.unload(added)
+				return nil, err
+			}
+			ls.chars = append(ls.chars, lc)
+		}
+		loaded = append(loaded, ls)
+	}
+
+	return loaded, nil
+}
+
+// unload remove the services added by a failed load, in reverse order
+func (app *App) unload(services []*Service) {
+	for i := len(services) - 1; i >= 0; i-- {
+		err := app.RemoveService(services[i])
+		if err != nil {
+			log.Warnf("Remove service %s: %s", services[i].UUID, err)
+		}
+	}
+}
+
+// checkHandlers check the handlers referenced by the definition are available and can be bound
+func (def *Definition) checkHandlers(handlers Handlers) error {
+	for _, sdef := range def.Services {
+		for _, cdef := range sdef.Characteristics {
+			if cdef.Handler != "" {
+				h, ok := handlers[cdef.Handler]
+				if !ok {
+					return fmt.Errorf("Characteristic %s: handler %s not found", cdef.UUID, cdef.Handler)
+				}
+				c := &Char{Properties: NewGattCharacteristic1Properties(cdef.UUID)}
+				if err := c.bind(h); err != nil {
+					return fmt.Errorf("Characteristic %s: handler %s: %s", cdef.UUID, cdef.Handler, err)
+				}
+			}
+			for _, ddef := range cdef.Descriptors {
+				if ddef.Handler == "" {
+					continue
+				}
+				h, ok := handlers[ddef.Handler]
+				if !ok {
+					return fmt.Errorf("Descriptor %s: handler %s not found", ddef.UUID, ddef.Handler)
+				}
+				d := &Descr{Properties: NewGattDescriptor1Properties(ddef.UUID)}
+				if err := d.bind(h); err != nil {
+					return fmt.Errorf("Descriptor %s: handler %s: %s", ddef.UUID, ddef.Handler, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// loadChar add a characteristic and its descriptors to the service
+func (s *Service) loadChar(def CharDefinition, handlers Handlers) (loadedChar, error) {
+
+	c, err := s.NewChar(definitionUUID(def.UUID))
+	if err != nil {
+		return loadedChar{}, err
+	}
+	c.Properties.Flags = append([]string{}, def.Flags...)
+	c.Properties.Value = definitionValue(def.Value, def.Hex)
+	c.MaxLength = def.MaxLength
+
+	if def.Handler != "" {
+		err = c.bind(handlers[def.Handler])
+		if err != nil {
+			return loadedChar{}, fmt.Errorf("Characteristic %s: handler %s: %s", def.UUID, def.Handler, err)
+		}
+	}
+
+	err = s.AddChar(c)
+	if err != nil {
+		return loadedChar{}, fmt.Errorf("AddChar %s: %s", def.UUID, err)
+	}
+
+	lc := loadedChar{char: c}
+	for _, ddef := range def.Descriptors {
+
+		d, err := c.NewDescr(definitionUUID(ddef.UUID))
+		if err != nil {
+			return loadedChar{}, err
+		}
+		if ddef.Flags != nil {
+			d.Properties.Flags = append([]string{}, ddef.Flags...)
+		}
+		d.Properties.Value = definitionValue(ddef.Value, ddef.Hex)
+		d.MaxLength = ddef.MaxLength
+
+		if ddef.Handler != "" {
+			err = d.bind(handlers[ddef.Handler])
+			if err != nil {
+				return loadedChar{}, fmt.Errorf("Descriptor %s: handler %s: %s", ddef.UUID, ddef.Handler, err)
+			}
+		}
+
+		err = c.AddDescr(d)
+		if err != nil {
+			return loadedChar{}, fmt.Errorf("AddDescr %s: %s", ddef.UUID, err)
+		}
+		lc.descr = append(lc.descr, d)
+	}
+
+	return lc, nil
+}
+
+// bind a characteristic handler
+func (s *Char) bind(h interface{}) error {
+	switch fx := h.(type) {
+	case CharReadRequestCallback:
+		s.OnReadRequest(fx)
+		return nil
+	case func(c *Char, req ReadRequest) ([]byte, error):
+		s.OnReadRequest(fx)
+		return nil
+	case CharWriteRequestCallback:
+		s.OnWriteRequest(fx)
+		return nil
+	case func(c *Char, req WriteRequest, value []byte) ([]byte, error):
+		s.OnWriteRequest(fx)
+		return nil
+	}
+
+	bound := false
+	if r, ok := h.(CharReader); ok {
+		s.OnReadRequest(r.ReadChar)
+		bound = true
+	}
+	if w, ok := h.(CharWriter); ok {
+		s.OnWriteRequest(w.WriteChar)
+		bound = true
+	}
+	if sub, ok := h.(CharSubscriber); ok {
+		s.OnSubscribe(sub.Subscribe)
+		s.OnUnsubscribe(sub.Unsubscribe)
+		bound = true
+	}
+	if !bound {
+		return fmt.Errorf("unsupported handler type %T", h)
+	}
+	return nil
+}
+
+// bind a descriptor handler
+func (s *Descr) bind(h interface{}) error {
+	switch fx := h.(type) {
+	case DescrReadRequestCallback:
+		s.OnReadRequest(fx)
+		return nil
+	case func(d *Descr, req ReadRequest) ([]byte, error):
+		s.OnReadRequest(fx)
+		return nil
+	case DescrWriteRequestCallback:
+		s.OnWriteRequest(fx)
+		return nil
+	case func(d *Descr, req WriteRequest, value []byte) ([]byte, error):
+		s.OnWriteRequest(fx)
+		return nil
+	}
+
+	bound := false
+	if r, ok := h.(DescrReader); ok {
+		s.OnReadRequest(r.ReadDescr)
+		bound = true
+	}
+	if w, ok := h.(DescrWriter); ok {
+		s.OnWriteRequest(w.WriteDescr)
+		bound = true
+	}
+	if !bound {
+		return fmt.Errorf("unsupported handler type %T", h)
+	}
+	return nil
+}
+
+// contains check if a string is in the list
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	charType  = reflect.TypeOf(&Char{})
+	descrType = reflect.TypeOf(&Descr{})
+)
+
+// DefinitionFromStruct build a definition from the tags of a struct. Each field of v tagged
+// `gatt:"<uuid>[,secondary]"` is a service, a struct or pointer to struct. In a service struct,
+// each *Char field tagged `gatt:"<uuid>,<flags>..."` is a characteristic and each *Descr field
+// tagged the same way is a descriptor of the preceding characteristic. Characteristics and
+// descriptors accept the `value`, `hex`, `maxlength` and `handler` tags, eg.
+//
+//	type Battery struct {
+//		Level      *service.Char  `gatt:"2A19,read,notify" hex:"64" handler:"level"`
+//		LevelDescr *service.Descr `gatt:"2901,read" value:"Battery level"`
+//	}
+//
+//	type Peripheral struct {
+//		Battery Battery `gatt:"180F"`
+//	}
+func DefinitionFromStruct(v interface{}) (*Definition, error) {
+
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("DefinitionFromStruct: expected a struct, %T given", v)
+	}
+
+	def := new(Definition)
+	for i := 0; i < t.NumField(); i++ {
+
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("gatt")
+		if !ok {
+			continue
+		}
+
+		if field.PkgPath != "" {
+			return nil, fmt.Errorf("%s: field must be exported", field.Name)
+		}
+
+		st := field.Type
+		if st.Kind() == reflect.Ptr {
+			st = st.Elem()
+		}
+		if st.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%s: a service must be a struct", field.Name)
+		}
+
+		parts := strings.Split(tag, ",")
+		sdef := ServiceDefinition{
+			UUID:      parts[0],
+			Secondary: contains(parts[1:], "secondary"),
+		}
+
+		for j := 0; j < st.NumField(); j++ {
+
+			cfield := st.Field(j)
+			ctag, ok := cfield.Tag.Lookup("gatt")
+			if !ok {
+				continue
+			}
+
+			if cfield.PkgPath != "" {
+				return nil, fmt.Errorf("%s.%s: field must be exported", field.Name, cfield.Name)
+			}
+
+			cparts := strings.Split(ctag, ",")
+			maxLength := uint16(0)
+			if m, ok := cfield.Tag.Lookup("maxlength"); ok {
+				l, err := strconv.ParseUint(m, 10, 16)
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: maxlength: %s", field.Name, cfield.Name, err)
+				}
+				maxLength = uint16(l)
+			}
+
+			switch cfield.Type {
+			case charType:
+				sdef.Characteristics = append(sdef.Characteristics, CharDefinition{
+					UUID:      cparts[0],
+					Flags:     cparts[1:],
+					Value:     cfield.Tag.Get("value"),
+					Hex:       cfield.Tag.Get("hex"),
+					MaxLength: maxLength,
+					Handler:   cfield.Tag.Get("handler"),
+				})
+			case descrType:
+				if len(sdef.Characteristics) == 0 {
+					return nil, fmt.Errorf("%s.%s: a descriptor must follow a characteristic", field.Name, cfield.Name)
+				}
+				var flags []string
+				if len(cparts) > 1 {
+					flags = cparts[1:]
+				}
+				c := &sdef.Characteristics[len(sdef.Characteristics)-1]
+				c.Descriptors = append(c.Descriptors, DescrDefinition{
+					UUID:      cparts[0],
+					Flags:     flags,
+					Value:     cfield.Tag.Get("value"),
+					Hex:       cfield.Tag.Get("hex"),
+					MaxLength: maxLength,
+					Handler:   cfield.Tag.Get("handler"),
+				})
+			default:
+				return nil, fmt.Errorf("%s.%s: expected *service.Char or *service.Descr, %s given", field.Name, cfield.Name, cfield.Type)
+			}
+		}
+
+		def.Services = append(def.Services, sdef)
+	}
+
+	err := def.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return def, nil
+}
+
+// LoadStruct add the services described by the tags of the struct pointed by v,
+// see DefinitionFromStruct. The *Char and *Descr fields are set to the created objects
+func (app *App) LoadStruct(v interface{}, handlers Handlers) error {
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("LoadStruct: expected a pointer to struct, %T given", v)
+	}
+
+	def, err := DefinitionFromStruct(v)
+	if err != nil {
+		return err
+	}
+
+	loaded, err := app.load(def, handlers)
+	if err != nil {
+		return err
+	}
+
+	rv = rv.Elem()
+	si := 0
+	for i := 0; i < rv.NumField(); i++ {
+
+		if _, ok := rv.Type().Field(i).Tag.Lookup("gatt"); !ok {
+			continue
+		}
+
+		sv := rv.Field(i)
+		if sv.Kind() == reflect.Ptr {
+			if sv.IsNil() {
+				sv.Set(reflect.New(sv.Type().Elem()))
+			}
+			sv = sv.Elem()
+		}
+
+		ls := loaded[si]
+		si++
+
+		ci := -1
+		di := 0
+		for j := 0; j < sv.NumField(); j++ {
+			if _, ok := sv.Type().Field(j).Tag.Lookup("gatt"); !ok {
+				continue
+			}
+			switch sv.Field(j).Type() {
+			case charType:
+				ci++
+				di = 0
+				sv.Field(j).Set(reflect.ValueOf(ls.chars[ci].char))
+			case descrType:
+				sv.Field(j).Set(reflect.ValueOf(ls.chars[ci].descr[di]))
+				di++
+			}
+		}
+	}
+
+	return nil
+}
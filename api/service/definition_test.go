@@ -0,0 +1,254 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
+	"github.com/stretchr/testify/assert"
+)
+
+const definitionYAML = `
+services:
+  - uuid: "180F"
+    characteristics:
+      - uuid: "2A19"
+        flags: [read, notify]
+        hex: "64"
+        handler: level
+        descriptors:
+          - uuid: "2901"
+            flags: [read]
+            value: Battery level
+  - uuid: 12345678-1234-5678-1234-56789abcdef0
+    secondary: true
+    characteristics:
+      - uuid: 12345678-1234-5678-1234-56789abcdef1
+        flags: [write, authorize]
+        max_length: 20
+`
+
+const definitionJSON = `{
+  "services": [{
+    "uuid": "180F",
+    "characteristics": [{
+      "uuid": "2A19",
+      "flags": ["read", "notify"],
+      "hex": "64",
+      "handler": "level",
+      "descriptors": [{"uuid": "2901", "flags": ["read"], "value": "Battery level"}]
+    }]
+  }, {
+    "uuid": "12345678-1234-5678-1234-56789abcdef0",
+    "secondary": true,
+    "characteristics": [{
+      "uuid": "12345678-1234-5678-1234-56789abcdef1",
+      "flags": ["write", "authorize"],
+      "max_length": 20
+    }]
+  }]
+}`
+
+var expectedDefinition = &Definition{
+	Services: []ServiceDefinition{
+		{
+			UUID: "180F",
+			Characteristics: []CharDefinition{
+				{
+					UUID:    "2A19",
+					Flags:   []string{"read", "notify"},
+					Hex:     "64",
+					Handler: "level",
+					Descriptors: []DescrDefinition{
+						{UUID: "2901", Flags: []string{"read"}, Value: "Battery level"},
+					},
+				},
+			},
+		},
+		{
+			UUID:      "12345678-1234-5678-1234-56789abcdef0",
+			Secondary: true,
+			Characteristics: []CharDefinition{
+				{
+					UUID:      "12345678-1234-5678-1234-56789abcdef1",
+					Flags:     []string{"write", "authorize"},
+					MaxLength: 20,
+				},
+			},
+		},
+	},
+}
+
+func TestParseDefinition(t *testing.T) {
+	for name, data := range map[string]string{"yaml": definitionYAML, "json": definitionJSON} {
+		t.Run(name, func(t *testing.T) {
+			def, err := ParseDefinition([]byte(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, expectedDefinition, def)
+		})
+	}
+}
+
+func TestParseDefinitionInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"syntax", "services: [uuid: }"},
+		{"service uuid", "services: [{uuid: 18}]"},
+		{"char uuid", "services: [{uuid: 180F, characteristics: [{uuid: zz19}]}]"},
+		{"char flag", "services: [{uuid: 180F, characteristics: [{uuid: 2A19, flags: [notfy]}]}]"},
+		{"descr flag", "services: [{uuid: 180F, characteristics: [{uuid: 2A19, descriptors: [{uuid: 2901, flags: [notify]}]}]}]"},
+		{"value and hex", "services: [{uuid: 180F, characteristics: [{uuid: 2A19, value: a, hex: '61'}]}]"},
+		{"hex", "services: [{uuid: 180F, characteristics: [{uuid: 2A19, hex: 6}]}]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDefinition([]byte(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+type testBattery struct {
+	Level      *Char  `gatt:"2A19,read,notify" hex:"64" handler:"level"`
+	LevelDescr *Descr `gatt:"2901,read" value:"Battery level"`
+	ignored    string
+}
+
+type testCustom struct {
+	Config *Char `gatt:"12345678-1234-5678-1234-56789abcdef1,write,authorize" maxlength:"20"`
+}
+
+type testPeripheral struct {
+	Battery testBattery `gatt:"180F"`
+	Custom  *testCustom `gatt:"12345678-1234-5678-1234-56789abcdef0,secondary"`
+	Name    string
+}
+
+func TestDefinitionFromStruct(t *testing.T) {
+
+	def, err := DefinitionFromStruct(&testPeripheral{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, expectedDefinition, def)
+
+	_, err = DefinitionFromStruct(struct {
+		S struct {
+			D *Descr `gatt:"2901"`
+		} `gatt:"180F"`
+	}{})
+	assert.Error(t, err)
+
+	_, err = DefinitionFromStruct(struct {
+		S struct {
+			C string `gatt:"2A19"`
+		} `gatt:"180F"`
+	}{})
+	assert.Error(t, err)
+}
+
+// testLevel handles the battery level char
+type testLevel struct {
+	subscribed bool
+}
+
+func (l *testLevel) ReadChar(c *Char, req ReadRequest) ([]byte, error) {
+	return []byte{42}, nil
+}
+
+func (l *testLevel) Subscribe(c *Char) {
+	l.subscribed = true
+}
+
+func (l *testLevel) Unsubscribe(c *Char) {
+	l.subscribed = false
+}
+
+func TestLoadStruct(t *testing.T) {
+	b, app := createFakeApp(t)
+
+	p := &testPeripheral{}
+	err := app.LoadStruct(p, Handlers{"level": &testLevel{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, app.GetServices(), 2)
+
+	level := p.Battery.Level
+	assert.NotNil(t, level)
+	assert.Equal(t, "00002a19-0000-1000-8000-00805f9b34fb", level.UUID)
+	assert.Equal(t, []string{"read", "notify"}, level.Properties.Flags)
+	assert.Equal(t, []byte{0x64}, level.Properties.Value)
+	assert.Equal(t, []byte("Battery level"), p.Battery.LevelDescr.Properties.Value)
+	assert.Equal(t, level, p.Battery.LevelDescr.Char())
+
+	assert.NotNil(t, p.Custom)
+	assert.Equal(t, "12345678-1234-5678-1234-56789abcdef1", p.Custom.Config.UUID)
+	assert.Equal(t, uint16(20), p.Custom.Config.MaxLength)
+	assert.False(t, p.Custom.Config.Service().Properties.Primary)
+
+	// the handler is bound
+	remote, err := b.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := remote.DBus().Object(app.DBusConn().Names()[0], level.Path())
+	var value []byte
+	err = obj.Call(gatt.GattCharacteristic1Interface+".ReadValue", 0, map[string]dbus.Variant{}).Store(&value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{42}, value)
+}
+
+func TestLoadHandlers(t *testing.T) {
+	_, app := createFakeApp(t)
+
+	def, err := ParseDefinition([]byte(definitionYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = app.Load(def, Handlers{})
+	assert.Error(t, err)
+
+	err = app.Load(def, Handlers{"level": "not a handler"})
+	assert.Error(t, err)
+	assert.Empty(t, app.GetServices())
+
+	err = app.Load(def, Handlers{
+		"level": func(c *Char, req ReadRequest) ([]byte, error) {
+			return nil, nil
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestLoadAssignedUUID(t *testing.T) {
+	_, app := createFakeApp(t)
+	app.Options.UUIDSuffix = "-1234-5678-1234-56789abcdef0"
+
+	def, err := ParseDefinition([]byte("services: [{uuid: 180F, characteristics: [{uuid: 00002A19, flags: [read]}]}]"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = app.Load(def, Handlers{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// assigned numbers use the Bluetooth Base UUID
+	services := app.GetServices()
+	assert.Len(t, services, 1)
+	for _, s := range services {
+		assert.Equal(t, "0000180f-0000-1000-8000-00805f9b34fb", s.UUID)
+		for _, c := range s.GetChars() {
+			assert.Equal(t, "00002a19-0000-1000-8000-00805f9b34fb", c.UUID)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"github.com/godbus/dbus/v5"
+)
+
+// Write types reported in WriteRequest.Type
+const (
+	WriteTypeCommand  = "command"
+	WriteTypeRequest  = "request"
+	WriteTypeReliable = "reliable"
+)
+
+// ReadRequest holds the options of a ReadValue request
+type ReadRequest struct {
+	// Device path of the device issuing the request
+	Device dbus.ObjectPath
+	// Offset to read from, read callbacks should return the value starting at Offset
+	Offset uint16
+	// MTU exchanged with the device
+	MTU uint16
+	// Link type, eg. BR/EDR or LE
+	Link string
+	// Options raw options received from bluez
+	Options map[string]interface{}
+}
+
+// WriteRequest holds the options of a WriteValue request
+type WriteRequest struct {
+	// Device path of the device issuing the request
+	Device dbus.ObjectPath
+	// Offset the fragment was written at. Write callbacks receive the
+	// current value with the fragment written at Offset
+	Offset uint16
+	// MTU exchanged with the device
+	MTU uint16
+	// Link type, eg. BR/EDR or LE
+	Link string
+	// Type of write, one of WriteTypeCommand, WriteTypeRequest or WriteTypeReliable
+	Type string
+	// PrepareAuthorize is true when the request is a prepare write authorization,
+	// the value is not written and should only be authorized
+	PrepareAuthorize bool
+	// Options raw options received from bluez
+	Options map[string]interface{}
+}
+
+type CharReadRequestCallback func(c *Char, req ReadRequest) ([]byte, error)
+type CharWriteRequestCallback func(c *Char, req WriteRequest, value []byte) ([]byte, error)
+
+type DescrReadRequestCallback func(d *Descr, req ReadRequest) ([]byte, error)
+type DescrWriteRequestCallback func(d *Descr, req WriteRequest, value []byte) ([]byte, error)
+
+// NewReadRequest parse the options of a ReadValue request
+func NewReadRequest(options map[string]interface{}) ReadRequest {
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+	req := ReadRequest{
+		Options: options,
+	}
+	req.Device, _ = options["device"].(dbus.ObjectPath)
+	req.Offset, _ = options["offset"].(uint16)
+	req.MTU, _ = options["mtu"].(uint16)
+	req.Link, _ = options["link"].(string)
+	return req
+}
+
+// NewWriteRequest parse the options of a WriteValue request
+func NewWriteRequest(options map[string]interface{}) WriteRequest {
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+	req := WriteRequest{
+		Options: options,
+	}
+	req.Device, _ = options["device"].(dbus.ObjectPath)
+	req.Offset, _ = options["offset"].(uint16)
+	req.MTU, _ = options["mtu"].(uint16)
+	req.Link, _ = options["link"].(string)
+	req.Type, _ = options["type"].(string)
+	req.PrepareAuthorize, _ = options["prepare-authorize"].(bool)
+	return req
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReadRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]interface{}
+		expect  ReadRequest
+	}{
+		{
+			name:    "nil options",
+			options: nil,
+			expect:  ReadRequest{Options: map[string]interface{}{}},
+		},
+		{
+			name: "all options",
+			options: map[string]interface{}{
+				"device": dbus.ObjectPath("/org/bluez/hci0/dev_00_11_22_33_44_55"),
+				"offset": uint16(10),
+				"mtu":    uint16(247),
+				"link":   "LE",
+			},
+			expect: ReadRequest{
+				Device: "/org/bluez/hci0/dev_00_11_22_33_44_55",
+				Offset: 10,
+				MTU:    247,
+				Link:   "LE",
+			},
+		},
+		{
+			name:    "wrong type",
+			options: map[string]interface{}{"offset": 10},
+			expect:  ReadRequest{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := NewReadRequest(tt.options)
+			if tt.options != nil {
+				tt.expect.Options = tt.options
+			}
+			assert.Equal(t, tt.expect, req)
+		})
+	}
+}
+
+func TestNewWriteRequest(t *testing.T) {
+	options := map[string]interface{}{
+		"device":            dbus.ObjectPath("/org/bluez/hci0/dev_00_11_22_33_44_55"),
+		"offset":            uint16(2),
+		"mtu":               uint16(23),
+		"link":              "BR/EDR",
+		"type":              WriteTypeReliable,
+		"prepare-authorize": true,
+	}
+	assert.Equal(t, WriteRequest{
+		Device:           "/org/bluez/hci0/dev_00_11_22_33_44_55",
+		Offset:           2,
+		MTU:              23,
+		Link:             "BR/EDR",
+		Type:             WriteTypeReliable,
+		PrepareAuthorize: true,
+		Options:          options,
+	}, NewWriteRequest(options))
+}
+
+// charValue read the Value property exposed by the app
+func charValue(t *testing.T, obj dbus.BusObject) []byte {
+	t.Helper()
+	v, err := obj.GetProperty(gatt.GattCharacteristic1Interface + ".Value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v.Value().([]byte)
+}
+
+func TestCharRequestCallbacks(t *testing.T) {
+	reads := make(chan ReadRequest, 1)
+	writes := make(chan WriteRequest, 2)
+	_, obj, _ := startCharApp(t, func(c *Char) {
+		c.OnReadRequest(func(c *Char, req ReadRequest) ([]byte, error) {
+			reads <- req
+			return []byte{1, 2, 3}[req.Offset:], nil
+		})
+		c.OnWriteRequest(func(c *Char, req WriteRequest, value []byte) ([]byte, error) {
+			writes <- req
+			return value, nil
+		})
+	}, gatt.FlagCharacteristicRead, gatt.FlagCharacteristicWrite)
+
+	dev := dbus.ObjectPath("/org/bluez/hci0/dev_00_11_22_33_44_55")
+
+	var value []byte
+	err := obj.Call(gatt.GattCharacteristic1Interface+".ReadValue", 0, map[string]dbus.Variant{
+		"device": dbus.MakeVariant(dev),
+		"offset": dbus.MakeVariant(uint16(1)),
+	}).Store(&value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{2, 3}, value)
+	req := <-reads
+	assert.Equal(t, dev, req.Device)
+	assert.Equal(t, uint16(1), req.Offset)
+
+	// prepare authorization does not store the value
+	err = obj.Call(gatt.GattCharacteristic1Interface+".WriteValue", 0, []byte{9}, map[string]dbus.Variant{
+		"prepare-authorize": dbus.MakeVariant(true),
+	}).Err
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, (<-writes).PrepareAuthorize)
+	assert.Empty(t, charValue(t, obj))
+
+	err = obj.Call(gatt.GattCharacteristic1Interface+".WriteValue", 0, []byte{9}, map[string]dbus.Variant{
+		"device": dbus.MakeVariant(dev),
+		"type":   dbus.MakeVariant(WriteTypeRequest),
+	}).Err
+	if err != nil {
+		t.Fatal(err)
+	}
+	req2 := <-writes
+	assert.Equal(t, dev, req2.Device)
+	assert.Equal(t, WriteTypeRequest, req2.Type)
+	assert.Equal(t, []byte{9}, charValue(t, obj))
+}
+
+func TestCharLegacyCallbacks(t *testing.T) {
+
+	c := &Char{}
+	c.OnRead(func(c *Char, options map[string]interface{}) ([]byte, error) {
+		return []byte{0, 1, 2, 3, byte(options["offset"].(uint16))}, nil
+	})
+	c.OnWrite(func(c *Char, value []byte) ([]byte, error) {
+		return append(value, 0), nil
+	})
+
+	value, err := c.readCallback(c, NewReadRequest(map[string]interface{}{"offset": uint16(4)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{4}, value)
+
+	value, err = c.writeCallback(c, NewWriteRequest(nil), []byte{7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{7, 0}, value)
+
+	c.OnRead(nil)
+	assert.Nil(t, c.readCallback)
+
+	d := &Descr{}
+	d.OnWrite(func(d *Descr, value []byte) ([]byte, error) {
+		return value[1:], nil
+	})
+	value, err = d.writeCallback(d, NewWriteRequest(nil), []byte{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{2}, value)
+}
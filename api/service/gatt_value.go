@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile"
+)
+
+// MaxValueLength is the maximum length of an attribute value
+const MaxValueLength = 512
+
+// readOffset return the value starting at offset
+func readOffset(value []byte, offset uint16) ([]byte, *dbus.Error) {
+	if int(offset) > len(value) {
+		return nil, &profile.ErrInvalidOffset
+	}
+	return value[offset:], nil
+}
+
+// writeOffset return the value resulting from writing a fragment at offset, the value is truncated
+// after the fragment. maxLength defaults to MaxValueLength when 0
+func writeOffset(value []byte, fragment []byte, offset uint16, maxLength uint16) ([]byte, *dbus.Error) {
+	if maxLength == 0 {
+		maxLength = MaxValueLength
+	}
+	if int(offset) > len(value) {
+		return nil, &profile.ErrInvalidOffset
+	}
+	if int(offset)+len(fragment) > int(maxLength) {
+		return nil, &profile.ErrInvalidValueLength
+	}
+	return fragmentWrite(value, fragment, offset), nil
+}
+
+// fragmentRead return the value read at offset from the whole value returned by an OnRead callback
+func fragmentRead(value []byte, offset uint16) ([]byte, error) {
+	b, derr := readOffset(value, offset)
+	if derr != nil {
+		return nil, derr
+	}
+	return b, nil
+}
+
+// fragmentWrite write at offset the fragment returned by an OnWrite callback
+func fragmentWrite(value []byte, fragment []byte, offset uint16) []byte {
+	res := make([]byte, 0, int(offset)+len(fragment))
+	res = append(res, value[:offset]...)
+	return append(res, fragment...)
+}
+
+// callbackError convert an error returned by a callback. DBus and bluez errors, eg.
+// bluez.ErrInvalidOffset, are returned to the client, others as org.bluez.Error.Failed
+func callbackError(err error) *dbus.Error {
+	var dberr *dbus.Error
+	if errors.As(err, &dberr) {
+		return dberr
+	}
+	var dbval dbus.Error
+	if errors.As(err, &dbval) {
+		return &dbval
+	}
+	var berr *bluez.Error
+	if errors.As(err, &berr) {
+		msg := berr.Message
+		if msg == "" {
+			msg = berr.Name
+		}
+		return dbus.NewError(berr.Name, []interface{}{msg})
+	}
+	return dbus.MakeFailedError(err)
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOffset(t *testing.T) {
+	tests := []struct {
+		offset uint16
+		expect []byte
+		err    *dbus.Error
+	}{
+		{0, []byte{1, 2, 3}, nil},
+		{2, []byte{3}, nil},
+		{3, []byte{}, nil},
+		{4, nil, &profile.ErrInvalidOffset},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("offset %d", tt.offset), func(t *testing.T) {
+			value, err := readOffset([]byte{1, 2, 3}, tt.offset)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expect, value)
+		})
+	}
+}
+
+func TestWriteOffset(t *testing.T) {
+	tests := []struct {
+		name      string
+		fragment  []byte
+		offset    uint16
+		maxLength uint16
+		expect    []byte
+		err       *dbus.Error
+	}{
+		{"replace", []byte{9}, 0, 0, []byte{9}, nil},
+		{"append", []byte{4, 5}, 3, 0, []byte{1, 2, 3, 4, 5}, nil},
+		{"truncate", []byte{9}, 1, 0, []byte{1, 9}, nil},
+		{"invalid offset", []byte{9}, 4, 0, nil, &profile.ErrInvalidOffset},
+		{"max length", []byte{4, 5}, 3, 4, nil, &profile.ErrInvalidValueLength},
+		{"default max length", make([]byte, MaxValueLength), 1, 0, nil, &profile.ErrInvalidValueLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := writeOffset([]byte{1, 2, 3}, tt.fragment, tt.offset, tt.maxLength)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expect, value)
+		})
+	}
+}
+
+func TestCallbackError(t *testing.T) {
+	assert.Equal(t, &profile.ErrInvalidOffset, callbackError(&profile.ErrInvalidOffset))
+	assert.Equal(t, profile.ErrNotPermitted.Name, callbackError(profile.ErrNotPermitted).Name)
+	assert.Equal(t, bluez.ErrInvalidValueLength.Name, callbackError(fmt.Errorf("check: %w", bluez.ErrInvalidValueLength)).Name)
+	assert.Equal(t, "org.freedesktop.DBus.Error.Failed", callbackError(errors.New("failed")).Name)
+}
+
+func TestCharLongValue(t *testing.T) {
+	_, obj, _ := startCharApp(t, func(c *Char) {
+		c.MaxLength = 40
+	}, gatt.FlagCharacteristicRead, gatt.FlagCharacteristicWrite)
+
+	write := func(value []byte, offset uint16) error {
+		return obj.Call(gatt.GattCharacteristic1Interface+".WriteValue", 0, value, map[string]dbus.Variant{
+			"offset": dbus.MakeVariant(offset),
+			"type":   dbus.MakeVariant(WriteTypeReliable),
+		}).Err
+	}
+	read := func(offset uint16) ([]byte, error) {
+		var value []byte
+		err := obj.Call(gatt.GattCharacteristic1Interface+".ReadValue", 0, map[string]dbus.Variant{
+			"offset": dbus.MakeVariant(offset),
+		}).Store(&value)
+		return value, err
+	}
+
+	long := make([]byte, 40)
+	for i := range long {
+		long[i] = byte(i)
+	}
+
+	// prepared writes are executed in order of offset
+	for offset := 0; offset < len(long); offset += 18 {
+		end := offset + 18
+		if end > len(long) {
+			end = len(long)
+		}
+		err := write(long[offset:end], uint16(offset))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.Equal(t, long, charValue(t, obj))
+
+	// long read
+	value := []byte{}
+	for {
+		chunk, err := read(uint16(len(value)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chunk) == 0 {
+			break
+		}
+		if len(chunk) > 22 {
+			chunk = chunk[:22]
+		}
+		value = append(value, chunk...)
+	}
+	assert.Equal(t, long, value)
+
+	_, err := read(41)
+	assert.True(t, errors.Is(bluez.ConvertError(err), bluez.ErrInvalidOffset))
+	err = write([]byte{1}, 41)
+	assert.True(t, errors.Is(bluez.ConvertError(err), bluez.ErrInvalidOffset))
+	err = write([]byte{1, 2}, 39)
+	assert.True(t, errors.Is(bluez.ConvertError(err), bluez.ErrInvalidValueLength))
+}
+
+func TestCharLegacyOffset(t *testing.T) {
+	fragments := [][]byte{}
+	_, obj, _ := startCharApp(t, func(c *Char) {
+		c.OnRead(func(c *Char, options map[string]interface{}) ([]byte, error) {
+			return []byte{1, 2, 3, 4}, nil
+		})
+		c.OnWrite(func(c *Char, value []byte) ([]byte, error) {
+			fragments = append(fragments, value)
+			return value, nil
+		})
+	}, gatt.FlagCharacteristicRead, gatt.FlagCharacteristicWrite)
+
+	// OnRead returns the whole value
+	var value []byte
+	err := obj.Call(gatt.GattCharacteristic1Interface+".ReadValue", 0, map[string]dbus.Variant{
+		"offset": dbus.MakeVariant(uint16(3)),
+	}).Store(&value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{4}, value)
+
+	// OnWrite receives the written fragments
+	for _, offset := range []uint16{0, 2} {
+		err = obj.Call(gatt.GattCharacteristic1Interface+".WriteValue", 0, []byte{5, 6}, map[string]dbus.Variant{
+			"offset": dbus.MakeVariant(offset),
+		}).Err
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.Equal(t, [][]byte{{5, 6}, {5, 6}}, fragments)
+	assert.Equal(t, []byte{5, 6, 5, 6}, charValue(t, obj))
+}
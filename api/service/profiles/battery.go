@@ -0,0 +1,72 @@
+package profiles
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/muka/go-bluetooth/api/service"
+)
+
+// batteryDefinition the Battery Service GATT definition
+type batteryDefinition struct {
+	Battery struct {
+		Level *service.Char `gatt:"2A19,read,notify" hex:"00"`
+	} `gatt:"180F"`
+}
+
+// BatteryService exposes the battery level, as a percentage
+type BatteryService struct {
+	lock  sync.Mutex
+	level uint8
+	char  *service.Char
+}
+
+// NewBatteryService add a Battery Service to the app
+func NewBatteryService(app *service.App, level uint8) (*BatteryService, error) {
+
+	if level > 100 {
+		return nil, fmt.Errorf("Battery level %d exceeds 100%%", level)
+	}
+
+	def := new(batteryDefinition)
+	err := app.LoadStruct(def, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &BatteryService{
+		char: def.Battery.Level,
+	}
+
+	err = s.SetLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Char return the Battery Level characteristic
+func (s *BatteryService) Char() *service.Char {
+	return s.char
+}
+
+// Level return the battery level
+func (s *BatteryService) Level() uint8 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.level
+}
+
+// SetLevel update the battery level, notifying the subscribed clients
+func (s *BatteryService) SetLevel(level uint8) error {
+	if level > 100 {
+		return fmt.Errorf("Battery level %d exceeds 100%%", level)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.level = level
+	return s.char.SetValue([]byte{level})
+}
@@ -0,0 +1,33 @@
+package profiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatteryService(t *testing.T) {
+	a := createTestApp(t)
+
+	_, err := NewBatteryService(a.app, 101)
+	assert.Error(t, err)
+
+	s, err := NewBatteryService(a.app, 87)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint8(87), s.Level())
+	assert.Equal(t, []byte{87}, a.read(t, s.Char(), 0))
+
+	values := a.subscribe(t, s.Char())
+
+	err = s.SetLevel(50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{50}, receive(t, values))
+	assert.Equal(t, uint8(50), s.Level())
+
+	assert.Error(t, s.SetLevel(120))
+	assert.Equal(t, uint8(50), s.Level())
+}
@@ -0,0 +1,71 @@
+package profiles
+
+import (
+	"time"
+
+	"github.com/muka/go-bluetooth/api/service"
+	"github.com/muka/go-bluetooth/api/standard"
+	"github.com/muka/go-bluetooth/bluez"
+)
+
+// currentTimeDefinition the Current Time service GATT definition
+type currentTimeDefinition struct {
+	CurrentTime struct {
+		Time     *service.Char `gatt:"2A2B,read,notify" handler:"time"`
+		TimeZone *service.Char `gatt:"2A0F,read" handler:"zone"`
+	} `gatt:"1805"`
+}
+
+// CurrentTimeService exposes the current time
+type CurrentTimeService struct {
+	now  func() time.Time
+	char *service.Char
+}
+
+// NewCurrentTimeService add a Current Time service to the app, now provides the
+// exposed time and defaults to time.Now
+func NewCurrentTimeService(app *service.App, now func() time.Time) (*CurrentTimeService, error) {
+
+	if now == nil {
+		now = time.Now
+	}
+
+	s := &CurrentTimeService{
+		now: now,
+	}
+
+	def := new(currentTimeDefinition)
+	err := app.LoadStruct(def, service.Handlers{
+		"time": func(c *service.Char, req service.ReadRequest) ([]byte, error) {
+			return readAt(standard.EncodeCurrentTime(s.now(), 0), req.Offset)
+		},
+		"zone": func(c *service.Char, req service.ReadRequest) ([]byte, error) {
+			return readAt(standard.EncodeLocalTimeInformation(s.now()), req.Offset)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.char = def.CurrentTime.Time
+	return s, nil
+}
+
+// Char return the Current Time characteristic
+func (s *CurrentTimeService) Char() *service.Char {
+	return s.char
+}
+
+// Adjust notify the subscribed clients the time changed, reason is a
+// combination of the standard.AdjustReason flags
+func (s *CurrentTimeService) Adjust(reason uint8) error {
+	return s.char.SetValue(standard.EncodeCurrentTime(s.now(), reason))
+}
+
+// readAt return value starting at offset
+func readAt(value []byte, offset uint16) ([]byte, error) {
+	if int(offset) > len(value) {
+		return nil, bluez.ErrInvalidOffset
+	}
+	return value[offset:], nil
+}
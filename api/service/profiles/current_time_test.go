@@ -0,0 +1,32 @@
+package profiles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/muka/go-bluetooth/api/standard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentTimeService(t *testing.T) {
+	a := createTestApp(t)
+
+	now := time.Date(2020, time.March, 1, 13, 14, 15, 0, time.FixedZone("test", 3600))
+	s, err := NewCurrentTimeService(a.app, func() time.Time {
+		return now
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, standard.EncodeCurrentTime(now, 0), a.read(t, s.Char(), 0))
+	assert.Equal(t, standard.EncodeCurrentTime(now, 0)[8:], a.read(t, s.Char(), 8))
+	assert.Equal(t, []byte{4, standard.DSTOffsetUnknown}, a.read(t, a.findChar(t, standard.LocalTimeInformationUUID), 0))
+
+	values := a.subscribe(t, s.Char())
+	err = s.Adjust(standard.AdjustReasonExternalReference)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, standard.EncodeCurrentTime(now, standard.AdjustReasonExternalReference), receive(t, values))
+}
@@ -0,0 +1,80 @@
+package profiles
+
+import (
+	"encoding/hex"
+
+	"github.com/muka/go-bluetooth/api/service"
+	"github.com/muka/go-bluetooth/api/standard"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
+)
+
+// DeviceInformation holds the values exposed by the Device Information service,
+// characteristics are added only for the non empty values
+type DeviceInformation struct {
+	ManufacturerName string
+	ModelNumber      string
+	SerialNumber     string
+	HardwareRevision string
+	FirmwareRevision string
+	SoftwareRevision string
+	SystemID         *standard.SystemID
+	PnPID            *standard.PnPID
+}
+
+// DeviceInformationService exposes the device information
+type DeviceInformationService struct {
+	info DeviceInformation
+}
+
+// NewDeviceInformationService add a Device Information service to the app
+func NewDeviceInformationService(app *service.App, info DeviceInformation) (*DeviceInformationService, error) {
+
+	sdef := service.ServiceDefinition{
+		UUID: standard.DeviceInformationServiceUUID,
+	}
+
+	addString := func(uuid, value string) {
+		if value == "" {
+			return
+		}
+		sdef.Characteristics = append(sdef.Characteristics, service.CharDefinition{
+			UUID:  uuid,
+			Flags: []string{gatt.FlagCharacteristicRead},
+			Value: value,
+		})
+	}
+	addBytes := func(uuid string, value []byte) {
+		sdef.Characteristics = append(sdef.Characteristics, service.CharDefinition{
+			UUID:  uuid,
+			Flags: []string{gatt.FlagCharacteristicRead},
+			Hex:   hex.EncodeToString(value),
+		})
+	}
+
+	addString(standard.ManufacturerNameUUID, info.ManufacturerName)
+	addString(standard.ModelNumberUUID, info.ModelNumber)
+	addString(standard.SerialNumberUUID, info.SerialNumber)
+	addString(standard.HardwareRevisionUUID, info.HardwareRevision)
+	addString(standard.FirmwareRevisionUUID, info.FirmwareRevision)
+	addString(standard.SoftwareRevisionUUID, info.SoftwareRevision)
+	if info.SystemID != nil {
+		addBytes(standard.SystemIDUUID, info.SystemID.Encode())
+	}
+	if info.PnPID != nil {
+		addBytes(standard.PnPIDUUID, info.PnPID.Encode())
+	}
+
+	err := app.Load(&service.Definition{
+		Services: []service.ServiceDefinition{sdef},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeviceInformationService{info}, nil
+}
+
+// Info return the exposed device information
+func (s *DeviceInformationService) Info() DeviceInformation {
+	return s.info
+}
@@ -0,0 +1,42 @@
+package profiles
+
+import (
+	"testing"
+
+	"github.com/muka/go-bluetooth/api/standard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceInformationService(t *testing.T) {
+	a := createTestApp(t)
+
+	info := DeviceInformation{
+		ManufacturerName: "ACME",
+		ModelNumber:      "X1",
+		FirmwareRevision: "1.2.3",
+		PnPID: &standard.PnPID{
+			VendorIDSource: standard.VendorIDSourceBluetooth,
+			VendorID:       0x000f,
+			ProductID:      1,
+			ProductVersion: 2,
+		},
+	}
+	s, err := NewDeviceInformationService(a.app, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, info, s.Info())
+
+	services := a.app.GetServices()
+	assert.Len(t, services, 1)
+	for _, svc := range services {
+		assert.Equal(t, "0000180a-0000-1000-8000-00805f9b34fb", svc.UUID)
+		// empty values are not exposed
+		assert.Len(t, svc.GetChars(), 4)
+	}
+
+	assert.Equal(t, []byte("ACME"), a.read(t, a.findChar(t, standard.ManufacturerNameUUID), 0))
+	assert.Equal(t, []byte("X1"), a.read(t, a.findChar(t, standard.ModelNumberUUID), 0))
+	assert.Equal(t, []byte("3"), a.read(t, a.findChar(t, standard.FirmwareRevisionUUID), 4))
+	assert.Equal(t, info.PnPID.Encode(), a.read(t, a.findChar(t, standard.PnPIDUUID), 0))
+}
@@ -0,0 +1,85 @@
+package profiles
+
+import (
+	"sync"
+
+	"github.com/muka/go-bluetooth/api/service"
+	"github.com/muka/go-bluetooth/api/standard"
+	"github.com/muka/go-bluetooth/bluez"
+)
+
+// heartRateDefinition the Heart Rate service GATT definition
+type heartRateDefinition struct {
+	HeartRate struct {
+		Measurement  *service.Char `gatt:"2A37,notify"`
+		Location     *service.Char `gatt:"2A38,read" hex:"00"`
+		ControlPoint *service.Char `gatt:"2A39,write" handler:"control"`
+	} `gatt:"180D"`
+}
+
+// HeartRateService exposes heart rate measurements
+type HeartRateService struct {
+	lock          sync.Mutex
+	resetCallback func()
+	measurement   *service.Char
+}
+
+// NewHeartRateService add a Heart Rate service to the app, location is one
+// of the standard.BodySensorLocation values
+func NewHeartRateService(app *service.App, location uint8) (*HeartRateService, error) {
+
+	s := &HeartRateService{}
+
+	def := new(heartRateDefinition)
+	err := app.LoadStruct(def, service.Handlers{
+		"control": s.writeControlPoint,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = def.HeartRate.Location.SetValue([]byte{location})
+	if err != nil {
+		return nil, err
+	}
+
+	s.measurement = def.HeartRate.Measurement
+	return s, nil
+}
+
+// Char return the Heart Rate Measurement characteristic
+func (s *HeartRateService) Char() *service.Char {
+	return s.measurement
+}
+
+// OnResetEnergyExpended set the callback invoked when a client resets the
+// energy expended via the control point
+func (s *HeartRateService) OnResetEnergyExpended(fn func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.resetCallback = fn
+}
+
+// Notify send a measurement to the subscribed clients. It returns
+// service.ErrNotNotifying when no client is subscribed
+func (s *HeartRateService) Notify(m standard.HeartRateMeasurement) error {
+	return s.measurement.Notify(m.Encode())
+}
+
+// writeControlPoint handles the control point commands
+func (s *HeartRateService) writeControlPoint(c *service.Char, req service.WriteRequest, value []byte) ([]byte, error) {
+	if len(value) != 1 || value[0] != standard.HeartRateResetEnergyExpended {
+		// bluez reports Failed as ATT error 0x80, Control Point Not Supported
+		return nil, bluez.ErrFailed
+	}
+
+	s.lock.Lock()
+	fn := s.resetCallback
+	s.lock.Unlock()
+
+	if fn != nil {
+		fn()
+	}
+
+	return nil, nil
+}
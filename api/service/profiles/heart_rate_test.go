@@ -0,0 +1,44 @@
+package profiles
+
+import (
+	"testing"
+
+	"github.com/muka/go-bluetooth/api/service"
+	"github.com/muka/go-bluetooth/api/standard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartRateService(t *testing.T) {
+	a := createTestApp(t)
+
+	s, err := NewHeartRateService(a.app, standard.BodySensorLocationWrist)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resets := make(chan bool, 1)
+	s.OnResetEnergyExpended(func() {
+		resets <- true
+	})
+
+	assert.Equal(t, []byte{standard.BodySensorLocationWrist}, a.read(t, a.findChar(t, standard.BodySensorLocationUUID), 0))
+
+	m := standard.HeartRateMeasurement{HeartRate: 72}
+	assert.Equal(t, service.ErrNotNotifying, s.Notify(m))
+
+	values := a.subscribe(t, s.Char())
+	err = s.Notify(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, m.Encode(), receive(t, values))
+
+	control := a.findChar(t, standard.HeartRateControlPointUUID)
+	err = a.write(control, []byte{standard.HeartRateResetEnergyExpended})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, <-resets)
+
+	assert.Error(t, a.write(control, []byte{0x02}))
+}
@@ -0,0 +1,7 @@
+// Package profiles implements standard GATT services for the peripheral side,
+// on top of service.App. The UUIDs and value formats are defined in api/standard.
+//
+// bluez manages the Client Characteristic Configuration descriptor of
+// characteristics with the notify or indicate flag: setters like
+// BatteryService.SetLevel notify the clients which enabled notifications.
+package profiles
@@ -0,0 +1,124 @@
+package profiles
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/api/service"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/fake"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
+)
+
+// testApp is an app exposed on a fake bluez, with a remote connection
+type testApp struct {
+	app    *service.App
+	remote *bluez.Conn
+}
+
+func createTestApp(t *testing.T) *testApp {
+	b, _ := fake.StartAdapterT(t, "hci0", nil)
+
+	conn, err := b.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app, err := service.NewApp(service.AppOptions{
+		AdapterID: "hci0",
+		Conn:      conn,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote, err := b.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testApp{app, remote}
+}
+
+// object return the remote object of a characteristic
+func (a *testApp) object(c *service.Char) dbus.BusObject {
+	return a.remote.DBus().Object(a.app.DBusConn().Names()[0], c.Path())
+}
+
+// findChar return the characteristic with the SIG uuid
+func (a *testApp) findChar(t *testing.T, uuid string) *service.Char {
+	t.Helper()
+	for _, s := range a.app.GetServices() {
+		for _, c := range s.GetChars() {
+			if bluez.UUID(uuid).Equal(bluez.UUID(c.UUID)) {
+				return c
+			}
+		}
+	}
+	t.Fatalf("Characteristic %s not found", uuid)
+	return nil
+}
+
+func (a *testApp) read(t *testing.T, c *service.Char, offset uint16) []byte {
+	t.Helper()
+	var value []byte
+	err := a.object(c).Call(gatt.GattCharacteristic1Interface+".ReadValue", 0, map[string]dbus.Variant{
+		"offset": dbus.MakeVariant(offset),
+	}).Store(&value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func (a *testApp) write(c *service.Char, value []byte) error {
+	return a.object(c).Call(gatt.GattCharacteristic1Interface+".WriteValue", 0, value, map[string]dbus.Variant{}).Err
+}
+
+// subscribe start notifications on a characteristic, returning the notified values
+func (a *testApp) subscribe(t *testing.T, c *service.Char) chan []byte {
+	t.Helper()
+
+	rule := fmt.Sprintf("type='signal',interface='%s',path='%s'", bluez.PropertiesInterface, c.Path())
+	err := a.remote.DBus().BusObject().Call("org.freedesktop.DBus.AddMatch", 0, rule).Err
+	if err != nil {
+		t.Fatal(err)
+	}
+	signals := make(chan *dbus.Signal, 10)
+	a.remote.DBus().Signal(signals)
+
+	err = a.object(c).Call(gatt.GattCharacteristic1Interface+".StartNotify", 0).Err
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := make(chan []byte, 10)
+	go func() {
+		for sig := range signals {
+			if sig.Name != bluez.PropertiesChanged || len(sig.Body) < 2 {
+				continue
+			}
+			changes, ok := sig.Body[1].(map[string]dbus.Variant)
+			if !ok {
+				continue
+			}
+			if v, ok := changes["Value"]; ok {
+				values <- v.Value().([]byte)
+			}
+		}
+	}()
+	return values
+}
+
+func receive(t *testing.T, values chan []byte) []byte {
+	t.Helper()
+	select {
+	case v := <-values:
+		return v
+	case <-time.After(time.Second * 2):
+		t.Fatal("Value not received")
+		return nil
+	}
+}
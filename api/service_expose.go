@@ -37,7 +37,7 @@ func ExposeDBusService(s ExposedDBusService) (err error) {
 	conn := s.DBusConn()
 
 	if conn == nil {
-		conn, err = dbus.SystemBus()
+		conn, err = bluez.GetConnection(bluez.SystemBus)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,8 @@
+package standard
+
+const (
+	// BatteryServiceUUID Battery Service
+	BatteryServiceUUID = "180F"
+	// BatteryLevelUUID Battery Level characteristic
+	BatteryLevelUUID = "2A19"
+)
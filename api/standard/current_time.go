@@ -0,0 +1,78 @@
+package standard
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// CurrentTimeServiceUUID Current Time service
+	CurrentTimeServiceUUID = "1805"
+	// CurrentTimeUUID Current Time characteristic
+	CurrentTimeUUID = "2A2B"
+	// LocalTimeInformationUUID Local Time Information characteristic
+	LocalTimeInformationUUID = "2A0F"
+)
+
+// Adjust reasons of the Current Time value, they can be combined
+const (
+	AdjustReasonManualUpdate      uint8 = 0x01
+	AdjustReasonExternalReference uint8 = 0x02
+	AdjustReasonTimeZoneChange    uint8 = 0x04
+	AdjustReasonDSTChange         uint8 = 0x08
+)
+
+// CurrentTimeLength is the length of an encoded Current Time value
+const CurrentTimeLength = 10
+
+// DSTOffsetUnknown is the Local Time Information DST offset when the DST is not known
+const DSTOffsetUnknown uint8 = 255
+
+// EncodeCurrentTime encode the local date and time of t as a Current Time value
+func EncodeCurrentTime(t time.Time, reason uint8) []byte {
+	b := make([]byte, 0, CurrentTimeLength)
+	b = putUint16(b, uint16(t.Year()))
+	weekday := uint8(t.Weekday())
+	if weekday == 0 {
+		// 1 is Monday, 7 Sunday
+		weekday = 7
+	}
+	return append(b,
+		uint8(t.Month()),
+		uint8(t.Day()),
+		uint8(t.Hour()),
+		uint8(t.Minute()),
+		uint8(t.Second()),
+		weekday,
+		uint8(t.Nanosecond()*256/int(time.Second)),
+		reason,
+	)
+}
+
+// DecodeCurrentTime decode a Current Time value in loc, returning the time and the adjust reason
+func DecodeCurrentTime(b []byte, loc *time.Location) (time.Time, uint8, error) {
+	if len(b) < CurrentTimeLength {
+		return time.Time{}, 0, fmt.Errorf("Current Time: expected %d bytes, got %d", CurrentTimeLength, len(b))
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	t := time.Date(
+		int(getUint16(b)),
+		time.Month(b[2]),
+		int(b[3]),
+		int(b[4]),
+		int(b[5]),
+		int(b[6]),
+		int(b[8])*int(time.Second)/256,
+		loc,
+	)
+	return t, b[9], nil
+}
+
+// EncodeLocalTimeInformation encode the time zone of t as a Local Time Information value.
+// The offset of t is reported as time zone, the DST offset as unknown
+func EncodeLocalTimeInformation(t time.Time) []byte {
+	_, offset := t.Zone()
+	return []byte{byte(int8(offset / (15 * 60))), DSTOffsetUnknown}
+}
@@ -0,0 +1,62 @@
+package standard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeCurrentTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     time.Time
+		reason   uint8
+		expected []byte
+	}{
+		{
+			"sunday",
+			time.Date(2020, time.March, 1, 13, 14, 15, 0, time.UTC),
+			0,
+			[]byte{0xe4, 0x07, 3, 1, 13, 14, 15, 7, 0, 0},
+		},
+		{
+			"monday fractions",
+			time.Date(2021, time.December, 27, 23, 59, 58, int(time.Second/2), time.UTC),
+			AdjustReasonManualUpdate | AdjustReasonTimeZoneChange,
+			[]byte{0xe5, 0x07, 12, 27, 23, 59, 58, 1, 128, 0x05},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := EncodeCurrentTime(tt.time, tt.reason)
+			assert.Equal(t, tt.expected, b)
+
+			decoded, reason, err := DecodeCurrentTime(b, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.True(t, tt.time.Equal(decoded), "%s != %s", tt.time, decoded)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+
+	_, _, err := DecodeCurrentTime([]byte{0xe4, 0x07}, time.UTC)
+	assert.Error(t, err)
+}
+
+func TestEncodeLocalTimeInformation(t *testing.T) {
+	tests := []struct {
+		offset   int
+		expected byte
+	}{
+		{0, 0},
+		{3600, 4},
+		{-5 * 3600, 0xec},
+		{5*3600 + 45*60, 23},
+	}
+	for _, tt := range tests {
+		tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.FixedZone("test", tt.offset))
+		assert.Equal(t, []byte{tt.expected, DSTOffsetUnknown}, EncodeLocalTimeInformation(tm))
+	}
+}
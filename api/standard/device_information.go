@@ -0,0 +1,65 @@
+package standard
+
+const (
+	// DeviceInformationServiceUUID Device Information service
+	DeviceInformationServiceUUID = "180A"
+	// SystemIDUUID System ID characteristic
+	SystemIDUUID = "2A23"
+	// ModelNumberUUID Model Number String characteristic
+	ModelNumberUUID = "2A24"
+	// SerialNumberUUID Serial Number String characteristic
+	SerialNumberUUID = "2A25"
+	// FirmwareRevisionUUID Firmware Revision String characteristic
+	FirmwareRevisionUUID = "2A26"
+	// HardwareRevisionUUID Hardware Revision String characteristic
+	HardwareRevisionUUID = "2A27"
+	// SoftwareRevisionUUID Software Revision String characteristic
+	SoftwareRevisionUUID = "2A28"
+	// ManufacturerNameUUID Manufacturer Name String characteristic
+	ManufacturerNameUUID = "2A29"
+	// PnPIDUUID PnP ID characteristic
+	PnPIDUUID = "2A50"
+)
+
+// Vendor ID sources of PnPID
+const (
+	VendorIDSourceBluetooth uint8 = 1
+	VendorIDSourceUSB       uint8 = 2
+)
+
+// SystemID is the IEEE 64 bit system identifier
+type SystemID struct {
+	// Manufacturer 40 bit manufacturer defined identifier
+	Manufacturer uint64
+	// OUI 24 bit organizationally unique identifier
+	OUI uint32
+}
+
+// Encode the System ID value
+func (id SystemID) Encode() []byte {
+	b := make([]byte, 8)
+	for i := 0; i < 5; i++ {
+		b[i] = byte(id.Manufacturer >> (8 * i))
+	}
+	for i := 0; i < 3; i++ {
+		b[5+i] = byte(id.OUI >> (8 * i))
+	}
+	return b
+}
+
+// PnPID identifies the device vendor and product
+type PnPID struct {
+	// VendorIDSource VendorIDSourceBluetooth or VendorIDSourceUSB
+	VendorIDSource uint8
+	VendorID       uint16
+	ProductID      uint16
+	ProductVersion uint16
+}
+
+// Encode the PnP ID value
+func (id PnPID) Encode() []byte {
+	b := []byte{id.VendorIDSource}
+	b = putUint16(b, id.VendorID)
+	b = putUint16(b, id.ProductID)
+	return putUint16(b, id.ProductVersion)
+}
@@ -0,0 +1,25 @@
+package standard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemIDEncode(t *testing.T) {
+	id := SystemID{
+		Manufacturer: 0x0504030201,
+		OUI:          0x080706,
+	}
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8}, id.Encode())
+}
+
+func TestPnPIDEncode(t *testing.T) {
+	id := PnPID{
+		VendorIDSource: VendorIDSourceUSB,
+		VendorID:       0x1d6b,
+		ProductID:      0x0246,
+		ProductVersion: 0x0537,
+	}
+	assert.Equal(t, []byte{2, 0x6b, 0x1d, 0x46, 0x02, 0x37, 0x05}, id.Encode())
+}
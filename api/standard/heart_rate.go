@@ -0,0 +1,134 @@
+package standard
+
+import (
+	"fmt"
+)
+
+const (
+	// HeartRateServiceUUID Heart Rate service
+	HeartRateServiceUUID = "180D"
+	// HeartRateMeasurementUUID Heart Rate Measurement characteristic
+	HeartRateMeasurementUUID = "2A37"
+	// BodySensorLocationUUID Body Sensor Location characteristic
+	BodySensorLocationUUID = "2A38"
+	// HeartRateControlPointUUID Heart Rate Control Point characteristic
+	HeartRateControlPointUUID = "2A39"
+)
+
+// Body Sensor Location values
+const (
+	BodySensorLocationOther   uint8 = 0
+	BodySensorLocationChest   uint8 = 1
+	BodySensorLocationWrist   uint8 = 2
+	BodySensorLocationFinger  uint8 = 3
+	BodySensorLocationHand    uint8 = 4
+	BodySensorLocationEarLobe uint8 = 5
+	BodySensorLocationFoot    uint8 = 6
+)
+
+// HeartRateResetEnergyExpended is the control point command resetting the energy expended
+const HeartRateResetEnergyExpended uint8 = 0x01
+
+// Heart Rate Measurement flags
+const (
+	heartRateFlagUint16          uint8 = 0x01
+	heartRateFlagContactDetected uint8 = 0x02
+	heartRateFlagContactSupport  uint8 = 0x04
+	heartRateFlagEnergyExpended  uint8 = 0x08
+	heartRateFlagRRIntervals     uint8 = 0x10
+)
+
+// HeartRateMeasurement is a Heart Rate Measurement value
+type HeartRateMeasurement struct {
+	// HeartRate in beats per minute
+	HeartRate uint16
+	// SensorContact is nil when sensor contact detection is not supported
+	SensorContact *bool
+	// EnergyExpended in kJ, nil when not reported
+	EnergyExpended *uint16
+	// RRIntervals in 1/1024 seconds
+	RRIntervals []uint16
+}
+
+// Encode the measurement. The heart rate is encoded as uint8 when it fits
+func (m HeartRateMeasurement) Encode() []byte {
+	flags := uint8(0)
+	b := []byte{0}
+
+	if m.HeartRate > 0xff {
+		flags |= heartRateFlagUint16
+		b = putUint16(b, m.HeartRate)
+	} else {
+		b = append(b, uint8(m.HeartRate))
+	}
+
+	if m.SensorContact != nil {
+		flags |= heartRateFlagContactSupport
+		if *m.SensorContact {
+			flags |= heartRateFlagContactDetected
+		}
+	}
+
+	if m.EnergyExpended != nil {
+		flags |= heartRateFlagEnergyExpended
+		b = putUint16(b, *m.EnergyExpended)
+	}
+
+	if len(m.RRIntervals) > 0 {
+		flags |= heartRateFlagRRIntervals
+		for _, rr := range m.RRIntervals {
+			b = putUint16(b, rr)
+		}
+	}
+
+	b[0] = flags
+	return b
+}
+
+// DecodeHeartRateMeasurement decode a Heart Rate Measurement value
+func DecodeHeartRateMeasurement(b []byte) (HeartRateMeasurement, error) {
+	m := HeartRateMeasurement{}
+
+	if len(b) < 2 {
+		return m, fmt.Errorf("Heart Rate Measurement: value too short (%d bytes)", len(b))
+	}
+
+	flags := b[0]
+	b = b[1:]
+
+	if flags&heartRateFlagUint16 != 0 {
+		if len(b) < 2 {
+			return m, fmt.Errorf("Heart Rate Measurement: missing heart rate")
+		}
+		m.HeartRate = getUint16(b)
+		b = b[2:]
+	} else {
+		m.HeartRate = uint16(b[0])
+		b = b[1:]
+	}
+
+	if flags&heartRateFlagContactSupport != 0 {
+		contact := flags&heartRateFlagContactDetected != 0
+		m.SensorContact = &contact
+	}
+
+	if flags&heartRateFlagEnergyExpended != 0 {
+		if len(b) < 2 {
+			return m, fmt.Errorf("Heart Rate Measurement: missing energy expended")
+		}
+		energy := getUint16(b)
+		m.EnergyExpended = &energy
+		b = b[2:]
+	}
+
+	if flags&heartRateFlagRRIntervals != 0 {
+		if len(b)%2 != 0 {
+			return m, fmt.Errorf("Heart Rate Measurement: invalid RR intervals length %d", len(b))
+		}
+		for ; len(b) > 0; b = b[2:] {
+			m.RRIntervals = append(m.RRIntervals, getUint16(b))
+		}
+	}
+
+	return m, nil
+}
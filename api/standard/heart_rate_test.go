@@ -0,0 +1,75 @@
+package standard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartRateMeasurement(t *testing.T) {
+	contact := true
+	noContact := false
+	energy := uint16(0x0102)
+
+	tests := []struct {
+		name        string
+		measurement HeartRateMeasurement
+		expected    []byte
+	}{
+		{
+			"uint8",
+			HeartRateMeasurement{HeartRate: 72},
+			[]byte{0x00, 72},
+		},
+		{
+			"uint16",
+			HeartRateMeasurement{HeartRate: 300},
+			[]byte{0x01, 0x2c, 0x01},
+		},
+		{
+			"contact detected",
+			HeartRateMeasurement{HeartRate: 60, SensorContact: &contact},
+			[]byte{0x06, 60},
+		},
+		{
+			"contact not detected",
+			HeartRateMeasurement{HeartRate: 60, SensorContact: &noContact},
+			[]byte{0x04, 60},
+		},
+		{
+			"energy and rr",
+			HeartRateMeasurement{HeartRate: 80, EnergyExpended: &energy, RRIntervals: []uint16{1024, 512}},
+			[]byte{0x18, 80, 0x02, 0x01, 0x00, 0x04, 0x00, 0x02},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.measurement.Encode()
+			assert.Equal(t, tt.expected, b)
+
+			m, err := DecodeHeartRateMeasurement(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.measurement, m)
+		})
+	}
+}
+
+func TestDecodeHeartRateMeasurementInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value []byte
+	}{
+		{"empty", []byte{}},
+		{"uint16", []byte{0x01, 0x2c}},
+		{"energy", []byte{0x08, 60, 0x01}},
+		{"rr", []byte{0x10, 60, 0x00, 0x04, 0x01}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeHeartRateMeasurement(tt.value)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+// Package standard holds the UUIDs and value formats of standard GATT services
+// and characteristics. It is shared by the peripheral profiles of
+// api/service/profiles and the client decoders of api/client/decoder.
+package standard
+
+import (
+	"encoding/binary"
+)
+
+// putUint16 append a little endian uint16
+func putUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v), byte(v>>8))
+}
+
+// getUint16 read a little endian uint16
+func getUint16(b []byte) uint16 {
+	return binary.LittleEndian.Uint16(b)
+}
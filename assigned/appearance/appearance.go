@@ -0,0 +1,31 @@
+// Package appearance resolves the GAP appearance values assigned by the Bluetooth SIG
+package appearance
+
+type category struct {
+	name          string
+	subcategories map[uint16]string
+}
+
+// Category return the category of an appearance value
+func Category(value uint16) uint16 {
+	return value >> 6
+}
+
+// Subcategory return the subcategory of an appearance value
+func Subcategory(value uint16) uint16 {
+	return value & 0x3f
+}
+
+// Name return the name of an appearance value, eg. "Watch: Sports Watch".
+// The category name is returned for unknown subcategories, an empty string for
+// unknown categories
+func Name(value uint16) string {
+	c, ok := categories[Category(value)]
+	if !ok {
+		return ""
+	}
+	if sub, ok := c.subcategories[Subcategory(value)]; ok {
+		return c.name + ": " + sub
+	}
+	return c.name
+}
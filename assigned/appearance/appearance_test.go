@@ -0,0 +1,32 @@
+package appearance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		value    uint16
+		expected string
+	}{
+		{0x0000, "Unknown"},
+		{0x0040, "Phone"},
+		{0x00C0, "Watch"},
+		{0x00C1, "Watch: Sports Watch"},
+		{0x03C1, "Human Interface Device: Keyboard"},
+		{0x03C2, "Human Interface Device: Mouse"},
+		{0x0485, "Cycling: Speed and Cadence Sensor"},
+		// unknown subcategory
+		{0x00CF, "Watch"},
+		// unknown category
+		{0xFFC0, ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Name(tt.value), "0x%04X", tt.value)
+	}
+
+	assert.Equal(t, uint16(0x012), Category(0x0485))
+	assert.Equal(t, uint16(0x05), Subcategory(0x0485))
+}
@@ -0,0 +1,124 @@
+// Code generated by go-bluetooth generator DO NOT EDIT.
+
+package appearance
+
+var categories = map[uint16]category{
+	0x0000: {
+		name: "Unknown",
+	},
+	0x0001: {
+		name: "Phone",
+	},
+	0x0002: {
+		name: "Computer",
+	},
+	0x0003: {
+		name: "Watch",
+		subcategories: map[uint16]string{
+			0x0001: "Sports Watch",
+			0x0002: "Smartwatch",
+		},
+	},
+	0x0004: {
+		name: "Clock",
+	},
+	0x0005: {
+		name: "Display",
+	},
+	0x0006: {
+		name: "Remote Control",
+	},
+	0x0007: {
+		name: "Eye-glasses",
+	},
+	0x0008: {
+		name: "Tag",
+	},
+	0x0009: {
+		name: "Keyring",
+	},
+	0x000A: {
+		name: "Media Player",
+	},
+	0x000B: {
+		name: "Barcode Scanner",
+	},
+	0x000C: {
+		name: "Thermometer",
+		subcategories: map[uint16]string{
+			0x0001: "Ear Thermometer",
+		},
+	},
+	0x000D: {
+		name: "Heart Rate Sensor",
+		subcategories: map[uint16]string{
+			0x0001: "Heart Rate Belt",
+		},
+	},
+	0x000E: {
+		name: "Blood Pressure",
+		subcategories: map[uint16]string{
+			0x0001: "Arm Blood Pressure",
+			0x0002: "Wrist Blood Pressure",
+		},
+	},
+	0x000F: {
+		name: "Human Interface Device",
+		subcategories: map[uint16]string{
+			0x0001: "Keyboard",
+			0x0002: "Mouse",
+			0x0003: "Joystick",
+			0x0004: "Gamepad",
+			0x0005: "Digitizer Tablet",
+			0x0006: "Card Reader",
+			0x0007: "Digital Pen",
+			0x0008: "Barcode Scanner",
+		},
+	},
+	0x0010: {
+		name: "Glucose Meter",
+	},
+	0x0011: {
+		name: "Running Walking Sensor",
+		subcategories: map[uint16]string{
+			0x0001: "In-Shoe Running Walking Sensor",
+			0x0002: "On-Shoe Running Walking Sensor",
+			0x0003: "On-Hip Running Walking Sensor",
+		},
+	},
+	0x0012: {
+		name: "Cycling",
+		subcategories: map[uint16]string{
+			0x0001: "Cycling Computer",
+			0x0002: "Speed Sensor",
+			0x0003: "Cadence Sensor",
+			0x0004: "Power Sensor",
+			0x0005: "Speed and Cadence Sensor",
+		},
+	},
+	0x0031: {
+		name: "Pulse Oximeter",
+		subcategories: map[uint16]string{
+			0x0001: "Fingertip Pulse Oximeter",
+			0x0002: "Wrist Worn Pulse Oximeter",
+		},
+	},
+	0x0032: {
+		name: "Weight Scale",
+	},
+	0x0033: {
+		name: "Personal Mobility Device",
+	},
+	0x0034: {
+		name: "Continuous Glucose Monitor",
+	},
+	0x0035: {
+		name: "Insulin Pump",
+	},
+	0x0036: {
+		name: "Medication Delivery",
+	},
+	0x0051: {
+		name: "Outdoor Sports Activity",
+	},
+}
@@ -0,0 +1,14 @@
+// Package company resolves the company identifiers assigned by the Bluetooth SIG,
+// as used in the ManufacturerData keys
+package company
+
+// Name return the name of a company identifier, or an empty string if unknown
+func Name(id uint16) string {
+	return companies[id]
+}
+
+// Lookup return the name of a company identifier
+func Lookup(id uint16) (string, bool) {
+	name, ok := companies[id]
+	return name, ok
+}
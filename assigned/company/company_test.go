@@ -0,0 +1,19 @@
+package company
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "Apple, Inc.", Name(0x004C))
+	assert.Equal(t, "Nordic Semiconductor ASA", Name(0x0059))
+	assert.Equal(t, "", Name(0xFFFF))
+
+	_, ok := Lookup(0xFFFF)
+	assert.False(t, ok)
+	name, ok := Lookup(0x00E0)
+	assert.True(t, ok)
+	assert.Equal(t, "Google", name)
+}
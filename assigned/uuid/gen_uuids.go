@@ -0,0 +1,189 @@
+// Code generated by go-bluetooth generator DO NOT EDIT.
+
+package uuid
+
+var assigned = map[uint16]Info{
+	0x1800: {Name: "GAP", ID: "org.bluetooth.service.gap", Type: TypeService},
+	0x1801: {Name: "GATT", ID: "org.bluetooth.service.gatt", Type: TypeService},
+	0x1802: {Name: "Immediate Alert", ID: "org.bluetooth.service.immediate_alert", Type: TypeService},
+	0x1803: {Name: "Link Loss", ID: "org.bluetooth.service.link_loss", Type: TypeService},
+	0x1804: {Name: "Tx Power", ID: "org.bluetooth.service.tx_power", Type: TypeService},
+	0x1805: {Name: "Current Time", ID: "org.bluetooth.service.current_time", Type: TypeService},
+	0x1806: {Name: "Reference Time Update", ID: "org.bluetooth.service.reference_time_update", Type: TypeService},
+	0x1807: {Name: "Next DST Change", ID: "org.bluetooth.service.next_dst_change", Type: TypeService},
+	0x1808: {Name: "Glucose", ID: "org.bluetooth.service.glucose", Type: TypeService},
+	0x1809: {Name: "Health Thermometer", ID: "org.bluetooth.service.health_thermometer", Type: TypeService},
+	0x180A: {Name: "Device Information", ID: "org.bluetooth.service.device_information", Type: TypeService},
+	0x180D: {Name: "Heart Rate", ID: "org.bluetooth.service.heart_rate", Type: TypeService},
+	0x180E: {Name: "Phone Alert Status", ID: "org.bluetooth.service.phone_alert_status", Type: TypeService},
+	0x180F: {Name: "Battery", ID: "org.bluetooth.service.battery_service", Type: TypeService},
+	0x1810: {Name: "Blood Pressure", ID: "org.bluetooth.service.blood_pressure", Type: TypeService},
+	0x1811: {Name: "Alert Notification", ID: "org.bluetooth.service.alert_notification", Type: TypeService},
+	0x1812: {Name: "Human Interface Device", ID: "org.bluetooth.service.human_interface_device", Type: TypeService},
+	0x1813: {Name: "Scan Parameters", ID: "org.bluetooth.service.scan_parameters", Type: TypeService},
+	0x1814: {Name: "Running Speed and Cadence", ID: "org.bluetooth.service.running_speed_and_cadence", Type: TypeService},
+	0x1815: {Name: "Automation IO", ID: "org.bluetooth.service.automation_io", Type: TypeService},
+	0x1816: {Name: "Cycling Speed and Cadence", ID: "org.bluetooth.service.cycling_speed_and_cadence", Type: TypeService},
+	0x1818: {Name: "Cycling Power", ID: "org.bluetooth.service.cycling_power", Type: TypeService},
+	0x1819: {Name: "Location and Navigation", ID: "org.bluetooth.service.location_and_navigation", Type: TypeService},
+	0x181A: {Name: "Environmental Sensing", ID: "org.bluetooth.service.environmental_sensing", Type: TypeService},
+	0x181B: {Name: "Body Composition", ID: "org.bluetooth.service.body_composition", Type: TypeService},
+	0x181C: {Name: "User Data", ID: "org.bluetooth.service.user_data", Type: TypeService},
+	0x181D: {Name: "Weight Scale", ID: "org.bluetooth.service.weight_scale", Type: TypeService},
+	0x181E: {Name: "Bond Management", ID: "org.bluetooth.service.bond_management", Type: TypeService},
+	0x181F: {Name: "Continuous Glucose Monitoring", ID: "org.bluetooth.service.continuous_glucose_monitoring", Type: TypeService},
+	0x1820: {Name: "Internet Protocol Support", ID: "org.bluetooth.service.internet_protocol_support", Type: TypeService},
+	0x1821: {Name: "Indoor Positioning", ID: "org.bluetooth.service.indoor_positioning", Type: TypeService},
+	0x1822: {Name: "Pulse Oximeter", ID: "org.bluetooth.service.pulse_oximeter", Type: TypeService},
+	0x1823: {Name: "HTTP Proxy", ID: "org.bluetooth.service.http_proxy", Type: TypeService},
+	0x1824: {Name: "Transport Discovery", ID: "org.bluetooth.service.transport_discovery", Type: TypeService},
+	0x1825: {Name: "Object Transfer", ID: "org.bluetooth.service.object_transfer", Type: TypeService},
+	0x1826: {Name: "Fitness Machine", ID: "org.bluetooth.service.fitness_machine", Type: TypeService},
+	0x1827: {Name: "Mesh Provisioning", ID: "org.bluetooth.service.mesh_provisioning", Type: TypeService},
+	0x1828: {Name: "Mesh Proxy", ID: "org.bluetooth.service.mesh_proxy", Type: TypeService},
+	0x1829: {Name: "Reconnection Configuration", ID: "org.bluetooth.service.reconnection_configuration", Type: TypeService},
+	0x183A: {Name: "Insulin Delivery", ID: "org.bluetooth.service.insulin_delivery", Type: TypeService},
+	0x183B: {Name: "Binary Sensor", ID: "org.bluetooth.service.binary_sensor", Type: TypeService},
+	0x183C: {Name: "Emergency Configuration", ID: "org.bluetooth.service.emergency_configuration", Type: TypeService},
+	0x183E: {Name: "Physical Activity Monitor", ID: "org.bluetooth.service.physical_activity_monitor", Type: TypeService},
+	0x1843: {Name: "Audio Input Control", ID: "org.bluetooth.service.audio_input_control", Type: TypeService},
+	0x1844: {Name: "Volume Control", ID: "org.bluetooth.service.volume_control", Type: TypeService},
+	0x1845: {Name: "Volume Offset Control", ID: "org.bluetooth.service.volume_offset_control", Type: TypeService},
+	0x1846: {Name: "Coordinated Set Identification", ID: "org.bluetooth.service.coordinated_set_identification", Type: TypeService},
+	0x1847: {Name: "Device Time", ID: "org.bluetooth.service.device_time", Type: TypeService},
+	0x1848: {Name: "Media Control", ID: "org.bluetooth.service.media_control", Type: TypeService},
+	0x1849: {Name: "Generic Media Control", ID: "org.bluetooth.service.generic_media_control", Type: TypeService},
+	0x184A: {Name: "Constant Tone Extension", ID: "org.bluetooth.service.constant_tone_extension", Type: TypeService},
+	0x184B: {Name: "Telephone Bearer", ID: "org.bluetooth.service.telephone_bearer", Type: TypeService},
+	0x184C: {Name: "Generic Telephone Bearer", ID: "org.bluetooth.service.generic_telephone_bearer", Type: TypeService},
+	0x184D: {Name: "Microphone Control", ID: "org.bluetooth.service.microphone_control", Type: TypeService},
+	0x184E: {Name: "Audio Stream Control", ID: "org.bluetooth.service.audio_stream_control", Type: TypeService},
+	0x184F: {Name: "Broadcast Audio Scan", ID: "org.bluetooth.service.broadcast_audio_scan", Type: TypeService},
+	0x1850: {Name: "Published Audio Capabilities", ID: "org.bluetooth.service.published_audio_capabilities", Type: TypeService},
+	0x1851: {Name: "Basic Audio Announcement", ID: "org.bluetooth.service.basic_audio_announcement", Type: TypeService},
+	0x1852: {Name: "Broadcast Audio Announcement", ID: "org.bluetooth.service.broadcast_audio_announcement", Type: TypeService},
+	0x1853: {Name: "Common Audio", ID: "org.bluetooth.service.common_audio", Type: TypeService},
+	0x1854: {Name: "Hearing Access", ID: "org.bluetooth.service.hearing_access", Type: TypeService},
+	0x1855: {Name: "Telephony and Media Audio", ID: "org.bluetooth.service.telephony_and_media_audio", Type: TypeService},
+	0x1856: {Name: "Public Broadcast Announcement", ID: "org.bluetooth.service.public_broadcast_announcement", Type: TypeService},
+	0x2A00: {Name: "Device Name", ID: "org.bluetooth.characteristic.device_name", Type: TypeCharacteristic},
+	0x2A01: {Name: "Appearance", ID: "org.bluetooth.characteristic.appearance", Type: TypeCharacteristic},
+	0x2A02: {Name: "Peripheral Privacy Flag", ID: "org.bluetooth.characteristic.peripheral_privacy_flag", Type: TypeCharacteristic},
+	0x2A03: {Name: "Reconnection Address", ID: "org.bluetooth.characteristic.reconnection_address", Type: TypeCharacteristic},
+	0x2A04: {Name: "Peripheral Preferred Connection Parameters", ID: "org.bluetooth.characteristic.peripheral_preferred_connection_parameters", Type: TypeCharacteristic},
+	0x2A05: {Name: "Service Changed", ID: "org.bluetooth.characteristic.service_changed", Type: TypeCharacteristic},
+	0x2A06: {Name: "Alert Level", ID: "org.bluetooth.characteristic.alert_level", Type: TypeCharacteristic},
+	0x2A07: {Name: "Tx Power Level", ID: "org.bluetooth.characteristic.tx_power_level", Type: TypeCharacteristic},
+	0x2A08: {Name: "Date Time", ID: "org.bluetooth.characteristic.date_time", Type: TypeCharacteristic},
+	0x2A09: {Name: "Day of Week", ID: "org.bluetooth.characteristic.day_of_week", Type: TypeCharacteristic},
+	0x2A0A: {Name: "Day Date Time", ID: "org.bluetooth.characteristic.day_date_time", Type: TypeCharacteristic},
+	0x2A0C: {Name: "Exact Time 256", ID: "org.bluetooth.characteristic.exact_time_256", Type: TypeCharacteristic},
+	0x2A0D: {Name: "DST Offset", ID: "org.bluetooth.characteristic.dst_offset", Type: TypeCharacteristic},
+	0x2A0E: {Name: "Time Zone", ID: "org.bluetooth.characteristic.time_zone", Type: TypeCharacteristic},
+	0x2A0F: {Name: "Local Time Information", ID: "org.bluetooth.characteristic.local_time_information", Type: TypeCharacteristic},
+	0x2A11: {Name: "Time with DST", ID: "org.bluetooth.characteristic.time_with_dst", Type: TypeCharacteristic},
+	0x2A12: {Name: "Time Accuracy", ID: "org.bluetooth.characteristic.time_accuracy", Type: TypeCharacteristic},
+	0x2A13: {Name: "Time Source", ID: "org.bluetooth.characteristic.time_source", Type: TypeCharacteristic},
+	0x2A14: {Name: "Reference Time Information", ID: "org.bluetooth.characteristic.reference_time_information", Type: TypeCharacteristic},
+	0x2A16: {Name: "Time Update Control Point", ID: "org.bluetooth.characteristic.time_update_control_point", Type: TypeCharacteristic},
+	0x2A17: {Name: "Time Update State", ID: "org.bluetooth.characteristic.time_update_state", Type: TypeCharacteristic},
+	0x2A18: {Name: "Glucose Measurement", ID: "org.bluetooth.characteristic.glucose_measurement", Type: TypeCharacteristic},
+	0x2A19: {Name: "Battery Level", ID: "org.bluetooth.characteristic.battery_level", Type: TypeCharacteristic},
+	0x2A1C: {Name: "Temperature Measurement", ID: "org.bluetooth.characteristic.temperature_measurement", Type: TypeCharacteristic},
+	0x2A1D: {Name: "Temperature Type", ID: "org.bluetooth.characteristic.temperature_type", Type: TypeCharacteristic},
+	0x2A1E: {Name: "Intermediate Temperature", ID: "org.bluetooth.characteristic.intermediate_temperature", Type: TypeCharacteristic},
+	0x2A21: {Name: "Measurement Interval", ID: "org.bluetooth.characteristic.measurement_interval", Type: TypeCharacteristic},
+	0x2A22: {Name: "Boot Keyboard Input Report", ID: "org.bluetooth.characteristic.boot_keyboard_input_report", Type: TypeCharacteristic},
+	0x2A23: {Name: "System ID", ID: "org.bluetooth.characteristic.system_id", Type: TypeCharacteristic},
+	0x2A24: {Name: "Model Number String", ID: "org.bluetooth.characteristic.model_number_string", Type: TypeCharacteristic},
+	0x2A25: {Name: "Serial Number String", ID: "org.bluetooth.characteristic.serial_number_string", Type: TypeCharacteristic},
+	0x2A26: {Name: "Firmware Revision String", ID: "org.bluetooth.characteristic.firmware_revision_string", Type: TypeCharacteristic},
+	0x2A27: {Name: "Hardware Revision String", ID: "org.bluetooth.characteristic.hardware_revision_string", Type: TypeCharacteristic},
+	0x2A28: {Name: "Software Revision String", ID: "org.bluetooth.characteristic.software_revision_string", Type: TypeCharacteristic},
+	0x2A29: {Name: "Manufacturer Name String", ID: "org.bluetooth.characteristic.manufacturer_name_string", Type: TypeCharacteristic},
+	0x2A2A: {Name: "IEEE 11073-20601 Regulatory Certification Data List", ID: "org.bluetooth.characteristic.ieee_11073_20601_regulatory_certification_data_list", Type: TypeCharacteristic},
+	0x2A2B: {Name: "Current Time", ID: "org.bluetooth.characteristic.current_time", Type: TypeCharacteristic},
+	0x2A2C: {Name: "Magnetic Declination", ID: "org.bluetooth.characteristic.magnetic_declination", Type: TypeCharacteristic},
+	0x2A31: {Name: "Scan Refresh", ID: "org.bluetooth.characteristic.scan_refresh", Type: TypeCharacteristic},
+	0x2A32: {Name: "Boot Keyboard Output Report", ID: "org.bluetooth.characteristic.boot_keyboard_output_report", Type: TypeCharacteristic},
+	0x2A33: {Name: "Boot Mouse Input Report", ID: "org.bluetooth.characteristic.boot_mouse_input_report", Type: TypeCharacteristic},
+	0x2A34: {Name: "Glucose Measurement Context", ID: "org.bluetooth.characteristic.glucose_measurement_context", Type: TypeCharacteristic},
+	0x2A35: {Name: "Blood Pressure Measurement", ID: "org.bluetooth.characteristic.blood_pressure_measurement", Type: TypeCharacteristic},
+	0x2A36: {Name: "Intermediate Cuff Pressure", ID: "org.bluetooth.characteristic.intermediate_cuff_pressure", Type: TypeCharacteristic},
+	0x2A37: {Name: "Heart Rate Measurement", ID: "org.bluetooth.characteristic.heart_rate_measurement", Type: TypeCharacteristic},
+	0x2A38: {Name: "Body Sensor Location", ID: "org.bluetooth.characteristic.body_sensor_location", Type: TypeCharacteristic},
+	0x2A39: {Name: "Heart Rate Control Point", ID: "org.bluetooth.characteristic.heart_rate_control_point", Type: TypeCharacteristic},
+	0x2A3F: {Name: "Alert Status", ID: "org.bluetooth.characteristic.alert_status", Type: TypeCharacteristic},
+	0x2A40: {Name: "Ringer Control Point", ID: "org.bluetooth.characteristic.ringer_control_point", Type: TypeCharacteristic},
+	0x2A41: {Name: "Ringer Setting", ID: "org.bluetooth.characteristic.ringer_setting", Type: TypeCharacteristic},
+	0x2A42: {Name: "Alert Category ID Bit Mask", ID: "org.bluetooth.characteristic.alert_category_id_bit_mask", Type: TypeCharacteristic},
+	0x2A43: {Name: "Alert Category ID", ID: "org.bluetooth.characteristic.alert_category_id", Type: TypeCharacteristic},
+	0x2A44: {Name: "Alert Notification Control Point", ID: "org.bluetooth.characteristic.alert_notification_control_point", Type: TypeCharacteristic},
+	0x2A45: {Name: "Unread Alert Status", ID: "org.bluetooth.characteristic.unread_alert_status", Type: TypeCharacteristic},
+	0x2A46: {Name: "New Alert", ID: "org.bluetooth.characteristic.new_alert", Type: TypeCharacteristic},
+	0x2A47: {Name: "Supported New Alert Category", ID: "org.bluetooth.characteristic.supported_new_alert_category", Type: TypeCharacteristic},
+	0x2A48: {Name: "Supported Unread Alert Category", ID: "org.bluetooth.characteristic.supported_unread_alert_category", Type: TypeCharacteristic},
+	0x2A49: {Name: "Blood Pressure Feature", ID: "org.bluetooth.characteristic.blood_pressure_feature", Type: TypeCharacteristic},
+	0x2A4A: {Name: "HID Information", ID: "org.bluetooth.characteristic.hid_information", Type: TypeCharacteristic},
+	0x2A4B: {Name: "Report Map", ID: "org.bluetooth.characteristic.report_map", Type: TypeCharacteristic},
+	0x2A4C: {Name: "HID Control Point", ID: "org.bluetooth.characteristic.hid_control_point", Type: TypeCharacteristic},
+	0x2A4D: {Name: "Report", ID: "org.bluetooth.characteristic.report", Type: TypeCharacteristic},
+	0x2A4E: {Name: "Protocol Mode", ID: "org.bluetooth.characteristic.protocol_mode", Type: TypeCharacteristic},
+	0x2A4F: {Name: "Scan Interval Window", ID: "org.bluetooth.characteristic.scan_interval_window", Type: TypeCharacteristic},
+	0x2A50: {Name: "PnP ID", ID: "org.bluetooth.characteristic.pnp_id", Type: TypeCharacteristic},
+	0x2A51: {Name: "Glucose Feature", ID: "org.bluetooth.characteristic.glucose_feature", Type: TypeCharacteristic},
+	0x2A52: {Name: "Record Access Control Point", ID: "org.bluetooth.characteristic.record_access_control_point", Type: TypeCharacteristic},
+	0x2A53: {Name: "RSC Measurement", ID: "org.bluetooth.characteristic.rsc_measurement", Type: TypeCharacteristic},
+	0x2A54: {Name: "RSC Feature", ID: "org.bluetooth.characteristic.rsc_feature", Type: TypeCharacteristic},
+	0x2A55: {Name: "SC Control Point", ID: "org.bluetooth.characteristic.sc_control_point", Type: TypeCharacteristic},
+	0x2A5A: {Name: "Aggregate", ID: "org.bluetooth.characteristic.aggregate", Type: TypeCharacteristic},
+	0x2A5B: {Name: "CSC Measurement", ID: "org.bluetooth.characteristic.csc_measurement", Type: TypeCharacteristic},
+	0x2A5C: {Name: "CSC Feature", ID: "org.bluetooth.characteristic.csc_feature", Type: TypeCharacteristic},
+	0x2A5D: {Name: "Sensor Location", ID: "org.bluetooth.characteristic.sensor_location", Type: TypeCharacteristic},
+	0x2A63: {Name: "Cycling Power Measurement", ID: "org.bluetooth.characteristic.cycling_power_measurement", Type: TypeCharacteristic},
+	0x2A64: {Name: "Cycling Power Vector", ID: "org.bluetooth.characteristic.cycling_power_vector", Type: TypeCharacteristic},
+	0x2A65: {Name: "Cycling Power Feature", ID: "org.bluetooth.characteristic.cycling_power_feature", Type: TypeCharacteristic},
+	0x2A66: {Name: "Cycling Power Control Point", ID: "org.bluetooth.characteristic.cycling_power_control_point", Type: TypeCharacteristic},
+	0x2A67: {Name: "Location and Speed", ID: "org.bluetooth.characteristic.location_and_speed", Type: TypeCharacteristic},
+	0x2A68: {Name: "Navigation", ID: "org.bluetooth.characteristic.navigation", Type: TypeCharacteristic},
+	0x2A6C: {Name: "Elevation", ID: "org.bluetooth.characteristic.elevation", Type: TypeCharacteristic},
+	0x2A6D: {Name: "Pressure", ID: "org.bluetooth.characteristic.pressure", Type: TypeCharacteristic},
+	0x2A6E: {Name: "Temperature", ID: "org.bluetooth.characteristic.temperature", Type: TypeCharacteristic},
+	0x2A6F: {Name: "Humidity", ID: "org.bluetooth.characteristic.humidity", Type: TypeCharacteristic},
+	0x2A70: {Name: "True Wind Speed", ID: "org.bluetooth.characteristic.true_wind_speed", Type: TypeCharacteristic},
+	0x2A71: {Name: "True Wind Direction", ID: "org.bluetooth.characteristic.true_wind_direction", Type: TypeCharacteristic},
+	0x2A72: {Name: "Apparent Wind Speed", ID: "org.bluetooth.characteristic.apparent_wind_speed", Type: TypeCharacteristic},
+	0x2A73: {Name: "Apparent Wind Direction", ID: "org.bluetooth.characteristic.apparent_wind_direction", Type: TypeCharacteristic},
+	0x2A74: {Name: "Gust Factor", ID: "org.bluetooth.characteristic.gust_factor", Type: TypeCharacteristic},
+	0x2A75: {Name: "Pollen Concentration", ID: "org.bluetooth.characteristic.pollen_concentration", Type: TypeCharacteristic},
+	0x2A76: {Name: "UV Index", ID: "org.bluetooth.characteristic.uv_index", Type: TypeCharacteristic},
+	0x2A77: {Name: "Irradiance", ID: "org.bluetooth.characteristic.irradiance", Type: TypeCharacteristic},
+	0x2A78: {Name: "Rainfall", ID: "org.bluetooth.characteristic.rainfall", Type: TypeCharacteristic},
+	0x2A79: {Name: "Wind Chill", ID: "org.bluetooth.characteristic.wind_chill", Type: TypeCharacteristic},
+	0x2A7A: {Name: "Heat Index", ID: "org.bluetooth.characteristic.heat_index", Type: TypeCharacteristic},
+	0x2A7B: {Name: "Dew Point", ID: "org.bluetooth.characteristic.dew_point", Type: TypeCharacteristic},
+	0x2A7D: {Name: "Descriptor Value Changed", ID: "org.bluetooth.characteristic.descriptor_value_changed", Type: TypeCharacteristic},
+	0x2A9D: {Name: "Weight Measurement", ID: "org.bluetooth.characteristic.weight_measurement", Type: TypeCharacteristic},
+	0x2A9E: {Name: "Weight Scale Feature", ID: "org.bluetooth.characteristic.weight_scale_feature", Type: TypeCharacteristic},
+	0x2AA6: {Name: "Central Address Resolution", ID: "org.bluetooth.characteristic.central_address_resolution", Type: TypeCharacteristic},
+	0x2AC9: {Name: "Resolvable Private Address Only", ID: "org.bluetooth.characteristic.resolvable_private_address_only", Type: TypeCharacteristic},
+	0x2B29: {Name: "Client Supported Features", ID: "org.bluetooth.characteristic.client_supported_features", Type: TypeCharacteristic},
+	0x2B2A: {Name: "Database Hash", ID: "org.bluetooth.characteristic.database_hash", Type: TypeCharacteristic},
+	0x2B3A: {Name: "Server Supported Features", ID: "org.bluetooth.characteristic.server_supported_features", Type: TypeCharacteristic},
+	0x2900: {Name: "Characteristic Extended Properties", ID: "org.bluetooth.descriptor.characteristic_extended_properties", Type: TypeDescriptor},
+	0x2901: {Name: "Characteristic User Description", ID: "org.bluetooth.descriptor.characteristic_user_description", Type: TypeDescriptor},
+	0x2902: {Name: "Client Characteristic Configuration", ID: "org.bluetooth.descriptor.client_characteristic_configuration", Type: TypeDescriptor},
+	0x2903: {Name: "Server Characteristic Configuration", ID: "org.bluetooth.descriptor.server_characteristic_configuration", Type: TypeDescriptor},
+	0x2904: {Name: "Characteristic Presentation Format", ID: "org.bluetooth.descriptor.characteristic_presentation_format", Type: TypeDescriptor},
+	0x2905: {Name: "Characteristic Aggregate Format", ID: "org.bluetooth.descriptor.characteristic_aggregate_format", Type: TypeDescriptor},
+	0x2906: {Name: "Valid Range", ID: "org.bluetooth.descriptor.valid_range", Type: TypeDescriptor},
+	0x2907: {Name: "External Report Reference", ID: "org.bluetooth.descriptor.external_report_reference", Type: TypeDescriptor},
+	0x2908: {Name: "Report Reference", ID: "org.bluetooth.descriptor.report_reference", Type: TypeDescriptor},
+	0x2909: {Name: "Number of Digitals", ID: "org.bluetooth.descriptor.number_of_digitals", Type: TypeDescriptor},
+	0x290A: {Name: "Value Trigger Setting", ID: "org.bluetooth.descriptor.value_trigger_setting", Type: TypeDescriptor},
+	0x290B: {Name: "Environmental Sensing Configuration", ID: "org.bluetooth.descriptor.environmental_sensing_configuration", Type: TypeDescriptor},
+	0x290C: {Name: "Environmental Sensing Measurement", ID: "org.bluetooth.descriptor.environmental_sensing_measurement", Type: TypeDescriptor},
+	0x290D: {Name: "Environmental Sensing Trigger Setting", ID: "org.bluetooth.descriptor.environmental_sensing_trigger_setting", Type: TypeDescriptor},
+	0x290E: {Name: "Time Trigger Setting", ID: "org.bluetooth.descriptor.time_trigger_setting", Type: TypeDescriptor},
+	0x290F: {Name: "Complete BR-EDR Transport Block Data", ID: "org.bluetooth.descriptor.complete_br_edr_transport_block_data", Type: TypeDescriptor},
+}
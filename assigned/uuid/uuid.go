@@ -0,0 +1,122 @@
+// Package uuid normalises Bluetooth UUIDs and resolves the names of the
+// services, characteristics and descriptors assigned by the Bluetooth SIG
+package uuid
+
+//go:generate go run ../../gen/assignedgen/main.go ../../gen/assigned/data
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidUUID is returned when a UUID cannot be parsed
+var ErrInvalidUUID = errors.New("invalid UUID")
+
+// BaseUUID is the Bluetooth Base UUID, 16 and 32 bit UUIDs are aliases of it
+const BaseUUID = "00000000-0000-1000-8000-00805f9b34fb"
+
+// baseSuffix the BaseUUID after the 32 bit alias
+const baseSuffix = "-0000-1000-8000-00805f9b34fb"
+
+// Type of an assigned UUID
+type Type string
+
+// Types of the assigned UUIDs
+const (
+	TypeService        Type = "service"
+	TypeCharacteristic Type = "characteristic"
+	TypeDescriptor     Type = "descriptor"
+)
+
+// Info describes an assigned UUID
+type Info struct {
+	// UUID 128 bit form
+	UUID string
+	Name string
+	// ID SIG identifier, eg. org.bluetooth.service.battery_service
+	ID   string
+	Type Type
+}
+
+// Parse return the lower case 128 bit form of a 16, 32 or 128 bit UUID.
+// The 0x prefix and a 128 bit form without dashes are accepted
+func Parse(uuid string) (string, error) {
+
+	s := strings.ToLower(strings.TrimSpace(uuid))
+	s = strings.TrimPrefix(s, "0x")
+
+	switch len(s) {
+	case 4:
+		s = "0000" + s + baseSuffix
+	case 8:
+		s = s + baseSuffix
+	case 32:
+		s = s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+	case 36:
+	default:
+		return "", fmt.Errorf("%s: %w", uuid, ErrInvalidUUID)
+	}
+
+	for i, c := range s {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if c != '-' {
+				return "", fmt.Errorf("%s: %w", uuid, ErrInvalidUUID)
+			}
+			continue
+		}
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') {
+			return "", fmt.Errorf("%s: %w", uuid, ErrInvalidUUID)
+		}
+	}
+
+	return s, nil
+}
+
+// MustParse is like Parse but panics if the UUID cannot be parsed
+func MustParse(uuid string) string {
+	s, err := Parse(uuid)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// FromUint16 return the 128 bit form of a 16 bit UUID
+func FromUint16(v uint16) string {
+	return fmt.Sprintf("0000%04x%s", v, baseSuffix)
+}
+
+// Short return the 16 bit alias of a UUID based on the Bluetooth Base UUID
+func Short(uuid string) (uint16, bool) {
+	s, err := Parse(uuid)
+	if err != nil || !strings.HasPrefix(s, "0000") || !strings.HasSuffix(s, baseSuffix) {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(s[4:8], 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	return uint16(v), true
+}
+
+// Lookup return the assigned UUID info
+func Lookup(uuid string) (Info, bool) {
+	v, ok := Short(uuid)
+	if !ok {
+		return Info{}, false
+	}
+	info, ok := assigned[v]
+	if !ok {
+		return Info{}, false
+	}
+	info.UUID = FromUint16(v)
+	return info, true
+}
+
+// Name return the name of an assigned UUID, or an empty string if unknown
+func Name(uuid string) string {
+	info, _ := Lookup(uuid)
+	return info.Name
+}
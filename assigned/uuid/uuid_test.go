@@ -0,0 +1,73 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		uuid     string
+		expected string
+	}{
+		{"180f", "0000180f-0000-1000-8000-00805f9b34fb"},
+		{"0x180F", "0000180f-0000-1000-8000-00805f9b34fb"},
+		{"0000180F", "0000180f-0000-1000-8000-00805f9b34fb"},
+		{"1234ABCD", "1234abcd-0000-1000-8000-00805f9b34fb"},
+		{"0000180F-0000-1000-8000-00805F9B34FB", "0000180f-0000-1000-8000-00805f9b34fb"},
+		{"12345678123456781234567812345678", "12345678-1234-5678-1234-567812345678"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uuid, func(t *testing.T) {
+			s, err := Parse(tt.uuid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.expected, s)
+		})
+	}
+
+	for _, invalid := range []string{"", "18f", "180g", "0000180f+0000-1000-8000-00805f9b34fb", "12345"} {
+		_, err := Parse(invalid)
+		assert.True(t, errors.Is(err, ErrInvalidUUID), invalid)
+	}
+
+	assert.Panics(t, func() {
+		MustParse("xyz")
+	})
+}
+
+func TestShort(t *testing.T) {
+	v, ok := Short("0000180F-0000-1000-8000-00805F9B34FB")
+	assert.True(t, ok)
+	assert.Equal(t, uint16(0x180f), v)
+
+	_, ok = Short("1234180f-0000-1000-8000-00805f9b34fb")
+	assert.False(t, ok)
+	_, ok = Short("0000180f-0000-1000-8000-00805f9b34fc")
+	assert.False(t, ok)
+
+	assert.Equal(t, "00002a19-0000-1000-8000-00805f9b34fb", FromUint16(0x2a19))
+}
+
+func TestLookup(t *testing.T) {
+	assert.Equal(t, "Battery", Name("180f"))
+	assert.Equal(t, "Battery Level", Name("00002A19-0000-1000-8000-00805F9B34FB"))
+	assert.Equal(t, "", Name("12345678-1234-5678-1234-56789abcdef0"))
+	assert.Equal(t, "", Name("ffff"))
+
+	info, ok := Lookup("0x2902")
+	assert.True(t, ok)
+	assert.Equal(t, Info{
+		UUID: "00002902-0000-1000-8000-00805f9b34fb",
+		Name: "Client Characteristic Configuration",
+		ID:   "org.bluetooth.descriptor.client_characteristic_configuration",
+		Type: TypeDescriptor,
+	}, info)
+
+	info, ok = Lookup("180d")
+	assert.True(t, ok)
+	assert.Equal(t, TypeService, info.Type)
+}
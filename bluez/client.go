@@ -1,6 +1,7 @@
 package bluez
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/godbus/dbus/v5"
@@ -25,35 +26,46 @@ func (c *Client) isConnected() bool {
 	return c.conn != nil
 }
 
-//Disconnect from DBus
-func (c *Client) Disconnect() {
+//Disconnect from DBus. The connection is not closed, as both the default
+// connections and the injected ones are shared with other clients, see
+// CloseConnections and Conn.Close
+func (c *Client) Disconnect() {}
 
-	// do not disconnect SystemBus
-	// as it is a singleton from dbus package
-	if c.Config.Bus == SystemBus {
-		return
+// Conn return the connection used by the client
+func (c *Client) Conn() (*Conn, error) {
+	if c.Config.Conn != nil {
+		return c.Config.Conn, nil
 	}
+	return DefaultConn(c.Config.Bus)
+}
 
-	if c.isConnected() {
-		c.conn.Close()
-		c.conn = nil
-		c.dbusObject = nil
+// ObjectManager return the bluez ObjectManager sharing the client connection
+func (c *Client) ObjectManager() (*ObjectManager, error) {
+	conn, err := c.Conn()
+	if err != nil {
+		return nil, err
 	}
+	return conn.ObjectManager()
 }
 
 // Connect connects to DBus
 func (c *Client) Connect() error {
-	dbusConn, err := GetConnection(c.Config.Bus)
+	conn, err := c.Conn()
 	if err != nil {
 		return err
 	}
-	c.conn = dbusConn
+	c.conn = conn.DBus()
 	c.dbusObject = c.conn.Object(c.Config.Name, dbus.ObjectPath(c.Config.Path))
 	return nil
 }
 
 // Call a DBus method
 func (c *Client) Call(method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	return c.CallWithContext(context.Background(), method, flags, args...)
+}
+
+// CallWithContext call a DBus method, the call is cancelled when ctx is done
+func (c *Client) CallWithContext(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
 
 	if !c.isConnected() {
 		err := c.Connect()
@@ -65,7 +77,9 @@ func (c *Client) Call(method string, flags dbus.Flags, args ...interface{}) *dbu
 	}
 
 	methodPath := fmt.Sprint(c.Config.Iface, ".", method)
-	return c.dbusObject.Call(methodPath, flags, args...)
+	call := c.dbusObject.CallWithContext(ctx, methodPath, flags, args...)
+	call.Err = convertCallError(call.Err, c.Config.Iface, method)
+	return call
 }
 
 //GetProperty return a property value
@@ -76,7 +90,8 @@ func (c *Client) GetProperty(p string) (dbus.Variant, error) {
 			return dbus.Variant{}, err
 		}
 	}
-	return c.dbusObject.GetProperty(c.Config.Iface + "." + p)
+	v, err := c.dbusObject.GetProperty(c.Config.Iface + "." + p)
+	return v, convertCallError(err, c.Config.Iface, p)
 }
 
 //SetProperty set a property value
@@ -87,7 +102,8 @@ func (c *Client) SetProperty(p string, v interface{}) error {
 			return err
 		}
 	}
-	return c.dbusObject.Call("org.freedesktop.DBus.Properties.Set", 0, c.Config.Iface, p, dbus.MakeVariant(v)).Store()
+	err := c.dbusObject.Call("org.freedesktop.DBus.Properties.Set", 0, c.Config.Iface, p, dbus.MakeVariant(v)).Store()
+	return convertCallError(err, c.Config.Iface, p)
 }
 
 //GetProperties load all the properties for an interface
@@ -103,7 +119,7 @@ func (c *Client) GetProperties(props interface{}) error {
 	result := make(map[string]dbus.Variant)
 	err := c.dbusObject.Call("org.freedesktop.DBus.Properties.GetAll", 0, c.Config.Iface).Store(&result)
 	if err != nil {
-		return fmt.Errorf("Properties.GetAll %s: %s", c.Config.Iface, err)
+		return fmt.Errorf("Properties.GetAll %s: %w", c.Config.Iface, convertCallError(err, c.Config.Iface, ""))
 	}
 
 	err = util.MapToStruct(props, result)
@@ -154,6 +170,31 @@ func (c *Client) Unregister(path dbus.ObjectPath, iface string, signal chan *dbu
 	return nil
 }
 
+// AddMatch add a signal match rule for path and iface. Matching signals are delivered
+// to all the channels registered on the connection
+func (c *Client) AddMatch(path dbus.ObjectPath, iface string) error {
+	if !c.isConnected() {
+		err := c.Connect()
+		if err != nil {
+			return err
+		}
+	}
+	matchstr := getMatchString(path, iface)
+	return c.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, matchstr).Store()
+}
+
+// RemoveMatch remove a signal match rule added with AddMatch
+func (c *Client) RemoveMatch(path dbus.ObjectPath, iface string) error {
+	if !c.isConnected() {
+		err := c.Connect()
+		if err != nil {
+			return err
+		}
+	}
+	matchstr := getMatchString(path, iface)
+	return c.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, matchstr).Store()
+}
+
 // Emit
 func (c *Client) Emit(path dbus.ObjectPath, name string, values ...interface{}) error {
 	if !c.isConnected() {
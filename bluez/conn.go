@@ -0,0 +1,68 @@
+package bluez
+
+import (
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Conn is a DBus connection shared by the clients of a bluetooth stack.
+// Passing a Conn to the *WithConn constructors allows to run isolated stacks
+// in the same process, eg. on a private bus or on a remote bus over TCP.
+type Conn struct {
+	conn          *dbus.Conn
+	lock          sync.Mutex
+	objectManager *ObjectManager
+}
+
+// NewConn wrap an authenticated DBus connection
+func NewConn(conn *dbus.Conn) *Conn {
+	return &Conn{
+		conn: conn,
+	}
+}
+
+// Dial open a private connection to the bus at address,
+// eg. unix:path=/var/run/dbus/system_bus_socket or tcp:host=10.0.0.1,port=5555
+func Dial(address string) (*Conn, error) {
+	conn, err := dbus.Dial(address)
+	if err != nil {
+		return nil, err
+	}
+	err = initConnection(conn)
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(conn), nil
+}
+
+// DBus return the underlying DBus connection
+func (c *Conn) DBus() *dbus.Conn {
+	return c.conn
+}
+
+// ObjectManager return the bluez ObjectManager client bound to this connection
+func (c *Conn) ObjectManager() (*ObjectManager, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.objectManager != nil {
+		return c.objectManager, nil
+	}
+
+	om, err := NewObjectManagerWithConn(c, OrgBluezInterface, "/")
+	if err != nil {
+		return nil, err
+	}
+
+	c.objectManager = om
+	return om, nil
+}
+
+// Close the connection
+func (c *Conn) Close() error {
+	c.lock.Lock()
+	c.objectManager = nil
+	c.lock.Unlock()
+	return c.conn.Close()
+}
@@ -2,6 +2,7 @@ package bluez
 
 import (
 	"errors"
+	"sync"
 
 	"github.com/godbus/dbus/v5"
 	log "github.com/sirupsen/logrus"
@@ -31,7 +32,10 @@ const (
 	SystemBus
 )
 
-var conns = make([]*dbus.Conn, 2)
+var (
+	defaultConnsLock sync.Mutex
+	defaultConns     = map[BusType]*Conn{}
+)
 
 // Config pass configuration to a DBUS client
 type Config struct {
@@ -39,46 +43,82 @@ type Config struct {
 	Iface string
 	Path  dbus.ObjectPath
 	Bus   BusType
+	// Conn connection to use, the default connection for Bus if nil
+	Conn *Conn
 }
 
-// CloseConnections close all open connection to DBus
+// CloseConnections close all the default connections to DBus
 func CloseConnections() (err error) {
-	for _, conn := range conns {
-		if conn != nil {
-			err = conn.Close()
-			if err != nil {
-				log.Warnf("Close: %s", err)
-			}
+	defaultConnsLock.Lock()
+	defer defaultConnsLock.Unlock()
+	for _, conn := range defaultConns {
+		err = conn.Close()
+		if err != nil {
+			log.Warnf("Close: %s", err)
 		}
 	}
-	conns = make([]*dbus.Conn, 2)
+	defaultConns = map[BusType]*Conn{}
 	return err
 }
 
-//GetConnection get a DBus connection
-func GetConnection(connType BusType) (*dbus.Conn, error) {
+// DefaultConn return the shared connection for a bus type, connecting on first use
+func DefaultConn(connType BusType) (*Conn, error) {
+	defaultConnsLock.Lock()
+	defer defaultConnsLock.Unlock()
+
+	if conn, ok := defaultConns[connType]; ok {
+		return conn, nil
+	}
+
+	var (
+		conn *dbus.Conn
+		err  error
+	)
 	switch connType {
 	case SystemBus:
-		if conns[SystemBus] == nil {
-			// c.logger.Debug("Connecting to SystemBus")
-			conn, err := dbus.SystemBus()
-			if err != nil {
-				return nil, err
-			}
-			conns[SystemBus] = conn
-		}
-		return conns[SystemBus], nil
+		// c.logger.Debug("Connecting to SystemBus")
+		conn, err = dbus.SystemBusPrivate()
 	case SessionBus:
-		if conns[SessionBus] == nil {
-			// c.logger.Debug("Connecting to SessionBus")
-			conn, err := dbus.SessionBus()
-			if err != nil {
-				return nil, err
-			}
-			conns[SessionBus] = conn
-		}
-		return conns[SessionBus], nil
+		// c.logger.Debug("Connecting to SessionBus")
+		conn, err = dbus.SessionBusPrivate()
 	default:
 		return nil, errors.New("Unmanged DBus type code")
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = initConnection(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultConns[connType] = NewConn(conn)
+	return defaultConns[connType], nil
+}
+
+//GetConnection get the default DBus connection for a bus type
+func GetConnection(connType BusType) (*dbus.Conn, error) {
+	conn, err := DefaultConn(connType)
+	if err != nil {
+		return nil, err
+	}
+	return conn.DBus(), nil
+}
+
+// initConnection authenticate a private connection and register it on the bus.
+// Private connections read the bus address from the environment on every
+// dial, allowing to point them to a different bus (eg. a fake bluez daemon)
+func initConnection(conn *dbus.Conn) error {
+	err := conn.Auth(nil)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	err = conn.Hello()
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	return nil
 }
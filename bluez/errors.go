@@ -0,0 +1,146 @@
+package bluez
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// ErrorPrefix the DBus error name prefix used by bluez
+const ErrorPrefix = "org.bluez.Error."
+
+// Error is an error returned by bluez, eg. org.bluez.Error.InProgress.
+// It matches the Err* values with errors.Is and unwraps to the original dbus.Error
+type Error struct {
+	// Name the DBus error name, eg. org.bluez.Error.InProgress
+	Name string
+	// Message the error description, if any
+	Message string
+	// Err the original DBus error
+	Err error
+}
+
+// Error return the error message, as the original dbus.Error would
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return e.Name
+}
+
+// Is match errors with the same name, eg. errors.Is(err, bluez.ErrInProgress)
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Name == e.Name
+}
+
+// Unwrap return the original DBus error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ConvertError wrap a bluez dbus.Error in an Error. Other errors are returned as-is
+func ConvertError(err error) error {
+
+	var dbusErr dbus.Error
+	switch e := err.(type) {
+	case dbus.Error:
+		dbusErr = e
+	case *dbus.Error:
+		if e == nil {
+			return nil
+		}
+		dbusErr = *e
+	default:
+		return err
+	}
+
+	if !strings.HasPrefix(dbusErr.Name, ErrorPrefix) {
+		return err
+	}
+
+	message := dbusErr.Error()
+	if message == dbusErr.Name {
+		message = ""
+	}
+
+	return &Error{
+		Name:    dbusErr.Name,
+		Message: message,
+		Err:     dbusErr,
+	}
+}
+
+// ErrNotSupportedByDaemon matches, with errors.Is, the calls to a method or
+// property the bluez daemon does not implement, eg. an application built
+// against a newer API than the running daemon
+var ErrNotSupportedByDaemon = errors.New("not supported by the bluez daemon")
+
+// NotSupportedError is returned in place of the UnknownMethod, UnknownProperty
+// and similar DBus errors. It matches ErrNotSupportedByDaemon with errors.Is
+// and unwraps to the original dbus.Error
+type NotSupportedError struct {
+	// Interface the interface called, eg. org.bluez.Adapter1
+	Interface string
+	// Member the method or property name, empty if the whole interface is missing
+	Member string
+	// Err the original DBus error
+	Err error
+}
+
+// Error return the error message, including the bluez version introducing the member if known.
+// SinceOldest is not reported, as the member may be older
+func (e *NotSupportedError) Error() string {
+	name := e.Interface
+	if e.Member != "" {
+		name += "." + e.Member
+	}
+	msg := fmt.Sprintf("%s is %s", name, ErrNotSupportedByDaemon)
+	if since, ok := Since[name]; ok && since != SinceOldest {
+		msg += fmt.Sprintf(" (requires bluez %s)", since)
+	}
+	return msg
+}
+
+// Is match ErrNotSupportedByDaemon
+func (e *NotSupportedError) Is(target error) bool {
+	return target == ErrNotSupportedByDaemon
+}
+
+// Unwrap return the original DBus error
+func (e *NotSupportedError) Unwrap() error {
+	return e.Err
+}
+
+// convertCallError convert the error of a call to member of iface. Errors
+// for missing members become NotSupportedError, see ConvertError for the others
+func convertCallError(err error, iface, member string) error {
+
+	var dbusErr dbus.Error
+	switch e := err.(type) {
+	case dbus.Error:
+		dbusErr = e
+	case *dbus.Error:
+		if e == nil {
+			return nil
+		}
+		dbusErr = *e
+	default:
+		return ConvertError(err)
+	}
+
+	if !isNotSupported(dbusErr) {
+		return ConvertError(err)
+	}
+
+	return &NotSupportedError{
+		Interface: iface,
+		Member:    member,
+		Err:       dbusErr,
+	}
+}
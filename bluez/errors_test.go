@@ -0,0 +1,102 @@
+package bluez
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertError(t *testing.T) {
+
+	inProgress := dbus.Error{
+		Name: "org.bluez.Error.InProgress",
+		Body: []interface{}{"Operation already in progress"},
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		match  bool
+	}{
+		{"value", inProgress, ErrInProgress, true},
+		{"pointer", &inProgress, ErrInProgress, true},
+		{"other bluez error", inProgress, ErrNotPermitted, false},
+		{"wrapped", fmt.Errorf("Connect: %w", inProgress), ErrInProgress, false},
+		{"no body", dbus.Error{Name: "org.bluez.Error.NotPermitted"}, ErrNotPermitted, true},
+		{"not bluez", dbus.Error{Name: "org.freedesktop.DBus.Error.NoReply"}, ErrFailed, false},
+		{"plain", errors.New("Failed"), ErrFailed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ConvertError(tt.err)
+			assert.Equal(t, tt.match, errors.Is(err, tt.target))
+		})
+	}
+}
+
+func TestConvertErrorKeepsDBusError(t *testing.T) {
+
+	src := dbus.Error{
+		Name: "org.bluez.Error.InProgress",
+		Body: []interface{}{"Operation already in progress"},
+	}
+
+	err := ConvertError(src)
+	assert.Equal(t, "Operation already in progress", err.Error())
+
+	var dbusErr dbus.Error
+	assert.True(t, errors.As(err, &dbusErr))
+	assert.Equal(t, src.Name, dbusErr.Name)
+
+	assert.Nil(t, ConvertError(nil))
+	assert.Nil(t, ConvertError((*dbus.Error)(nil)))
+	assert.Equal(t, "org.bluez.Error.NotReady", ErrNotReady.Error())
+}
+
+func TestConvertCallError(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		err   error
+		match bool
+	}{
+		{"unknown method", dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownMethod"}, true},
+		{"unknown property", &dbus.Error{Name: "org.freedesktop.DBus.Properties.Error.PropertyNotFound"}, true},
+		{"bluetoothd property", dbus.Error{
+			Name: "org.freedesktop.DBus.Error.InvalidArgs",
+			Body: []interface{}{"No such property 'WakeAllowed'"},
+		}, true},
+		{"invalid args", dbus.Error{
+			Name: "org.freedesktop.DBus.Error.InvalidArgs",
+			Body: []interface{}{"Invalid arguments in method call"},
+		}, false},
+		{"unknown object", dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownObject"}, false},
+		{"bluez error", dbus.Error{Name: "org.bluez.Error.NotSupported"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := convertCallError(tt.err, "org.bluez.Device1", "WakeAllowed")
+			assert.Equal(t, tt.match, errors.Is(err, ErrNotSupportedByDaemon))
+		})
+	}
+
+	err := convertCallError(dbus.Error{Name: "org.bluez.Error.NotSupported"}, "org.bluez.Device1", "Pair")
+	assert.True(t, errors.Is(err, ErrNotSupported))
+	assert.Nil(t, convertCallError((*dbus.Error)(nil), "org.bluez.Device1", "Pair"))
+
+	err = convertCallError(dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownInterface"}, "org.bluez.Unknown1", "")
+	assert.Equal(t, "org.bluez.Unknown1 is not supported by the bluez daemon", err.Error())
+	var dbusErr dbus.Error
+	assert.True(t, errors.As(err, &dbusErr))
+
+	// members of the oldest definitions may be older, no version is reported
+	err = convertCallError(dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownMethod"}, "org.bluez.Device1", "Pair")
+	assert.Equal(t, SinceOldest, Since["org.bluez.Device1.Pair"])
+	assert.Equal(t, "org.bluez.Device1.Pair is not supported by the bluez daemon", err.Error())
+}
@@ -0,0 +1,496 @@
+package fake
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile"
+)
+
+const (
+	// Adapter1Interface the bluez adapter interface
+	Adapter1Interface = "org.bluez.Adapter1"
+	// GattManager1Interface the bluez GATT manager interface, exposed by adapters
+	GattManager1Interface = "org.bluez.GattManager1"
+	// LEAdvertisingManager1Interface the bluez advertising manager interface, exposed by adapters
+	LEAdvertisingManager1Interface = "org.bluez.LEAdvertisingManager1"
+	// AdvertisementMonitorManager1Interface the bluez advertisement monitor manager interface, exposed by adapters
+	AdvertisementMonitorManager1Interface = "org.bluez.AdvertisementMonitorManager1"
+	// AdvertisementMonitor1Interface the interface of the monitors registered with AdvertisementMonitorManager1
+	AdvertisementMonitor1Interface = "org.bluez.AdvertisementMonitor1"
+)
+
+// Adapter is a fake org.bluez.Adapter1 object. It exposes also GattManager1,
+// LEAdvertisingManager1 and AdvertisementMonitorManager1 on the same path
+type Adapter struct {
+	*Object
+	adapterID string
+
+	lock           sync.Mutex
+	filter         map[string]dbus.Variant
+	applications   []dbus.ObjectPath
+	advertisements []dbus.ObjectPath
+	// advertisers are the bus names that registered the advertisements
+	advertisers map[dbus.ObjectPath]string
+	// monitorApps are the roots of the monitors hierarchies, by registering bus name
+	monitorApps map[dbus.ObjectPath]string
+}
+
+// AddAdapter expose a new adapter, eg. hci0. props override the default properties values
+func (b *Bluez) AddAdapter(adapterID string, props map[string]interface{}) (*Adapter, error) {
+
+	path := dbus.ObjectPath(fmt.Sprintf("%s/%s", bluez.OrgBluezPath, adapterID))
+
+	defaults := map[string]interface{}{
+		"Address":             "00:00:00:00:00:00",
+		"AddressType":         "public",
+		"Alias":               adapterID,
+		"Class":               uint32(0),
+		"Discoverable":        false,
+		"DiscoverableTimeout": uint32(180),
+		"Discovering":         false,
+		"Modalias":            "",
+		"Name":                adapterID,
+		"Pairable":            true,
+		"PairableTimeout":     uint32(0),
+		"Powered":             false,
+		"Roles":               []string{"central", "peripheral"},
+		"UUIDs":               []string{},
+	}
+
+	extra := map[string]map[string]interface{}{
+		GattManager1Interface: {},
+		LEAdvertisingManager1Interface: {
+			"ActiveInstances":            byte(0),
+			"SupportedInstances":         byte(5),
+			"SupportedIncludes":          []string{"tx-power", "appearance", "local-name"},
+			"SupportedSecondaryChannels": []string{"1M", "2M", "Coded"},
+		},
+		AdvertisementMonitorManager1Interface: {
+			"SupportedMonitorTypes": []string{"or_patterns"},
+			"SupportedFeatures":     []string{},
+		},
+	}
+
+	o, err := b.newObject(path, Adapter1Interface, defaults, props, extra,
+		"Alias", "Discoverable", "DiscoverableTimeout", "Pairable", "PairableTimeout", "Powered")
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Adapter{
+		Object:      o,
+		adapterID:   adapterID,
+		filter:      map[string]dbus.Variant{},
+		advertisers: map[dbus.ObjectPath]string{},
+		monitorApps: map[dbus.ObjectPath]string{},
+	}
+
+	err = b.addObject(o, map[string]interface{}{
+		Adapter1Interface:                     a,
+		GattManager1Interface:                 &gattManager{a},
+		LEAdvertisingManager1Interface:        &advertisingManager{a},
+		AdvertisementMonitorManager1Interface: &monitorManager{a},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b.lock.Lock()
+	b.adapters[adapterID] = a
+	b.lock.Unlock()
+
+	return a, nil
+}
+
+// RemoveAdapter remove an adapter and all its devices
+func (b *Bluez) RemoveAdapter(a *Adapter) error {
+	b.lock.Lock()
+	delete(b.adapters, a.adapterID)
+	b.lock.Unlock()
+	return b.removeObject(a.Path())
+}
+
+// Adapter return an adapter by ID, eg. hci0, nil if not found
+func (b *Bluez) Adapter(adapterID string) *Adapter {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.adapters[adapterID]
+}
+
+// AdapterID return the adapter ID, eg. hci0
+func (a *Adapter) AdapterID() string {
+	return a.adapterID
+}
+
+// DiscoveryFilter return the last filter set with SetDiscoveryFilter
+func (a *Adapter) DiscoveryFilter() map[string]dbus.Variant {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	filter := map[string]dbus.Variant{}
+	for k, v := range a.filter {
+		filter[k] = v
+	}
+	return filter
+}
+
+// Applications return the GATT applications registered with GattManager1
+func (a *Adapter) Applications() []dbus.ObjectPath {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return append([]dbus.ObjectPath{}, a.applications...)
+}
+
+// Advertisements return the advertisements registered with LEAdvertisingManager1
+func (a *Adapter) Advertisements() []dbus.ObjectPath {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return append([]dbus.ObjectPath{}, a.advertisements...)
+}
+
+// StartDiscovery implements Adapter1.StartDiscovery
+func (a *Adapter) StartDiscovery() *dbus.Error {
+	if err := a.call("StartDiscovery"); err != nil {
+		return err
+	}
+	if !a.Get("Powered").(bool) {
+		return &profile.ErrNotReady
+	}
+	a.Set("Discovering", true)
+	return nil
+}
+
+// StopDiscovery implements Adapter1.StopDiscovery
+func (a *Adapter) StopDiscovery() *dbus.Error {
+	if err := a.call("StopDiscovery"); err != nil {
+		return err
+	}
+	if !a.Get("Discovering").(bool) {
+		return &profile.ErrFailed
+	}
+	a.Set("Discovering", false)
+	return nil
+}
+
+// SetDiscoveryFilter implements Adapter1.SetDiscoveryFilter
+func (a *Adapter) SetDiscoveryFilter(filter map[string]dbus.Variant) *dbus.Error {
+	if err := a.call("SetDiscoveryFilter"); err != nil {
+		return err
+	}
+	a.lock.Lock()
+	a.filter = filter
+	a.lock.Unlock()
+	return nil
+}
+
+// GetDiscoveryFilters implements Adapter1.GetDiscoveryFilters
+func (a *Adapter) GetDiscoveryFilters() ([]string, *dbus.Error) {
+	if err := a.call("GetDiscoveryFilters"); err != nil {
+		return nil, err
+	}
+	return []string{"UUIDs", "RSSI", "Pathloss", "Transport", "DuplicateData", "Discoverable", "Pattern"}, nil
+}
+
+// RemoveDevice implements Adapter1.RemoveDevice
+func (a *Adapter) RemoveDevice(device dbus.ObjectPath) *dbus.Error {
+	if err := a.call("RemoveDevice"); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(string(device), string(a.Path())+"/") {
+		return &profile.ErrInvalidArguments
+	}
+	if a.bluez.Object(device) == nil {
+		return &profile.ErrDoesNotExist
+	}
+	err := a.bluez.removeObject(device)
+	if err != nil {
+		return dbus.MakeFailedError(err)
+	}
+	return nil
+}
+
+// ConnectDevice implements Adapter1.ConnectDevice
+func (a *Adapter) ConnectDevice(properties map[string]dbus.Variant) (dbus.ObjectPath, *dbus.Error) {
+	if err := a.call("ConnectDevice"); err != nil {
+		return dbus.ObjectPath(""), err
+	}
+
+	address, ok := properties["Address"].Value().(string)
+	if !ok {
+		return dbus.ObjectPath(""), &profile.ErrInvalidArguments
+	}
+
+	props := map[string]interface{}{}
+	if addressType, ok := properties["AddressType"].Value().(string); ok {
+		props["AddressType"] = addressType
+	}
+
+	dev := a.Device(address)
+	if dev == nil {
+		d, err := a.AddDevice(address, props)
+		if err != nil {
+			return dbus.ObjectPath(""), dbus.MakeFailedError(err)
+		}
+		dev = d
+	}
+
+	dev.Set("Connected", true)
+	return dev.Path(), nil
+}
+
+// gattManager implements org.bluez.GattManager1 for an adapter
+type gattManager struct {
+	adapter *Adapter
+}
+
+// RegisterApplication implements GattManager1.RegisterApplication. As bluez
+// does, the application objects are loaded with GetManagedObjects and at
+// least a GattService1 is required
+func (m *gattManager) RegisterApplication(sender dbus.Sender, app dbus.ObjectPath, options map[string]dbus.Variant) *dbus.Error {
+	if err := m.adapter.call("RegisterApplication"); err != nil {
+		return err
+	}
+
+	m.adapter.lock.Lock()
+	registered := indexOf(m.adapter.applications, app) > -1
+	m.adapter.lock.Unlock()
+	if registered {
+		return &profile.ErrAlreadyExists
+	}
+
+	var objects map[dbus.ObjectPath]map[string]map[string]dbus.Variant
+	err := m.adapter.bluez.conn.Object(string(sender), app).
+		Call(bluez.ObjectManagerInterface+".GetManagedObjects", 0).Store(&objects)
+	if err != nil {
+		return dbus.MakeFailedError(err)
+	}
+	services := 0
+	for _, ifaces := range objects {
+		if _, ok := ifaces[GattService1Interface]; ok {
+			services++
+		}
+	}
+	if services == 0 {
+		return &profile.ErrFailed
+	}
+
+	m.adapter.lock.Lock()
+	defer m.adapter.lock.Unlock()
+	if indexOf(m.adapter.applications, app) > -1 {
+		return &profile.ErrAlreadyExists
+	}
+	m.adapter.applications = append(m.adapter.applications, app)
+	return nil
+}
+
+// UnregisterApplication implements GattManager1.UnregisterApplication
+func (m *gattManager) UnregisterApplication(app dbus.ObjectPath) *dbus.Error {
+	if err := m.adapter.call("UnregisterApplication"); err != nil {
+		return err
+	}
+	m.adapter.lock.Lock()
+	defer m.adapter.lock.Unlock()
+	i := indexOf(m.adapter.applications, app)
+	if i == -1 {
+		return &profile.ErrDoesNotExist
+	}
+	m.adapter.applications = append(m.adapter.applications[:i], m.adapter.applications[i+1:]...)
+	return nil
+}
+
+// advertisingManager implements org.bluez.LEAdvertisingManager1 for an adapter
+type advertisingManager struct {
+	adapter *Adapter
+}
+
+// RegisterAdvertisement implements LEAdvertisingManager1.RegisterAdvertisement
+func (m *advertisingManager) RegisterAdvertisement(sender dbus.Sender, adv dbus.ObjectPath, options map[string]dbus.Variant) *dbus.Error {
+	if err := m.adapter.call("RegisterAdvertisement"); err != nil {
+		return err
+	}
+	m.adapter.lock.Lock()
+	if indexOf(m.adapter.advertisements, adv) > -1 {
+		m.adapter.lock.Unlock()
+		return &profile.ErrAlreadyExists
+	}
+	max := m.adapter.GetProperty(LEAdvertisingManager1Interface, "SupportedInstances").(byte)
+	active := m.adapter.GetProperty(LEAdvertisingManager1Interface, "ActiveInstances").(byte)
+	if max == 0 {
+		m.adapter.lock.Unlock()
+		return &profile.ErrNotPermitted
+	}
+	m.adapter.advertisements = append(m.adapter.advertisements, adv)
+	m.adapter.advertisers[adv] = string(sender)
+	m.adapter.lock.Unlock()
+
+	m.adapter.SetProperty(LEAdvertisingManager1Interface, "ActiveInstances", active+1)
+	m.adapter.SetProperty(LEAdvertisingManager1Interface, "SupportedInstances", max-1)
+	return nil
+}
+
+// UnregisterAdvertisement implements LEAdvertisingManager1.UnregisterAdvertisement
+func (m *advertisingManager) UnregisterAdvertisement(adv dbus.ObjectPath) *dbus.Error {
+	if err := m.adapter.call("UnregisterAdvertisement"); err != nil {
+		return err
+	}
+	if _, ok := m.adapter.removeAdvertisement(adv); !ok {
+		return &profile.ErrDoesNotExist
+	}
+	return nil
+}
+
+// removeAdvertisement drop a registered advertisement, returning the bus name that registered it
+func (a *Adapter) removeAdvertisement(adv dbus.ObjectPath) (string, bool) {
+	a.lock.Lock()
+	i := indexOf(a.advertisements, adv)
+	if i == -1 {
+		a.lock.Unlock()
+		return "", false
+	}
+	a.advertisements = append(a.advertisements[:i], a.advertisements[i+1:]...)
+	sender := a.advertisers[adv]
+	delete(a.advertisers, adv)
+	a.lock.Unlock()
+
+	max := a.GetProperty(LEAdvertisingManager1Interface, "SupportedInstances").(byte)
+	active := a.GetProperty(LEAdvertisingManager1Interface, "ActiveInstances").(byte)
+	a.SetProperty(LEAdvertisingManager1Interface, "ActiveInstances", active-1)
+	a.SetProperty(LEAdvertisingManager1Interface, "SupportedInstances", max+1)
+	return sender, true
+}
+
+// ReleaseAdvertisement remove a registered advertisement and call its Release
+// method, as bluez does when the advertisement times out
+func (a *Adapter) ReleaseAdvertisement(adv dbus.ObjectPath) error {
+	sender, ok := a.removeAdvertisement(adv)
+	if !ok {
+		return fmt.Errorf("Advertisement %s not registered", adv)
+	}
+	return a.bluez.conn.Object(sender, adv).Call("org.bluez.LEAdvertisement1.Release", 0).Err
+}
+
+// monitorManager implements org.bluez.AdvertisementMonitorManager1 for an adapter
+type monitorManager struct {
+	adapter *Adapter
+}
+
+// RegisterMonitor implements AdvertisementMonitorManager1.RegisterMonitor
+func (m *monitorManager) RegisterMonitor(sender dbus.Sender, app dbus.ObjectPath) *dbus.Error {
+	if err := m.adapter.call("RegisterMonitor"); err != nil {
+		return err
+	}
+	m.adapter.lock.Lock()
+	defer m.adapter.lock.Unlock()
+	if _, ok := m.adapter.monitorApps[app]; ok {
+		return &profile.ErrAlreadyExists
+	}
+	m.adapter.monitorApps[app] = string(sender)
+	return nil
+}
+
+// UnregisterMonitor implements AdvertisementMonitorManager1.UnregisterMonitor
+func (m *monitorManager) UnregisterMonitor(app dbus.ObjectPath) *dbus.Error {
+	if err := m.adapter.call("UnregisterMonitor"); err != nil {
+		return err
+	}
+	m.adapter.lock.Lock()
+	defer m.adapter.lock.Unlock()
+	if _, ok := m.adapter.monitorApps[app]; !ok {
+		return &profile.ErrDoesNotExist
+	}
+	delete(m.adapter.monitorApps, app)
+	return nil
+}
+
+// MonitorApps return the roots of the monitors hierarchies registered with AdvertisementMonitorManager1
+func (a *Adapter) MonitorApps() []dbus.ObjectPath {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	apps := []dbus.ObjectPath{}
+	for app := range a.monitorApps {
+		apps = append(apps, app)
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i] < apps[j]
+	})
+	return apps
+}
+
+// Monitors list the monitors exposed by the registered hierarchies, querying
+// their ObjectManager as bluez does
+func (a *Adapter) Monitors() ([]dbus.ObjectPath, error) {
+	a.lock.Lock()
+	apps := map[dbus.ObjectPath]string{}
+	for app, sender := range a.monitorApps {
+		apps[app] = sender
+	}
+	a.lock.Unlock()
+
+	monitors := []dbus.ObjectPath{}
+	for app, sender := range apps {
+		objects := map[dbus.ObjectPath]map[string]map[string]dbus.Variant{}
+		err := a.bluez.conn.Object(sender, app).Call(bluez.ObjectManagerInterface+".GetManagedObjects", 0).Store(&objects)
+		if err != nil {
+			return nil, fmt.Errorf("GetManagedObjects %s: %s", app, err)
+		}
+		for path, ifaces := range objects {
+			if _, ok := ifaces[AdvertisementMonitor1Interface]; ok {
+				monitors = append(monitors, path)
+			}
+		}
+	}
+	sort.Slice(monitors, func(i, j int) bool {
+		return monitors[i] < monitors[j]
+	})
+	return monitors, nil
+}
+
+// ActivateMonitor call the Activate method of a monitor
+func (a *Adapter) ActivateMonitor(monitor dbus.ObjectPath) error {
+	return a.callMonitor(monitor, "Activate")
+}
+
+// ReleaseMonitor call the Release method of a monitor, as bluez does when it
+// cannot be activated
+func (a *Adapter) ReleaseMonitor(monitor dbus.ObjectPath) error {
+	return a.callMonitor(monitor, "Release")
+}
+
+// MonitorDeviceFound notify a monitor that device matches it
+func (a *Adapter) MonitorDeviceFound(monitor dbus.ObjectPath, device dbus.ObjectPath) error {
+	return a.callMonitor(monitor, "DeviceFound", device)
+}
+
+// MonitorDeviceLost notify a monitor that device is lost
+func (a *Adapter) MonitorDeviceLost(monitor dbus.ObjectPath, device dbus.ObjectPath) error {
+	return a.callMonitor(monitor, "DeviceLost", device)
+}
+
+// callMonitor call a method of a monitor on the bus name that registered its hierarchy
+func (a *Adapter) callMonitor(monitor dbus.ObjectPath, method string, args ...interface{}) error {
+	a.lock.Lock()
+	sender := ""
+	for app, s := range a.monitorApps {
+		if strings.HasPrefix(string(monitor), string(app)+"/") {
+			sender = s
+		}
+	}
+	a.lock.Unlock()
+	if sender == "" {
+		return fmt.Errorf("Monitor %s not registered", monitor)
+	}
+	return a.bluez.conn.Object(sender, monitor).Call(AdvertisementMonitor1Interface+"."+method, 0, args...).Err
+}
+
+func indexOf(list []dbus.ObjectPath, path dbus.ObjectPath) int {
+	for i, p := range list {
+		if p == path {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,139 @@
+package fake
+
+import (
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile"
+)
+
+// AgentManager1Interface the bluez agent manager interface, exposed at /org/bluez
+const AgentManager1Interface = "org.bluez.AgentManager1"
+
+// agentCapabilities are the IO capabilities accepted by RegisterAgent, an empty one means KeyboardDisplay
+var agentCapabilities = []string{"", "DisplayOnly", "DisplayYesNo", "KeyboardOnly", "NoInputNoOutput", "KeyboardDisplay"}
+
+// AgentManager is the fake org.bluez.AgentManager1 object
+type AgentManager struct {
+	*Object
+
+	lock sync.Mutex
+	// agents map the registered agents to their capability
+	agents       map[dbus.ObjectPath]string
+	defaultAgent dbus.ObjectPath
+}
+
+// addAgentManager expose the AgentManager1 object at /org/bluez
+func (b *Bluez) addAgentManager() error {
+
+	o, err := b.newObject(bluez.OrgBluezPath, AgentManager1Interface, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	m := &AgentManager{
+		Object: o,
+		agents: map[dbus.ObjectPath]string{},
+	}
+
+	err = b.addObject(o, map[string]interface{}{
+		AgentManager1Interface: &agentManager{m},
+	})
+	if err != nil {
+		return err
+	}
+
+	b.agentManager = m
+	return nil
+}
+
+// AgentManager return the AgentManager1 object
+func (b *Bluez) AgentManager() *AgentManager {
+	return b.agentManager
+}
+
+// Agents return the paths of the registered agents
+func (m *AgentManager) Agents() []dbus.ObjectPath {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	list := make([]dbus.ObjectPath, 0, len(m.agents))
+	for path := range m.agents {
+		list = append(list, path)
+	}
+	return list
+}
+
+// Capability return the IO capability an agent registered with
+func (m *AgentManager) Capability(agent dbus.ObjectPath) string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.agents[agent]
+}
+
+// DefaultAgent return the path of the default agent, if any
+func (m *AgentManager) DefaultAgent() dbus.ObjectPath {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.defaultAgent
+}
+
+// agentManager implements org.bluez.AgentManager1
+type agentManager struct {
+	m *AgentManager
+}
+
+// RegisterAgent implements AgentManager1.RegisterAgent
+func (a *agentManager) RegisterAgent(agent dbus.ObjectPath, capability string) *dbus.Error {
+	if err := a.m.call("RegisterAgent"); err != nil {
+		return err
+	}
+	valid := false
+	for _, c := range agentCapabilities {
+		if c == capability {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return &profile.ErrInvalidArguments
+	}
+	a.m.lock.Lock()
+	defer a.m.lock.Unlock()
+	if _, ok := a.m.agents[agent]; ok {
+		return &profile.ErrAlreadyExists
+	}
+	a.m.agents[agent] = capability
+	return nil
+}
+
+// UnregisterAgent implements AgentManager1.UnregisterAgent
+func (a *agentManager) UnregisterAgent(agent dbus.ObjectPath) *dbus.Error {
+	if err := a.m.call("UnregisterAgent"); err != nil {
+		return err
+	}
+	a.m.lock.Lock()
+	defer a.m.lock.Unlock()
+	if _, ok := a.m.agents[agent]; !ok {
+		return &profile.ErrDoesNotExist
+	}
+	delete(a.m.agents, agent)
+	if a.m.defaultAgent == agent {
+		a.m.defaultAgent = ""
+	}
+	return nil
+}
+
+// RequestDefaultAgent implements AgentManager1.RequestDefaultAgent
+func (a *agentManager) RequestDefaultAgent(agent dbus.ObjectPath) *dbus.Error {
+	if err := a.m.call("RequestDefaultAgent"); err != nil {
+		return err
+	}
+	a.m.lock.Lock()
+	defer a.m.lock.Unlock()
+	if _, ok := a.m.agents[agent]; !ok {
+		return &profile.ErrDoesNotExist
+	}
+	a.m.defaultAgent = agent
+	return nil
+}
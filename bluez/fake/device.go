@@ -0,0 +1,150 @@
+package fake
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez/profile"
+)
+
+// Device1Interface the bluez device interface
+const Device1Interface = "org.bluez.Device1"
+
+// Device is a fake org.bluez.Device1 object
+type Device struct {
+	*Object
+	adapter *Adapter
+}
+
+// AddDevice expose a new device, as if found by discovery. props override the default properties values
+func (a *Adapter) AddDevice(address string, props map[string]interface{}) (*Device, error) {
+
+	path := dbus.ObjectPath(fmt.Sprintf("%s/dev_%s", a.Path(), strings.Replace(address, ":", "_", -1)))
+
+	defaults := map[string]interface{}{
+		"Adapter":          a.Path(),
+		"Address":          address,
+		"AddressType":      "public",
+		"Alias":            strings.Replace(address, ":", "-", -1),
+		"Appearance":       uint16(0),
+		"Blocked":          false,
+		"Class":            uint32(0),
+		"Connected":        false,
+		"Icon":             "",
+		"LegacyPairing":    false,
+		"ManufacturerData": map[uint16]dbus.Variant{},
+		"Modalias":         "",
+		"Name":             "",
+		"Paired":           false,
+		"RSSI":             int16(0),
+		"ServiceData":      map[string]dbus.Variant{},
+		"ServicesResolved": false,
+		"Trusted":          false,
+		"TxPower":          int16(0),
+		"UUIDs":            []string{},
+	}
+
+	o, err := a.bluez.newObject(path, Device1Interface, defaults, props, nil, "Alias", "Trusted", "Blocked")
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Device{
+		Object:  o,
+		adapter: a,
+	}
+
+	err = a.bluez.addObject(o, map[string]interface{}{Device1Interface: d})
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Device return a device by address, nil if not found
+func (a *Adapter) Device(address string) *Device {
+	path := dbus.ObjectPath(fmt.Sprintf("%s/dev_%s", a.Path(), strings.Replace(address, ":", "_", -1)))
+	o := a.bluez.Object(path)
+	if o == nil {
+		return nil
+	}
+	return &Device{
+		Object:  o,
+		adapter: a,
+	}
+}
+
+// Adapter return the adapter the device belongs to
+func (d *Device) Adapter() *Adapter {
+	return d.adapter
+}
+
+// Connect implements Device1.Connect
+func (d *Device) Connect() *dbus.Error {
+	if err := d.call("Connect"); err != nil {
+		return err
+	}
+	if d.Get("Connected").(bool) {
+		return &profile.ErrAlreadyConnected
+	}
+	d.Set("Connected", true)
+	d.Set("ServicesResolved", true)
+	return nil
+}
+
+// Disconnect implements Device1.Disconnect
+func (d *Device) Disconnect() *dbus.Error {
+	if err := d.call("Disconnect"); err != nil {
+		return err
+	}
+	if !d.Get("Connected").(bool) {
+		return &profile.ErrNotConnected
+	}
+	d.Set("ServicesResolved", false)
+	d.Set("Connected", false)
+	return nil
+}
+
+// ConnectProfile implements Device1.ConnectProfile
+func (d *Device) ConnectProfile(uuid string) *dbus.Error {
+	if err := d.call("ConnectProfile"); err != nil {
+		return err
+	}
+	for _, u := range d.Get("UUIDs").([]string) {
+		if strings.EqualFold(u, uuid) {
+			d.Set("Connected", true)
+			return nil
+		}
+	}
+	return &profile.ErrDoesNotExist
+}
+
+// DisconnectProfile implements Device1.DisconnectProfile
+func (d *Device) DisconnectProfile(uuid string) *dbus.Error {
+	if err := d.call("DisconnectProfile"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Pair implements Device1.Pair
+func (d *Device) Pair() *dbus.Error {
+	if err := d.call("Pair"); err != nil {
+		return err
+	}
+	if d.Get("Paired").(bool) {
+		return &profile.ErrAlreadyExists
+	}
+	d.Set("Paired", true)
+	return nil
+}
+
+// CancelPairing implements Device1.CancelPairing
+func (d *Device) CancelPairing() *dbus.Error {
+	if err := d.call("CancelPairing"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+// Package fake runs an in-process BlueZ daemon on a private DBus bus.
+//
+// The daemon exposes a scriptable org.bluez ObjectManager providing Adapter1,
+// Device1, GattService1, GattCharacteristic1 and GattDescriptor1 objects, and
+// the AgentManager1 at /org/bluez,
+// allowing to test code built on top of the bluez profiles without a
+// bluetoothd instance on the system bus.
+//
+// Start spawns a dbus-daemon listening on a temporary socket and points the
+// system bus address used by the bluez package to it, bluez.MinVersion and
+// bluez.Supports reporting then on the fake objects, while bluez.Version
+// returns bluez.ErrVersionUnknown. Close restores the previous configuration.
+package fake
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	log "github.com/sirupsen/logrus"
+)
+
+// SystemBusAddressEnv environment variable read to connect to the system bus
+const SystemBusAddressEnv = "DBUS_SYSTEM_BUS_ADDRESS"
+
+// DaemonPath path to the dbus-daemon executable, looked up in PATH if empty
+var DaemonPath = ""
+
+// StartTimeout max time to wait for the dbus-daemon to be ready
+var StartTimeout = 5 * time.Second
+
+// ErrDaemonNotFound returned when the dbus-daemon executable is not available
+var ErrDaemonNotFound = errors.New("dbus-daemon executable not found")
+
+const busConfig = `<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">
+<busconfig>
+  <type>session</type>
+  <listen>unix:path=%s</listen>
+  <auth>EXTERNAL</auth>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>
+`
+
+// Bluez is a fake bluetoothd exposing org.bluez on a private bus
+type Bluez struct {
+	lock     sync.Mutex
+	dir      string
+	cmd      *exec.Cmd
+	address  string
+	conn     *dbus.Conn
+	objects  map[dbus.ObjectPath]*Object
+	adapters map[string]*Adapter
+	handle   uint16
+
+	agentManager *AgentManager
+	closed       bool
+
+	systemBusAddress    string
+	hasSystemBusAddress bool
+	versionCommands     [][]string
+}
+
+// Start launch a private dbus-daemon, register org.bluez on it and use it as
+// system bus for the bluez package
+func Start() (*Bluez, error) {
+
+	daemonPath := DaemonPath
+	if daemonPath == "" {
+		p, err := exec.LookPath("dbus-daemon")
+		if err != nil {
+			return nil, ErrDaemonNotFound
+		}
+		daemonPath = p
+	}
+
+	b := &Bluez{
+		objects:  make(map[dbus.ObjectPath]*Object),
+		adapters: make(map[string]*Adapter),
+	}
+
+	err := b.startDaemon(daemonPath)
+	if err != nil {
+		b.stopDaemon()
+		return nil, err
+	}
+
+	err = b.connect()
+	if err != nil {
+		b.stopDaemon()
+		return nil, err
+	}
+
+	err = b.addAgentManager()
+	if err != nil {
+		b.conn.Close()
+		b.stopDaemon()
+		return nil, err
+	}
+
+	b.systemBusAddress, b.hasSystemBusAddress = os.LookupEnv(SystemBusAddressEnv)
+	os.Setenv(SystemBusAddressEnv, b.address)
+
+	// the version of the local bluetoothd does not apply to the fake
+	b.versionCommands = bluez.VersionCommands
+	bluez.VersionCommands = nil
+
+	err = bluez.CloseConnections()
+	if err != nil {
+		log.Warnf("CloseConnections: %s", err)
+	}
+
+	return b, nil
+}
+
+func (b *Bluez) startDaemon(daemonPath string) error {
+
+	dir, err := ioutil.TempDir("", "go-bluetooth-fake")
+	if err != nil {
+		return err
+	}
+	b.dir = dir
+
+	configFile := filepath.Join(dir, "bus.conf")
+	config := fmt.Sprintf(busConfig, filepath.Join(dir, "bus"))
+	err = ioutil.WriteFile(configFile, []byte(config), 0600)
+	if err != nil {
+		return err
+	}
+
+	b.cmd = exec.Command(daemonPath, "--config-file="+configFile, "--nofork", "--print-address=1")
+	stdout, err := b.cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	err = b.cmd.Start()
+	if err != nil {
+		return fmt.Errorf("Start dbus-daemon: %s", err)
+	}
+
+	ready := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(stdout).ReadString('\n')
+		ready <- strings.TrimSpace(line)
+	}()
+
+	select {
+	case address := <-ready:
+		if address == "" {
+			return errors.New("dbus-daemon did not report an address")
+		}
+		b.address = address
+	case <-time.After(StartTimeout):
+		return fmt.Errorf("dbus-daemon not ready after %s", StartTimeout)
+	}
+
+	return nil
+}
+
+func (b *Bluez) stopDaemon() {
+	if b.cmd != nil && b.cmd.Process != nil {
+		b.cmd.Process.Kill()
+		b.cmd.Wait()
+		b.cmd = nil
+	}
+	if b.dir != "" {
+		os.RemoveAll(b.dir)
+		b.dir = ""
+	}
+}
+
+func (b *Bluez) connect() error {
+
+	conn, err := dbus.Dial(b.address)
+	if err != nil {
+		return err
+	}
+	b.conn = conn
+
+	err = conn.Auth(nil)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	err = conn.Hello()
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	reply, err := conn.RequestName(bluez.OrgBluezInterface, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		conn.Close()
+		return fmt.Errorf("Cannot own %s name", bluez.OrgBluezInterface)
+	}
+
+	err = conn.Export(&objectManager{b}, "/", bluez.ObjectManagerInterface)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	return nil
+}
+
+// Address return the DBus address of the private bus
+func (b *Bluez) Address() string {
+	return b.address
+}
+
+// Conn return the connection owning the org.bluez name
+func (b *Bluez) Conn() *dbus.Conn {
+	return b.conn
+}
+
+// Dial open a new client connection to the private bus, to be passed to the *WithConn constructors
+func (b *Bluez) Dial() (*bluez.Conn, error) {
+	return bluez.Dial(b.address)
+}
+
+// Object return an exposed object by path, nil if not found
+func (b *Bluez) Object(path dbus.ObjectPath) *Object {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.objects[path]
+}
+
+// Close stop the daemon and restore the system bus address. Further calls are no-op
+func (b *Bluez) Close() error {
+
+	b.lock.Lock()
+	closed := b.closed
+	b.closed = true
+	b.lock.Unlock()
+	if closed {
+		return nil
+	}
+
+	if b.hasSystemBusAddress {
+		os.Setenv(SystemBusAddressEnv, b.systemBusAddress)
+	} else {
+		os.Unsetenv(SystemBusAddressEnv)
+	}
+	bluez.VersionCommands = b.versionCommands
+
+	err := bluez.CloseConnections()
+
+	if b.conn != nil {
+		b.conn.Close()
+		b.conn = nil
+	}
+
+	b.stopDaemon()
+
+	return err
+}
+
+// nextHandle return an incremental attribute handle, used to name GATT objects
+func (b *Bluez) nextHandle() uint16 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.handle++
+	return b.handle
+}
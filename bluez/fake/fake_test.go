@@ -0,0 +1,474 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile"
+	"github.com/muka/go-bluetooth/bluez/profile/adapter"
+	"github.com/muka/go-bluetooth/bluez/profile/agent"
+	"github.com/muka/go-bluetooth/bluez/profile/device"
+	"github.com/muka/go-bluetooth/bluez/profile/gatt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdapter(t *testing.T) {
+	b := StartT(t)
+
+	_, err := b.AddAdapter("hci0", map[string]interface{}{
+		"Address": "AA:BB:CC:DD:EE:FF",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := adapter.GetAdapter("hci0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "AA:BB:CC:DD:EE:FF", a.Properties.Address)
+
+	err = a.StartDiscovery()
+	assert.Error(t, err, "discovery requires a powered adapter")
+
+	err = a.SetPowered(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = a.StartDiscovery()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	discovering, err := a.GetDiscovering()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, discovering)
+}
+
+func TestDeviceDiscovery(t *testing.T) {
+	b := StartT(t)
+
+	fa, err := b.AddAdapter("hci0", map[string]interface{}{"Powered": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := adapter.GetAdapter("hci0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	discovery, cancel, err := a.OnDeviceDiscovered()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fa.OnCall("StartDiscovery", func() *dbus.Error {
+		go fa.AddDevice("11:22:33:44:55:66", map[string]interface{}{
+			"Name": "fake",
+			"RSSI": int16(-42),
+		})
+		return nil
+	})
+
+	err = a.StartDiscovery()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case ev := <-discovery:
+		assert.Equal(t, adapter.DeviceAdded, ev.Type)
+		dev, err := device.NewDevice1(ev.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "fake", dev.Properties.Name)
+		assert.Equal(t, int16(-42), dev.Properties.RSSI)
+	case <-time.After(time.Second * 2):
+		t.Fatal("Device not discovered")
+	}
+	cancel()
+
+	list, err := a.GetDeviceList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, list, 1)
+
+	err = a.FlushDevices()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err = a.GetDeviceList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, list, 0)
+}
+
+func TestGatt(t *testing.T) {
+	b := StartT(t)
+
+	fa, err := b.AddAdapter("hci0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd, err := fa.AddDevice("11:22:33:44:55:66", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs, err := fd.AddService("0000180f-0000-1000-8000-00805f9b34fb", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc, err := fs.AddCharacteristic("00002a19-0000-1000-8000-00805f9b34fb", []string{"read", "write", "notify"}, []byte{42})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := device.NewDevice("hci0", "11:22:33:44:55:66")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = dev.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, fd.Get("ServicesResolved").(bool))
+
+	char, err := dev.GetCharByUUID("00002a19-0000-1000-8000-00805f9b34fb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fc.Path(), char.Path())
+
+	value, err := char.ReadValue(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{42}, value)
+
+	written := make(chan []byte, 1)
+	fc.OnWrite(func(value []byte, options map[string]dbus.Variant) *dbus.Error {
+		written <- value
+		return nil
+	})
+	err = char.WriteValue([]byte{1, 2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{1, 2}, <-written)
+
+	ch, err := char.WatchProperties()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = char.StartNotify()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = fc.Notify([]byte{99})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeout := time.After(time.Second * 2)
+	for {
+		select {
+		case ev := <-ch:
+			if ev.Name != "Value" {
+				continue
+			}
+			assert.Equal(t, []byte{99}, ev.Value)
+			return
+		case <-timeout:
+			t.Fatal("Notification not received")
+		}
+	}
+}
+
+func TestHook(t *testing.T) {
+	b := StartT(t)
+
+	fa, err := b.AddAdapter("hci0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd, err := fa.AddDevice("11:22:33:44:55:66", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd.OnCall("Connect", func() *dbus.Error {
+		return &profile.ErrInProgress
+	})
+
+	dev, err := device.NewDevice("hci0", "11:22:33:44:55:66")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = dev.Connect()
+	if err == nil {
+		t.Fatal("Expected Connect to fail")
+	}
+	assert.True(t, errors.Is(err, bluez.ErrInProgress))
+	assert.False(t, errors.Is(err, bluez.ErrFailed))
+	assert.False(t, fd.Get("Connected").(bool))
+
+	_, err = gatt.NewGattCharacteristic1(dbus.ObjectPath(string(fd.Path()) + "/service0001/char0002"))
+	assert.Error(t, err)
+}
+
+func TestConnIsolation(t *testing.T) {
+	b1 := StartT(t)
+	b2 := StartT(t)
+
+	_, err := b1.AddAdapter("hci0", map[string]interface{}{"Address": "00:00:00:00:00:01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = b2.AddAdapter("hci0", map[string]interface{}{"Address": "00:00:00:00:00:02"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn1, err := b1.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn1.Close()
+	conn2, err := b2.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn2.Close()
+
+	a1, err := adapter.GetAdapterWithConn(conn1, "hci0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2, err := adapter.GetAdapterWithConn(conn2, "hci0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "00:00:00:00:00:01", a1.Properties.Address)
+	assert.Equal(t, "00:00:00:00:00:02", a2.Properties.Address)
+
+	err = a1.SetAlias("first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alias, err := a2.GetAlias()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "hci0", alias)
+}
+
+func TestCallContext(t *testing.T) {
+	b := StartT(t)
+
+	fa, err := b.AddAdapter("hci0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd, err := fa.AddDevice("11:22:33:44:55:66", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	fd.OnCall("Connect", func() *dbus.Error {
+		<-release
+		return nil
+	})
+
+	dev, err := device.NewDevice("hci0", "11:22:33:44:55:66")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	start := time.Now()
+	err = dev.ConnectContext(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestVersion(t *testing.T) {
+	b := StartT(t)
+
+	// the fake does not report its version, only a lower bound is known
+	_, err := bluez.Version()
+	assert.Equal(t, bluez.ErrVersionUnknown, err)
+
+	// AgentManager1 is available since the oldest API definitions
+	version, err := bluez.MinVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, bluez.SinceOldest, version)
+
+	fa, err := b.AddAdapter("hci0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fa.AddDevice("11:22:33:44:55:66", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Adapter1.Roles is introduced by 5.55
+	version, err = bluez.MinVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "5.55", version)
+
+	tests := []struct {
+		iface  string
+		member string
+		match  bool
+	}{
+		{Adapter1Interface, "", true},
+		{Adapter1Interface, "StartDiscovery", true},
+		{Adapter1Interface, "Roles", true},
+		{Adapter1Interface, "Unknown", false},
+		{Device1Interface, "Connect", true},
+		{Device1Interface, "WakeAllowed", false},
+		{"org.bluez.Media1", "", false},
+	}
+	for _, tt := range tests {
+		supported, err := bluez.Supports(tt.iface, tt.member)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tt.match, supported, "%s.%s", tt.iface, tt.member)
+	}
+}
+
+func TestNotSupportedByDaemon(t *testing.T) {
+	b := StartT(t)
+
+	fa, err := b.AddAdapter("hci0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fa.AddDevice("11:22:33:44:55:66", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := device.NewDevice("hci0", "11:22:33:44:55:66")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = dev.GetWakeAllowed()
+	assert.True(t, errors.Is(err, bluez.ErrNotSupportedByDaemon))
+	assert.Equal(t, "org.bluez.Device1.WakeAllowed is not supported by the bluez daemon (requires bluez 5.55)", err.Error())
+
+	var notSupported *bluez.NotSupportedError
+	assert.True(t, errors.As(err, &notSupported))
+	assert.Equal(t, "WakeAllowed", notSupported.Member)
+
+	err = dev.Client().Call("Unknown", 0).Store()
+	assert.True(t, errors.Is(err, bluez.ErrNotSupportedByDaemon))
+
+	_, err = dev.GetConnected()
+	assert.NoError(t, err)
+}
+
+func TestAgentManager(t *testing.T) {
+	b := StartT(t)
+
+	am, err := agent.NewAgentManager1()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := dbus.ObjectPath("/test/agent")
+	err = am.RequestDefaultAgent(path)
+	assert.True(t, errors.Is(err, bluez.ErrDoesNotExist))
+
+	err = am.RegisterAgent(path, "Unknown")
+	assert.True(t, errors.Is(err, bluez.ErrInvalidArguments))
+
+	err = am.RegisterAgent(path, agent.CapDisplayYesNo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = am.RegisterAgent(path, agent.CapDisplayYesNo)
+	assert.True(t, errors.Is(err, bluez.ErrAlreadyExists))
+
+	err = am.RequestDefaultAgent(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []dbus.ObjectPath{path}, b.AgentManager().Agents())
+	assert.Equal(t, agent.CapDisplayYesNo, b.AgentManager().Capability(path))
+	assert.Equal(t, path, b.AgentManager().DefaultAgent())
+
+	err = am.UnregisterAgent(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, b.AgentManager().Agents())
+	assert.Equal(t, dbus.ObjectPath(""), b.AgentManager().DefaultAgent())
+}
+
+func TestDisconnectSharedConn(t *testing.T) {
+	b := StartT(t)
+
+	// use the fake bus as session bus too
+	session, hasSession := os.LookupEnv("DBUS_SESSION_BUS_ADDRESS")
+	os.Setenv("DBUS_SESSION_BUS_ADDRESS", b.Address())
+	defer func() {
+		if hasSession {
+			os.Setenv("DBUS_SESSION_BUS_ADDRESS", session)
+		} else {
+			os.Unsetenv("DBUS_SESSION_BUS_ADDRESS")
+		}
+		bluez.CloseConnections()
+	}()
+
+	for _, bus := range []bluez.BusType{bluez.SessionBus, bluez.SystemBus} {
+		client := bluez.NewClient(&bluez.Config{
+			Name:  bluez.OrgBluezInterface,
+			Iface: bluez.ObjectManagerInterface,
+			Path:  "/",
+			Bus:   bus,
+		})
+		err := client.Connect()
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.Disconnect()
+
+		conn, err := bluez.DefaultConn(bus)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = conn.DBus().BusObject().Call("org.freedesktop.DBus.Peer.Ping", 0).Err
+		assert.NoError(t, err)
+
+		// the client is still usable
+		err = client.Call("GetManagedObjects", 0).Err
+		assert.NoError(t, err)
+	}
+}
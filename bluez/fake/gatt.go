@@ -0,0 +1,404 @@
+package fake
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez/profile"
+)
+
+const (
+	// GattService1Interface the bluez GATT service interface
+	GattService1Interface = "org.bluez.GattService1"
+	// GattCharacteristic1Interface the bluez GATT characteristic interface
+	GattCharacteristic1Interface = "org.bluez.GattCharacteristic1"
+	// GattDescriptor1Interface the bluez GATT descriptor interface
+	GattDescriptor1Interface = "org.bluez.GattDescriptor1"
+)
+
+// ReadCallback is called on ReadValue, overriding the stored value
+type ReadCallback func(options map[string]dbus.Variant) ([]byte, *dbus.Error)
+
+// WriteCallback is called on WriteValue
+type WriteCallback func(value []byte, options map[string]dbus.Variant) *dbus.Error
+
+// MTU is returned by AcquireWrite and AcquireNotify
+const MTU uint16 = 64
+
+// AcquireCallback receives the fake bluez end of the socket returned by AcquireWrite or AcquireNotify
+type AcquireCallback func(file *os.File, options map[string]dbus.Variant)
+
+// Service is a fake org.bluez.GattService1 object
+type Service struct {
+	*Object
+	device *Device
+}
+
+// Characteristic is a fake org.bluez.GattCharacteristic1 object
+type Characteristic struct {
+	*Object
+	service *Service
+
+	cbLock                sync.Mutex
+	readCallback          ReadCallback
+	writeCallback         WriteCallback
+	acquireWriteCallback  AcquireCallback
+	acquireNotifyCallback AcquireCallback
+}
+
+// Descriptor is a fake org.bluez.GattDescriptor1 object
+type Descriptor struct {
+	*Object
+	char *Characteristic
+
+	cbLock        sync.Mutex
+	readCallback  ReadCallback
+	writeCallback WriteCallback
+}
+
+// AddService expose a GATT service on the device and add its UUID to the device UUIDs
+func (d *Device) AddService(uuid string, primary bool) (*Service, error) {
+
+	handle := d.bluez.nextHandle()
+	path := dbus.ObjectPath(fmt.Sprintf("%s/service%04x", d.Path(), handle))
+
+	defaults := map[string]interface{}{
+		"UUID":     uuid,
+		"Device":   d.Path(),
+		"Primary":  primary,
+		"Includes": []dbus.ObjectPath{},
+		"Handle":   handle,
+	}
+
+	o, err := d.bluez.newObject(path, GattService1Interface, defaults, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{
+		Object: o,
+		device: d,
+	}
+
+	err = d.bluez.addObject(o, map[string]interface{}{GattService1Interface: s})
+	if err != nil {
+		return nil, err
+	}
+
+	uuids := append([]string{}, d.Get("UUIDs").([]string)...)
+	d.Set("UUIDs", append(uuids, uuid))
+
+	return s, nil
+}
+
+// Device return the device exposing the service
+func (s *Service) Device() *Device {
+	return s.device
+}
+
+// AddCharacteristic expose a GATT characteristic on the service
+func (s *Service) AddCharacteristic(uuid string, flags []string, value []byte) (*Characteristic, error) {
+
+	handle := s.bluez.nextHandle()
+	path := dbus.ObjectPath(fmt.Sprintf("%s/char%04x", s.Path(), handle))
+
+	if value == nil {
+		value = []byte{}
+	}
+	if flags == nil {
+		flags = []string{}
+	}
+
+	defaults := map[string]interface{}{
+		"UUID":      uuid,
+		"Service":   s.Path(),
+		"Value":     value,
+		"Notifying": false,
+		"Flags":     flags,
+		"Handle":    handle,
+	}
+
+	o, err := s.bluez.newObject(path, GattCharacteristic1Interface, defaults, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Characteristic{
+		Object:  o,
+		service: s,
+	}
+
+	err = s.bluez.addObject(o, map[string]interface{}{GattCharacteristic1Interface: c})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Service return the service exposing the characteristic
+func (c *Characteristic) Service() *Service {
+	return c.service
+}
+
+// OnRead set the callback providing the value on read
+func (c *Characteristic) OnRead(fn ReadCallback) {
+	c.cbLock.Lock()
+	defer c.cbLock.Unlock()
+	c.readCallback = fn
+}
+
+// OnWrite set the callback receiving written values
+func (c *Characteristic) OnWrite(fn WriteCallback) {
+	c.cbLock.Lock()
+	defer c.cbLock.Unlock()
+	c.writeCallback = fn
+}
+
+// OnAcquireWrite set the callback receiving the socket on AcquireWrite.
+// AcquireWrite returns NotSupported when no callback is set
+func (c *Characteristic) OnAcquireWrite(fn AcquireCallback) {
+	c.cbLock.Lock()
+	defer c.cbLock.Unlock()
+	c.acquireWriteCallback = fn
+}
+
+// OnAcquireNotify set the callback receiving the socket on AcquireNotify.
+// AcquireNotify returns NotSupported when no callback is set
+func (c *Characteristic) OnAcquireNotify(fn AcquireCallback) {
+	c.cbLock.Lock()
+	defer c.cbLock.Unlock()
+	c.acquireNotifyCallback = fn
+}
+
+// Notify update the characteristic value and emits it to subscribers
+func (c *Characteristic) Notify(value []byte) error {
+	if !c.Get("Notifying").(bool) {
+		return errors.New("Notifications are not enabled")
+	}
+	return c.Set("Value", value)
+}
+
+// ReadValue implements GattCharacteristic1.ReadValue
+func (c *Characteristic) ReadValue(options map[string]dbus.Variant) ([]byte, *dbus.Error) {
+	if err := c.call("ReadValue"); err != nil {
+		return nil, err
+	}
+
+	c.cbLock.Lock()
+	fn := c.readCallback
+	c.cbLock.Unlock()
+
+	if fn != nil {
+		value, err := fn(options)
+		if err != nil {
+			return nil, err
+		}
+		c.Set("Value", value)
+		return value, nil
+	}
+
+	return readOffset(c.Get("Value").([]byte), options)
+}
+
+// WriteValue implements GattCharacteristic1.WriteValue
+func (c *Characteristic) WriteValue(value []byte, options map[string]dbus.Variant) *dbus.Error {
+	if err := c.call("WriteValue"); err != nil {
+		return err
+	}
+
+	c.cbLock.Lock()
+	fn := c.writeCallback
+	c.cbLock.Unlock()
+
+	if fn != nil {
+		return fn(value, options)
+	}
+
+	c.Set("Value", value)
+	return nil
+}
+
+// StartNotify implements GattCharacteristic1.StartNotify
+func (c *Characteristic) StartNotify() *dbus.Error {
+	if err := c.call("StartNotify"); err != nil {
+		return err
+	}
+	c.Set("Notifying", true)
+	return nil
+}
+
+// StopNotify implements GattCharacteristic1.StopNotify
+func (c *Characteristic) StopNotify() *dbus.Error {
+	if err := c.call("StopNotify"); err != nil {
+		return err
+	}
+	if !c.Get("Notifying").(bool) {
+		return &profile.ErrFailed
+	}
+	c.Set("Notifying", false)
+	return nil
+}
+
+// AcquireWrite implements GattCharacteristic1.AcquireWrite
+func (c *Characteristic) AcquireWrite(options map[string]dbus.Variant) (dbus.UnixFD, uint16, *dbus.Error) {
+	if err := c.call("AcquireWrite"); err != nil {
+		return 0, 0, err
+	}
+
+	c.cbLock.Lock()
+	fn := c.acquireWriteCallback
+	c.cbLock.Unlock()
+
+	return acquire(fn, options)
+}
+
+// AcquireNotify implements GattCharacteristic1.AcquireNotify
+func (c *Characteristic) AcquireNotify(options map[string]dbus.Variant) (dbus.UnixFD, uint16, *dbus.Error) {
+	if err := c.call("AcquireNotify"); err != nil {
+		return 0, 0, err
+	}
+
+	c.cbLock.Lock()
+	fn := c.acquireNotifyCallback
+	c.cbLock.Unlock()
+
+	return acquire(fn, options)
+}
+
+// acquire create a socket pair, passing one end to the callback and returning the other
+func acquire(fn AcquireCallback, options map[string]dbus.Variant) (dbus.UnixFD, uint16, *dbus.Error) {
+	if fn == nil {
+		return 0, 0, &profile.ErrNotSupported
+	}
+
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_SEQPACKET|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return 0, 0, dbus.MakeFailedError(err)
+	}
+	err = syscall.SetNonblock(fds[0], true)
+	if err != nil {
+		syscall.Close(fds[0])
+		syscall.Close(fds[1])
+		return 0, 0, dbus.MakeFailedError(err)
+	}
+
+	// the reply carrying the fd is sent after the method returns
+	time.AfterFunc(time.Second, func() {
+		syscall.Close(fds[1])
+	})
+
+	fn(os.NewFile(uintptr(fds[0]), "fake"), options)
+
+	return dbus.UnixFD(fds[1]), MTU, nil
+}
+
+// AddDescriptor expose a GATT descriptor on the characteristic
+func (c *Characteristic) AddDescriptor(uuid string, flags []string, value []byte) (*Descriptor, error) {
+
+	handle := c.bluez.nextHandle()
+	path := dbus.ObjectPath(fmt.Sprintf("%s/desc%04x", c.Path(), handle))
+
+	if value == nil {
+		value = []byte{}
+	}
+	if flags == nil {
+		flags = []string{}
+	}
+
+	defaults := map[string]interface{}{
+		"UUID":           uuid,
+		"Characteristic": c.Path(),
+		"Value":          value,
+		"Flags":          flags,
+		"Handle":         handle,
+	}
+
+	o, err := c.bluez.newObject(path, GattDescriptor1Interface, defaults, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Descriptor{
+		Object: o,
+		char:   c,
+	}
+
+	err = c.bluez.addObject(o, map[string]interface{}{GattDescriptor1Interface: d})
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Characteristic return the characteristic exposing the descriptor
+func (d *Descriptor) Characteristic() *Characteristic {
+	return d.char
+}
+
+// OnRead set the callback providing the value on read
+func (d *Descriptor) OnRead(fn ReadCallback) {
+	d.cbLock.Lock()
+	defer d.cbLock.Unlock()
+	d.readCallback = fn
+}
+
+// OnWrite set the callback receiving written values
+func (d *Descriptor) OnWrite(fn WriteCallback) {
+	d.cbLock.Lock()
+	defer d.cbLock.Unlock()
+	d.writeCallback = fn
+}
+
+// ReadValue implements GattDescriptor1.ReadValue
+func (d *Descriptor) ReadValue(options map[string]dbus.Variant) ([]byte, *dbus.Error) {
+	if err := d.call("ReadValue"); err != nil {
+		return nil, err
+	}
+
+	d.cbLock.Lock()
+	fn := d.readCallback
+	d.cbLock.Unlock()
+
+	if fn != nil {
+		return fn(options)
+	}
+
+	return readOffset(d.Get("Value").([]byte), options)
+}
+
+// WriteValue implements GattDescriptor1.WriteValue
+func (d *Descriptor) WriteValue(value []byte, options map[string]dbus.Variant) *dbus.Error {
+	if err := d.call("WriteValue"); err != nil {
+		return err
+	}
+
+	d.cbLock.Lock()
+	fn := d.writeCallback
+	d.cbLock.Unlock()
+
+	if fn != nil {
+		return fn(value, options)
+	}
+
+	d.Set("Value", value)
+	return nil
+}
+
+// readOffset return the value starting from the offset option
+func readOffset(value []byte, options map[string]dbus.Variant) ([]byte, *dbus.Error) {
+	offset, ok := options["offset"].Value().(uint16)
+	if !ok {
+		return value, nil
+	}
+	if int(offset) > len(value) {
+		return nil, &profile.ErrInvalidOffset
+	}
+	return value[offset:], nil
+}
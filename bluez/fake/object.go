@@ -0,0 +1,263 @@
+package fake
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/godbus/dbus/v5/prop"
+	"github.com/muka/go-bluetooth/bluez"
+)
+
+// Hook is called before the default implementation of a method.
+// Returning an error aborts the call and replies with that error.
+type Hook func() *dbus.Error
+
+// Object is an object exposed by the fake daemon
+type Object struct {
+	bluez *Bluez
+	path  dbus.ObjectPath
+	iface string
+	props *prop.Properties
+	// ifaces lists all the exposed interfaces, the first is iface
+	ifaces []string
+
+	lock  sync.Mutex
+	hooks map[string]Hook
+}
+
+// Path return the object path
+func (o *Object) Path() dbus.ObjectPath {
+	return o.path
+}
+
+// Interface return the object interface
+func (o *Object) Interface() string {
+	return o.iface
+}
+
+// Get return a property value
+func (o *Object) Get(name string) interface{} {
+	return o.GetProperty(o.iface, name)
+}
+
+// Set update a property value, emitting PropertiesChanged
+func (o *Object) Set(name string, value interface{}) error {
+	return o.SetProperty(o.iface, name, value)
+}
+
+// GetProperty return a property value of one of the object interfaces
+func (o *Object) GetProperty(iface, name string) interface{} {
+	v, err := o.props.Get(iface, name)
+	if err != nil {
+		return nil
+	}
+	return v.Value()
+}
+
+// SetProperty update a property value of one of the object interfaces, emitting PropertiesChanged
+func (o *Object) SetProperty(iface, name string, value interface{}) (err error) {
+	_, derr := o.props.Get(iface, name)
+	if derr != nil {
+		return fmt.Errorf("Property %s.%s: %s", iface, name, derr)
+	}
+	// SetMust panics if the signal cannot be emitted, eg. the fake is closing
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Property %s.%s: %v", iface, name, r)
+		}
+	}()
+	o.props.SetMust(iface, name, value)
+	return nil
+}
+
+// OnCall register an hook called before the method implementation.
+// Pass a nil hook to remove it.
+func (o *Object) OnCall(method string, fn Hook) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if fn == nil {
+		delete(o.hooks, method)
+		return
+	}
+	o.hooks[method] = fn
+}
+
+// call run the hook registered for a method, if any
+func (o *Object) call(method string) *dbus.Error {
+	o.lock.Lock()
+	fn := o.hooks[method]
+	o.lock.Unlock()
+	if fn == nil {
+		return nil
+	}
+	return fn()
+}
+
+// newObject create an object with a set of default properties, overridden by props.
+// extra lists the read-only properties of additional interfaces exposed by the object
+func (b *Bluez) newObject(path dbus.ObjectPath, iface string, defaults map[string]interface{}, props map[string]interface{}, extra map[string]map[string]interface{}, writable ...string) (*Object, error) {
+
+	propsConfig := map[string]*prop.Prop{}
+	for name, value := range defaults {
+		propsConfig[name] = &prop.Prop{
+			Value: value,
+			Emit:  prop.EmitTrue,
+		}
+	}
+	for name, value := range props {
+		propsConfig[name] = &prop.Prop{
+			Value: value,
+			Emit:  prop.EmitTrue,
+		}
+	}
+	for _, name := range writable {
+		if p, ok := propsConfig[name]; ok {
+			p.Writable = true
+		}
+	}
+
+	o := &Object{
+		bluez:  b,
+		path:   path,
+		iface:  iface,
+		ifaces: []string{iface},
+		hooks:  make(map[string]Hook),
+	}
+
+	ifacesConfig := map[string]map[string]*prop.Prop{
+		iface: propsConfig,
+	}
+	for extraIface, extraProps := range extra {
+		ifacesConfig[extraIface] = map[string]*prop.Prop{}
+		for name, value := range extraProps {
+			ifacesConfig[extraIface][name] = &prop.Prop{
+				Value: value,
+				Emit:  prop.EmitTrue,
+			}
+		}
+		o.ifaces = append(o.ifaces, extraIface)
+	}
+
+	p, err := prop.Export(b.conn, path, ifacesConfig)
+	if err != nil {
+		return nil, err
+	}
+	o.props = p
+
+	return o, nil
+}
+
+// addObject export an object and notify InterfacesAdded.
+// impl implements the methods of the object interfaces, keyed by interface name
+func (b *Bluez) addObject(o *Object, impl map[string]interface{}) error {
+
+	if b.Object(o.path) != nil {
+		return fmt.Errorf("Object %s already exists", o.path)
+	}
+
+	node := &introspect.Node{
+		Interfaces: []introspect.Interface{
+			introspect.IntrospectData,
+			prop.IntrospectData,
+		},
+	}
+
+	for _, iface := range o.ifaces {
+		err := b.conn.Export(impl[iface], o.path, iface)
+		if err != nil {
+			return err
+		}
+		node.Interfaces = append(node.Interfaces, introspect.Interface{
+			Name:       iface,
+			Methods:    introspect.Methods(impl[iface]),
+			Properties: o.props.Introspection(iface),
+		})
+	}
+
+	err := b.conn.Export(introspect.NewIntrospectable(node), o.path, bluez.Introspectable)
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	b.objects[o.path] = o
+	b.lock.Unlock()
+
+	return b.conn.Emit("/", bluez.InterfacesAdded, o.path, o.managedObject())
+}
+
+// removeObject remove an object and its children, notifying InterfacesRemoved
+func (b *Bluez) removeObject(path dbus.ObjectPath) error {
+
+	b.lock.Lock()
+	o, ok := b.objects[path]
+	children := []dbus.ObjectPath{}
+	for p := range b.objects {
+		if strings.HasPrefix(string(p), string(path)+"/") {
+			children = append(children, p)
+		}
+	}
+	b.lock.Unlock()
+
+	if !ok {
+		return fmt.Errorf("Object %s not found", path)
+	}
+
+	for _, child := range children {
+		if b.Object(child) == nil {
+			continue
+		}
+		err := b.removeObject(child)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, iface := range o.ifaces {
+		b.conn.Export(nil, path, iface)
+	}
+	b.conn.Export(nil, path, bluez.PropertiesInterface)
+	b.conn.Export(nil, path, bluez.Introspectable)
+
+	b.lock.Lock()
+	delete(b.objects, path)
+	b.lock.Unlock()
+
+	return b.conn.Emit("/", bluez.InterfacesRemoved, path, o.ifaces)
+}
+
+func (o *Object) managedObject() map[string]map[string]dbus.Variant {
+	ifaces := map[string]map[string]dbus.Variant{}
+	for _, iface := range o.ifaces {
+		props, err := o.props.GetAll(iface)
+		if err != nil {
+			props = map[string]dbus.Variant{}
+		}
+		ifaces[iface] = props
+	}
+	return ifaces
+}
+
+// objectManager implements org.freedesktop.DBus.ObjectManager
+type objectManager struct {
+	bluez *Bluez
+}
+
+// GetManagedObjects return the state of all the exposed objects
+func (om *objectManager) GetManagedObjects() (map[dbus.ObjectPath]map[string]map[string]dbus.Variant, *dbus.Error) {
+	om.bluez.lock.Lock()
+	objects := make([]*Object, 0, len(om.bluez.objects))
+	for _, o := range om.bluez.objects {
+		objects = append(objects, o)
+	}
+	om.bluez.lock.Unlock()
+
+	res := make(map[dbus.ObjectPath]map[string]map[string]dbus.Variant)
+	for _, o := range objects {
+		res[o.path] = o.managedObject()
+	}
+	return res, nil
+}
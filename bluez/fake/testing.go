@@ -0,0 +1,53 @@
+package fake
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// StartT start a fake daemon for a test, skipping it if dbus-daemon is not
+// available. The daemon is closed when the test and its subtests complete
+func StartT(t testing.TB) *Bluez {
+	t.Helper()
+	b, err := Start()
+	if err == ErrDaemonNotFound {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		b.Close()
+	})
+	return b
+}
+
+// StartAdapterT start a fake daemon for a test, as StartT, exposing the
+// adapter adapterID. props override the default adapter properties
+func StartAdapterT(t testing.TB, adapterID string, props map[string]interface{}) (*Bluez, *Adapter) {
+	t.Helper()
+	b := StartT(t)
+	a, err := b.AddAdapter(adapterID, props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, a
+}
+
+// WaitGoroutines wait for the running goroutines to get back to max, failing
+// the test with a dump of the goroutines stacks if they do not
+func WaitGoroutines(t testing.TB, max int) {
+	t.Helper()
+	n := 0
+	for i := 0; i < 100; i++ {
+		n = runtime.NumGoroutine()
+		if n <= max {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	buf := make([]byte, 1<<16)
+	buf = buf[:runtime.Stack(buf, true)]
+	t.Fatalf("Goroutines leaked: %d running, expected at most %d\n%s", n, max, buf)
+}
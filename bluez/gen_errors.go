@@ -0,0 +1,102 @@
+// Code generated by go-bluetooth generator DO NOT EDIT.
+
+package bluez
+
+var (
+	// ErrNotReady map to org.bluez.Error.NotReady
+	ErrNotReady = &Error{
+		Name: "org.bluez.Error.NotReady",
+	}
+	// ErrInvalidArguments map to org.bluez.Error.InvalidArguments
+	ErrInvalidArguments = &Error{
+		Name: "org.bluez.Error.InvalidArguments",
+	}
+	// ErrFailed map to org.bluez.Error.Failed
+	ErrFailed = &Error{
+		Name: "org.bluez.Error.Failed",
+	}
+	// ErrDoesNotExist map to org.bluez.Error.DoesNotExist
+	ErrDoesNotExist = &Error{
+		Name: "org.bluez.Error.DoesNotExist",
+	}
+	// ErrRejected map to org.bluez.Error.Rejected
+	ErrRejected = &Error{
+		Name: "org.bluez.Error.Rejected",
+	}
+	// ErrNotConnected map to org.bluez.Error.NotConnected
+	ErrNotConnected = &Error{
+		Name: "org.bluez.Error.NotConnected",
+	}
+	// ErrNotAcquired map to org.bluez.Error.NotAcquired
+	ErrNotAcquired = &Error{
+		Name: "org.bluez.Error.NotAcquired",
+	}
+	// ErrNotSupported map to org.bluez.Error.NotSupported
+	ErrNotSupported = &Error{
+		Name: "org.bluez.Error.NotSupported",
+	}
+	// ErrNotAuthorized map to org.bluez.Error.NotAuthorized
+	ErrNotAuthorized = &Error{
+		Name: "org.bluez.Error.NotAuthorized",
+	}
+	// ErrNotAvailable map to org.bluez.Error.NotAvailable
+	ErrNotAvailable = &Error{
+		Name: "org.bluez.Error.NotAvailable",
+	}
+	// ErrAlreadyConnected map to org.bluez.Error.AlreadyConnected
+	ErrAlreadyConnected = &Error{
+		Name: "org.bluez.Error.AlreadyConnected",
+	}
+	// ErrInProgress map to org.bluez.Error.InProgress
+	ErrInProgress = &Error{
+		Name: "org.bluez.Error.InProgress",
+	}
+	// ErrAlreadyExists map to org.bluez.Error.AlreadyExists
+	ErrAlreadyExists = &Error{
+		Name: "org.bluez.Error.AlreadyExists",
+	}
+	// ErrNotPermitted map to org.bluez.Error.NotPermitted
+	ErrNotPermitted = &Error{
+		Name: "org.bluez.Error.NotPermitted",
+	}
+	// ErrNoSuchAdapter map to org.bluez.Error.NoSuchAdapter
+	ErrNoSuchAdapter = &Error{
+		Name: "org.bluez.Error.NoSuchAdapter",
+	}
+	// ErrAgentNotAvailable map to org.bluez.Error.AgentNotAvailable
+	ErrAgentNotAvailable = &Error{
+		Name: "org.bluez.Error.AgentNotAvailable",
+	}
+	// ErrCanceled map to org.bluez.Error.Canceled
+	ErrCanceled = &Error{
+		Name: "org.bluez.Error.Canceled",
+	}
+	// ErrInvalidOffset map to org.bluez.Error.InvalidOffset
+	ErrInvalidOffset = &Error{
+		Name: "org.bluez.Error.InvalidOffset",
+	}
+	// ErrInvalidValueLength map to org.bluez.Error.InvalidValueLength
+	ErrInvalidValueLength = &Error{
+		Name: "org.bluez.Error.InvalidValueLength",
+	}
+	// ErrAuthenticationCanceled map to org.bluez.Error.AuthenticationCanceled
+	ErrAuthenticationCanceled = &Error{
+		Name: "org.bluez.Error.AuthenticationCanceled",
+	}
+	// ErrAuthenticationFailed map to org.bluez.Error.AuthenticationFailed
+	ErrAuthenticationFailed = &Error{
+		Name: "org.bluez.Error.AuthenticationFailed",
+	}
+	// ErrAuthenticationRejected map to org.bluez.Error.AuthenticationRejected
+	ErrAuthenticationRejected = &Error{
+		Name: "org.bluez.Error.AuthenticationRejected",
+	}
+	// ErrAuthenticationTimeout map to org.bluez.Error.AuthenticationTimeout
+	ErrAuthenticationTimeout = &Error{
+		Name: "org.bluez.Error.AuthenticationTimeout",
+	}
+	// ErrConnectionAttemptFailed map to org.bluez.Error.ConnectionAttemptFailed
+	ErrConnectionAttemptFailed = &Error{
+		Name: "org.bluez.Error.ConnectionAttemptFailed",
+	}
+)
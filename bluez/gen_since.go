@@ -0,0 +1,452 @@
+// Code generated by go-bluetooth generator DO NOT EDIT.
+
+package bluez
+
+// SinceOldest is the oldest version of the API definitions, tagging everything
+// available up to then: a member tagged with it is available since bluez <= SinceOldest
+const SinceOldest = "5.50"
+
+// Since map the interfaces, and their members as <interface>.<member>, to the
+// bluez version introducing them, see SinceOldest
+var Since = map[string]string{
+	"org.bluez.Adapter1":                                           "5.50",
+	"org.bluez.Adapter1.Address":                                   "5.50",
+	"org.bluez.Adapter1.AddressType":                               "5.50",
+	"org.bluez.Adapter1.Alias":                                     "5.50",
+	"org.bluez.Adapter1.Class":                                     "5.50",
+	"org.bluez.Adapter1.ConnectDevice":                             "5.50",
+	"org.bluez.Adapter1.Discoverable":                              "5.50",
+	"org.bluez.Adapter1.DiscoverableTimeout":                       "5.50",
+	"org.bluez.Adapter1.Discovering":                               "5.50",
+	"org.bluez.Adapter1.GetDiscoveryFilters":                       "5.50",
+	"org.bluez.Adapter1.Modalias":                                  "5.50",
+	"org.bluez.Adapter1.Name":                                      "5.50",
+	"org.bluez.Adapter1.Pairable":                                  "5.50",
+	"org.bluez.Adapter1.PairableTimeout":                           "5.50",
+	"org.bluez.Adapter1.Powered":                                   "5.50",
+	"org.bluez.Adapter1.RemoveDevice":                              "5.50",
+	"org.bluez.Adapter1.Roles":                                     "5.55",
+	"org.bluez.Adapter1.SetDiscoveryFilter":                        "5.50",
+	"org.bluez.Adapter1.StartDiscovery":                            "5.50",
+	"org.bluez.Adapter1.StopDiscovery":                             "5.50",
+	"org.bluez.Adapter1.UUIDs":                                     "5.50",
+	"org.bluez.AdvertisementMonitor1":                              "5.55",
+	"org.bluez.AdvertisementMonitor1.Activate":                     "5.55",
+	"org.bluez.AdvertisementMonitor1.DeviceFound":                  "5.55",
+	"org.bluez.AdvertisementMonitor1.DeviceLost":                   "5.55",
+	"org.bluez.AdvertisementMonitor1.Patterns":                     "5.55",
+	"org.bluez.AdvertisementMonitor1.RSSIThresholdsAndTimers":      "5.55",
+	"org.bluez.AdvertisementMonitor1.Release":                      "5.55",
+	"org.bluez.AdvertisementMonitor1.Type":                         "5.55",
+	"org.bluez.AdvertisementMonitorManager1":                       "5.55",
+	"org.bluez.AdvertisementMonitorManager1.RegisterMonitor":       "5.55",
+	"org.bluez.AdvertisementMonitorManager1.SupportedFeatures":     "5.55",
+	"org.bluez.AdvertisementMonitorManager1.SupportedMonitorTypes": "5.55",
+	"org.bluez.AdvertisementMonitorManager1.UnregisterMonitor":     "5.55",
+	"org.bluez.Agent1":                                             "5.50",
+	"org.bluez.Agent1.AuthorizeService":                            "5.50",
+	"org.bluez.Agent1.Cancel":                                      "5.50",
+	"org.bluez.Agent1.DisplayPasskey":                              "5.50",
+	"org.bluez.Agent1.DisplayPinCode":                              "5.50",
+	"org.bluez.Agent1.Release":                                     "5.50",
+	"org.bluez.Agent1.RequestAuthorization":                        "5.50",
+	"org.bluez.Agent1.RequestConfirmation":                         "5.50",
+	"org.bluez.Agent1.RequestPasskey":                              "5.50",
+	"org.bluez.Agent1.RequestPinCode":                              "5.50",
+	"org.bluez.AgentManager1":                                      "5.50",
+	"org.bluez.AgentManager1.RegisterAgent":                        "5.50",
+	"org.bluez.AgentManager1.RequestDefaultAgent":                  "5.50",
+	"org.bluez.AgentManager1.UnregisterAgent":                      "5.50",
+	"org.bluez.Battery1":                                           "5.50",
+	"org.bluez.Battery1.Percentage":                                "5.50",
+	"org.bluez.Device1":                                            "5.50",
+	"org.bluez.Device1.Adapter":                                    "5.50",
+	"org.bluez.Device1.Address":                                    "5.50",
+	"org.bluez.Device1.AddressType":                                "5.50",
+	"org.bluez.Device1.AdvertisingData":                            "5.50",
+	"org.bluez.Device1.AdvertisingFlags":                           "5.50",
+	"org.bluez.Device1.Alias":                                      "5.50",
+	"org.bluez.Device1.Appearance":                                 "5.50",
+	"org.bluez.Device1.Blocked":                                    "5.50",
+	"org.bluez.Device1.CancelPairing":                              "5.50",
+	"org.bluez.Device1.Class":                                      "5.50",
+	"org.bluez.Device1.Connect":                                    "5.50",
+	"org.bluez.Device1.ConnectProfile":                             "5.50",
+	"org.bluez.Device1.Connected":                                  "5.50",
+	"org.bluez.Device1.Disconnect":                                 "5.50",
+	"org.bluez.Device1.DisconnectProfile":                          "5.50",
+	"org.bluez.Device1.Icon":                                       "5.50",
+	"org.bluez.Device1.LegacyPairing":                              "5.50",
+	"org.bluez.Device1.ManufacturerData":                           "5.50",
+	"org.bluez.Device1.Modalias":                                   "5.50",
+	"org.bluez.Device1.Name":                                       "5.50",
+	"org.bluez.Device1.Pair":                                       "5.50",
+	"org.bluez.Device1.Paired":                                     "5.50",
+	"org.bluez.Device1.RSSI":                                       "5.50",
+	"org.bluez.Device1.ServiceData":                                "5.50",
+	"org.bluez.Device1.ServicesResolved":                           "5.50",
+	"org.bluez.Device1.Trusted":                                    "5.50",
+	"org.bluez.Device1.TxPower":                                    "5.50",
+	"org.bluez.Device1.UUIDs":                                      "5.50",
+	"org.bluez.Device1.WakeAllowed":                                "5.55",
+	"org.bluez.GattCharacteristic1":                                "5.50",
+	"org.bluez.GattCharacteristic1.AcquireNotify":                  "5.50",
+	"org.bluez.GattCharacteristic1.AcquireWrite":                   "5.50",
+	"org.bluez.GattCharacteristic1.Confirm":                        "5.50",
+	"org.bluez.GattCharacteristic1.Flags":                          "5.50",
+	"org.bluez.GattCharacteristic1.Handle":                         "5.53",
+	"org.bluez.GattCharacteristic1.NotifyAcquired":                 "5.50",
+	"org.bluez.GattCharacteristic1.Notifying":                      "5.50",
+	"org.bluez.GattCharacteristic1.ReadValue":                      "5.50",
+	"org.bluez.GattCharacteristic1.Service":                        "5.50",
+	"org.bluez.GattCharacteristic1.StartNotify":                    "5.50",
+	"org.bluez.GattCharacteristic1.StopNotify":                     "5.50",
+	"org.bluez.GattCharacteristic1.UUID":                           "5.50",
+	"org.bluez.GattCharacteristic1.Value":                          "5.50",
+	"org.bluez.GattCharacteristic1.WriteAcquired":                  "5.50",
+	"org.bluez.GattCharacteristic1.WriteValue":                     "5.50",
+	"org.bluez.GattDescriptor1":                                    "5.50",
+	"org.bluez.GattDescriptor1.Characteristic":                     "5.50",
+	"org.bluez.GattDescriptor1.Flags":                              "5.50",
+	"org.bluez.GattDescriptor1.Handle":                             "5.53",
+	"org.bluez.GattDescriptor1.ReadValue":                          "5.50",
+	"org.bluez.GattDescriptor1.UUID":                               "5.50",
+	"org.bluez.GattDescriptor1.Value":                              "5.50",
+	"org.bluez.GattDescriptor1.WriteValue":                         "5.50",
+	"org.bluez.GattManager1":                                       "5.50",
+	"org.bluez.GattManager1.RegisterApplication":                   "5.50",
+	"org.bluez.GattManager1.UnregisterApplication":                 "5.50",
+	"org.bluez.GattProfile1":                                       "5.50",
+	"org.bluez.GattProfile1.Release":                               "5.50",
+	"org.bluez.GattProfile1.UUIDs":                                 "5.50",
+	"org.bluez.GattService1":                                       "5.50",
+	"org.bluez.GattService1.Device":                                "5.50",
+	"org.bluez.GattService1.Handle":                                "5.53",
+	"org.bluez.GattService1.Includes":                              "5.50",
+	"org.bluez.GattService1.Primary":                               "5.50",
+	"org.bluez.GattService1.UUID":                                  "5.50",
+	"org.bluez.HealthChannel1":                                     "5.50",
+	"org.bluez.HealthChannel1.Acquire":                             "5.50",
+	"org.bluez.HealthChannel1.Application":                         "5.50",
+	"org.bluez.HealthChannel1.Device":                              "5.50",
+	"org.bluez.HealthChannel1.Release":                             "5.50",
+	"org.bluez.HealthChannel1.Type":                                "5.50",
+	"org.bluez.HealthDevice1":                                      "5.50",
+	"org.bluez.HealthDevice1.ChannelConnected":                     "5.50",
+	"org.bluez.HealthDevice1.ChannelDeleted":                       "5.50",
+	"org.bluez.HealthDevice1.CreateChannel":                        "5.50",
+	"org.bluez.HealthDevice1.DestroyChannel":                       "5.50",
+	"org.bluez.HealthDevice1.Echo":                                 "5.50",
+	"org.bluez.HealthDevice1.MainChannel":                          "5.50",
+	"org.bluez.HealthManager1":                                     "5.50",
+	"org.bluez.HealthManager1.CreateApplication":                   "5.50",
+	"org.bluez.HealthManager1.DestroyApplication":                  "5.50",
+	"org.bluez.Input1":                                             "5.50",
+	"org.bluez.Input1.ReconnectMode":                               "5.50",
+	"org.bluez.LEAdvertisement1":                                   "5.50",
+	"org.bluez.LEAdvertisement1.Appearance":                        "5.50",
+	"org.bluez.LEAdvertisement1.Data":                              "5.50",
+	"org.bluez.LEAdvertisement1.Discoverable":                      "5.50",
+	"org.bluez.LEAdvertisement1.DiscoverableTimeout":               "5.50",
+	"org.bluez.LEAdvertisement1.Duration":                          "5.50",
+	"org.bluez.LEAdvertisement1.Includes":                          "5.50",
+	"org.bluez.LEAdvertisement1.LocalName":                         "5.50",
+	"org.bluez.LEAdvertisement1.ManufacturerData":                  "5.50",
+	"org.bluez.LEAdvertisement1.Release":                           "5.50",
+	"org.bluez.LEAdvertisement1.SecondaryChannel":                  "5.53",
+	"org.bluez.LEAdvertisement1.ServiceData":                       "5.50",
+	"org.bluez.LEAdvertisement1.ServiceUUIDs":                      "5.50",
+	"org.bluez.LEAdvertisement1.SolicitUUIDs":                      "5.50",
+	"org.bluez.LEAdvertisement1.Timeout":                           "5.50",
+	"org.bluez.LEAdvertisement1.Type":                              "5.50",
+	"org.bluez.LEAdvertisingManager1":                              "5.50",
+	"org.bluez.LEAdvertisingManager1.ActiveInstances":              "5.50",
+	"org.bluez.LEAdvertisingManager1.RegisterAdvertisement":        "5.50",
+	"org.bluez.LEAdvertisingManager1.SupportedIncludes":            "5.50",
+	"org.bluez.LEAdvertisingManager1.SupportedInstances":           "5.50",
+	"org.bluez.LEAdvertisingManager1.SupportedSecondaryChannels":   "5.53",
+	"org.bluez.LEAdvertisingManager1.UnregisterAdvertisement":      "5.50",
+	"org.bluez.Media1":                                             "5.50",
+	"org.bluez.Media1.RegisterApplication":                         "5.53",
+	"org.bluez.Media1.RegisterEndpoint":                            "5.50",
+	"org.bluez.Media1.RegisterPlayer":                              "5.50",
+	"org.bluez.Media1.UnregisterApplication":                       "5.53",
+	"org.bluez.Media1.UnregisterEndpoint":                          "5.50",
+	"org.bluez.Media1.UnregisterPlayer":                            "5.50",
+	"org.bluez.MediaControl1":                                      "5.50",
+	"org.bluez.MediaControl1.Connected":                            "5.50",
+	"org.bluez.MediaControl1.FastForward":                          "5.50",
+	"org.bluez.MediaControl1.Next":                                 "5.50",
+	"org.bluez.MediaControl1.Pause":                                "5.50",
+	"org.bluez.MediaControl1.Play":                                 "5.50",
+	"org.bluez.MediaControl1.Player":                               "5.50",
+	"org.bluez.MediaControl1.Previous":                             "5.50",
+	"org.bluez.MediaControl1.Rewind":                               "5.50",
+	"org.bluez.MediaControl1.Stop":                                 "5.50",
+	"org.bluez.MediaControl1.VolumeDown":                           "5.50",
+	"org.bluez.MediaControl1.VolumeUp":                             "5.50",
+	"org.bluez.MediaEndpoint1":                                     "5.50",
+	"org.bluez.MediaEndpoint1.Capabilities":                        "5.53",
+	"org.bluez.MediaEndpoint1.ClearConfiguration":                  "5.50",
+	"org.bluez.MediaEndpoint1.Codec":                               "5.53",
+	"org.bluez.MediaEndpoint1.DelayReporting":                      "5.55",
+	"org.bluez.MediaEndpoint1.Device":                              "5.53",
+	"org.bluez.MediaEndpoint1.Release":                             "5.50",
+	"org.bluez.MediaEndpoint1.SelectConfiguration":                 "5.50",
+	"org.bluez.MediaEndpoint1.SetConfiguration":                    "5.50",
+	"org.bluez.MediaEndpoint1.UUID":                                "5.53",
+	"org.bluez.MediaFolder1":                                       "5.50",
+	"org.bluez.MediaFolder1.Attributes":                            "5.50",
+	"org.bluez.MediaFolder1.ChangeFolder":                          "5.50",
+	"org.bluez.MediaFolder1.End:":                                  "5.50",
+	"org.bluez.MediaFolder1.ListItems":                             "5.50",
+	"org.bluez.MediaFolder1.Name":                                  "5.50",
+	"org.bluez.MediaFolder1.NumberOfItems":                         "5.50",
+	"org.bluez.MediaFolder1.Search":                                "5.50",
+	"org.bluez.MediaFolder1.Start:":                                "5.50",
+	"org.bluez.MediaItem1":                                         "5.50",
+	"org.bluez.MediaItem1.AddtoNowPlaying":                         "5.50",
+	"org.bluez.MediaItem1.Album":                                   "5.50",
+	"org.bluez.MediaItem1.Artist":                                  "5.50",
+	"org.bluez.MediaItem1.Duration":                                "5.50",
+	"org.bluez.MediaItem1.FolderType":                              "5.50",
+	"org.bluez.MediaItem1.Genre":                                   "5.50",
+	"org.bluez.MediaItem1.Metadata":                                "5.50",
+	"org.bluez.MediaItem1.Name":                                    "5.50",
+	"org.bluez.MediaItem1.Number":                                  "5.50",
+	"org.bluez.MediaItem1.NumberOfTracks":                          "5.50",
+	"org.bluez.MediaItem1.Play":                                    "5.50",
+	"org.bluez.MediaItem1.Playable":                                "5.50",
+	"org.bluez.MediaItem1.Player":                                  "5.50",
+	"org.bluez.MediaItem1.Title":                                   "5.50",
+	"org.bluez.MediaItem1.Type":                                    "5.50",
+	"org.bluez.MediaPlayer1":                                       "5.50",
+	"org.bluez.MediaPlayer1.Album:":                                "5.50",
+	"org.bluez.MediaPlayer1.Artist:":                               "5.50",
+	"org.bluez.MediaPlayer1.Browsable":                             "5.50",
+	"org.bluez.MediaPlayer1.Device":                                "5.50",
+	"org.bluez.MediaPlayer1.Duration:":                             "5.50",
+	"org.bluez.MediaPlayer1.Equalizer":                             "5.50",
+	"org.bluez.MediaPlayer1.FastForward":                           "5.50",
+	"org.bluez.MediaPlayer1.Genre:":                                "5.50",
+	"org.bluez.MediaPlayer1.Hold":                                  "5.55",
+	"org.bluez.MediaPlayer1.Name":                                  "5.50",
+	"org.bluez.MediaPlayer1.Next":                                  "5.50",
+	"org.bluez.MediaPlayer1.NumberOfTracks:":                       "5.50",
+	"org.bluez.MediaPlayer1.Pause":                                 "5.50",
+	"org.bluez.MediaPlayer1.Play":                                  "5.50",
+	"org.bluez.MediaPlayer1.Playlist":                              "5.50",
+	"org.bluez.MediaPlayer1.Position":                              "5.50",
+	"org.bluez.MediaPlayer1.Press":                                 "5.55",
+	"org.bluez.MediaPlayer1.Previous":                              "5.50",
+	"org.bluez.MediaPlayer1.Release":                               "5.55",
+	"org.bluez.MediaPlayer1.Repeat":                                "5.50",
+	"org.bluez.MediaPlayer1.Rewind":                                "5.50",
+	"org.bluez.MediaPlayer1.Scan":                                  "5.50",
+	"org.bluez.MediaPlayer1.Searchable":                            "5.50",
+	"org.bluez.MediaPlayer1.Shuffle":                               "5.50",
+	"org.bluez.MediaPlayer1.Status":                                "5.50",
+	"org.bluez.MediaPlayer1.Stop":                                  "5.50",
+	"org.bluez.MediaPlayer1.Subtype":                               "5.50",
+	"org.bluez.MediaPlayer1.Title:":                                "5.50",
+	"org.bluez.MediaPlayer1.Track":                                 "5.50",
+	"org.bluez.MediaPlayer1.TrackNumber:":                          "5.50",
+	"org.bluez.MediaPlayer1.Type":                                  "5.50",
+	"org.bluez.MediaTransport1":                                    "5.50",
+	"org.bluez.MediaTransport1.Acquire":                            "5.50",
+	"org.bluez.MediaTransport1.Codec":                              "5.50",
+	"org.bluez.MediaTransport1.Configuration":                      "5.50",
+	"org.bluez.MediaTransport1.Delay":                              "5.50",
+	"org.bluez.MediaTransport1.Device":                             "5.50",
+	"org.bluez.MediaTransport1.Endpoint":                           "5.53",
+	"org.bluez.MediaTransport1.Release":                            "5.50",
+	"org.bluez.MediaTransport1.State":                              "5.50",
+	"org.bluez.MediaTransport1.TryAcquire":                         "5.50",
+	"org.bluez.MediaTransport1.UUID":                               "5.50",
+	"org.bluez.MediaTransport1.Volume":                             "5.50",
+	"org.bluez.Network1":                                           "5.50",
+	"org.bluez.Network1.Connect":                                   "5.50",
+	"org.bluez.Network1.Connected":                                 "5.50",
+	"org.bluez.Network1.Disconnect":                                "5.50",
+	"org.bluez.Network1.Interface":                                 "5.50",
+	"org.bluez.Network1.UUID":                                      "5.50",
+	"org.bluez.NetworkServer1":                                     "5.50",
+	"org.bluez.NetworkServer1.Register":                            "5.50",
+	"org.bluez.NetworkServer1.Unregister":                          "5.50",
+	"org.bluez.Profile1":                                           "5.50",
+	"org.bluez.Profile1.NewConnection":                             "5.50",
+	"org.bluez.Profile1.Release":                                   "5.50",
+	"org.bluez.Profile1.RequestDisconnection":                      "5.50",
+	"org.bluez.ProfileManager1":                                    "5.50",
+	"org.bluez.ProfileManager1.RegisterProfile":                    "5.50",
+	"org.bluez.ProfileManager1.UnregisterProfile":                  "5.50",
+	"org.bluez.SimAccess1":                                         "5.50",
+	"org.bluez.SimAccess1.Connected":                               "5.50",
+	"org.bluez.SimAccess1.Disconnect":                              "5.50",
+	"org.bluez.Thermometer1":                                       "5.50",
+	"org.bluez.Thermometer1.Intermediate":                          "5.50",
+	"org.bluez.Thermometer1.Interval":                              "5.50",
+	"org.bluez.Thermometer1.Maximum":                               "5.50",
+	"org.bluez.Thermometer1.Minimum":                               "5.50",
+	"org.bluez.ThermometerManager1":                                "5.50",
+	"org.bluez.ThermometerManager1.DisableIntermediateMeasurement": "5.50",
+	"org.bluez.ThermometerManager1.EnableIntermediateMeasurement":  "5.50",
+	"org.bluez.ThermometerManager1.RegisterWatcher":                "5.50",
+	"org.bluez.ThermometerManager1.UnregisterWatcher":              "5.50",
+	"org.bluez.ThermometerWatcher1":                                "5.50",
+	"org.bluez.ThermometerWatcher1.MeasurementReceived":            "5.50",
+	"org.bluez.mesh.Application1":                                  "5.53",
+	"org.bluez.mesh.Application1.CRPL":                             "5.53",
+	"org.bluez.mesh.Application1.CompanyID":                        "5.53",
+	"org.bluez.mesh.Application1.JoinComplete":                     "5.53",
+	"org.bluez.mesh.Application1.JoinFailed":                       "5.53",
+	"org.bluez.mesh.Application1.ProductID":                        "5.53",
+	"org.bluez.mesh.Application1.VersionID":                        "5.53",
+	"org.bluez.mesh.Attention1":                                    "5.53",
+	"org.bluez.mesh.Attention1.GetTimer":                           "5.53",
+	"org.bluez.mesh.Attention1.SetTimer":                           "5.53",
+	"org.bluez.mesh.Element1":                                      "5.53",
+	"org.bluez.mesh.Element1.DevKeyMessageReceived":                "5.53",
+	"org.bluez.mesh.Element1.Location":                             "5.53",
+	"org.bluez.mesh.Element1.MessageReceived":                      "5.53",
+	"org.bluez.mesh.Element1.Models":                               "5.53",
+	"org.bluez.mesh.Element1.Publish":                              "5.55",
+	"org.bluez.mesh.Element1.Subscribe":                            "5.55",
+	"org.bluez.mesh.Element1.UpdateModelConfiguration":             "5.53",
+	"org.bluez.mesh.Element1.VendorModels":                         "5.53",
+	"org.bluez.mesh.Management1":                                   "5.53",
+	"org.bluez.mesh.Management1.AddNode":                           "5.53",
+	"org.bluez.mesh.Management1.CreateAppKey":                      "5.53",
+	"org.bluez.mesh.Management1.CreateSubnet":                      "5.53",
+	"org.bluez.mesh.Management1.DeleteAppKey":                      "5.53",
+	"org.bluez.mesh.Management1.DeleteRemoteNode":                  "5.53",
+	"org.bluez.mesh.Management1.DeleteSubnet":                      "5.53",
+	"org.bluez.mesh.Management1.ImportAppKey":                      "5.53",
+	"org.bluez.mesh.Management1.ImportRemoteNode":                  "5.53",
+	"org.bluez.mesh.Management1.ImportSubnet":                      "5.53",
+	"org.bluez.mesh.Management1.SetKeyPhase":                       "5.53",
+	"org.bluez.mesh.Management1.UnprovisionedScan":                 "5.53",
+	"org.bluez.mesh.Management1.UnprovisionedScanCancel":           "5.53",
+	"org.bluez.mesh.Management1.UpdateAppKey":                      "5.53",
+	"org.bluez.mesh.Management1.UpdateSubnet":                      "5.53",
+	"org.bluez.mesh.Network1":                                      "5.53",
+	"org.bluez.mesh.Network1.Attach":                               "5.53",
+	"org.bluez.mesh.Network1.Cancel":                               "5.53",
+	"org.bluez.mesh.Network1.CreateNetwork":                        "5.53",
+	"org.bluez.mesh.Network1.Import":                               "5.53",
+	"org.bluez.mesh.Network1.Join":                                 "5.53",
+	"org.bluez.mesh.Network1.Leave":                                "5.53",
+	"org.bluez.mesh.Node1":                                         "5.53",
+	"org.bluez.mesh.Node1.AddAppKey":                               "5.53",
+	"org.bluez.mesh.Node1.AddNetKey":                               "5.53",
+	"org.bluez.mesh.Node1.Addresses":                               "5.53",
+	"org.bluez.mesh.Node1.Beacon":                                  "5.53",
+	"org.bluez.mesh.Node1.DevKeySend":                              "5.53",
+	"org.bluez.mesh.Node1.Features":                                "5.53",
+	"org.bluez.mesh.Node1.Friend":                                  "5.53",
+	"org.bluez.mesh.Node1.IvIndex":                                 "5.53",
+	"org.bluez.mesh.Node1.IvUpdate":                                "5.55",
+	"org.bluez.mesh.Node1.LowPower":                                "5.53",
+	"org.bluez.mesh.Node1.Proxy":                                   "5.53",
+	"org.bluez.mesh.Node1.Publish":                                 "5.53",
+	"org.bluez.mesh.Node1.Relay":                                   "5.53",
+	"org.bluez.mesh.Node1.SecondsSinceLastHeard":                   "5.53",
+	"org.bluez.mesh.Node1.Send":                                    "5.53",
+	"org.bluez.mesh.Node1.SequenceNumber":                          "5.53",
+	"org.bluez.mesh.ProvisionAgent1":                               "5.53",
+	"org.bluez.mesh.ProvisionAgent1.Cancel":                        "5.53",
+	"org.bluez.mesh.ProvisionAgent1.Capabilities":                  "5.53",
+	"org.bluez.mesh.ProvisionAgent1.DisplayNumeric":                "5.53",
+	"org.bluez.mesh.ProvisionAgent1.DisplayString":                 "5.53",
+	"org.bluez.mesh.ProvisionAgent1.OutOfBandInfo":                 "5.53",
+	"org.bluez.mesh.ProvisionAgent1.PrivateKey":                    "5.53",
+	"org.bluez.mesh.ProvisionAgent1.PromptNumeric":                 "5.53",
+	"org.bluez.mesh.ProvisionAgent1.PromptStatic":                  "5.53",
+	"org.bluez.mesh.ProvisionAgent1.PublicKey":                     "5.53",
+	"org.bluez.mesh.ProvisionAgent1.URI":                           "5.53",
+	"org.bluez.mesh.Provisioner1":                                  "5.53",
+	"org.bluez.mesh.Provisioner1.AddNodeComplete":                  "5.53",
+	"org.bluez.mesh.Provisioner1.AddNodeFailed":                    "5.53",
+	"org.bluez.mesh.Provisioner1.RequestProvData":                  "5.53",
+	"org.bluez.mesh.Provisioner1.ScanResult":                       "5.53",
+	"org.bluez.obex.Agent1":                                        "5.50",
+	"org.bluez.obex.Agent1.AuthorizePush":                          "5.50",
+	"org.bluez.obex.Agent1.Cancel":                                 "5.50",
+	"org.bluez.obex.Agent1.Release":                                "5.50",
+	"org.bluez.obex.AgentManager1":                                 "5.50",
+	"org.bluez.obex.AgentManager1.RegisterAgent":                   "5.50",
+	"org.bluez.obex.AgentManager1.UnregisterAgent":                 "5.50",
+	"org.bluez.obex.Client1":                                       "5.50",
+	"org.bluez.obex.Client1.CreateSession":                         "5.50",
+	"org.bluez.obex.Client1.RemoveSession":                         "5.50",
+	"org.bluez.obex.FileTransfer":                                  "5.50",
+	"org.bluez.obex.FileTransfer.ChangeFolder":                     "5.50",
+	"org.bluez.obex.FileTransfer.CopyFile":                         "5.50",
+	"org.bluez.obex.FileTransfer.CreateFolder":                     "5.50",
+	"org.bluez.obex.FileTransfer.Delete":                           "5.50",
+	"org.bluez.obex.FileTransfer.GetFile":                          "5.50",
+	"org.bluez.obex.FileTransfer.ListFolder":                       "5.50",
+	"org.bluez.obex.FileTransfer.MoveFile":                         "5.50",
+	"org.bluez.obex.FileTransfer.PutFile":                          "5.50",
+	"org.bluez.obex.Message1":                                      "5.50",
+	"org.bluez.obex.Message1.Deleted":                              "5.50",
+	"org.bluez.obex.Message1.Folder":                               "5.50",
+	"org.bluez.obex.Message1.Get":                                  "5.50",
+	"org.bluez.obex.Message1.Priority":                             "5.50",
+	"org.bluez.obex.Message1.Protected":                            "5.50",
+	"org.bluez.obex.Message1.Read":                                 "5.50",
+	"org.bluez.obex.Message1.Recipient":                            "5.50",
+	"org.bluez.obex.Message1.RecipientAddress":                     "5.50",
+	"org.bluez.obex.Message1.ReplyTo":                              "5.50",
+	"org.bluez.obex.Message1.Sender":                               "5.50",
+	"org.bluez.obex.Message1.SenderAddress":                        "5.50",
+	"org.bluez.obex.Message1.Sent":                                 "5.50",
+	"org.bluez.obex.Message1.Status":                               "5.50",
+	"org.bluez.obex.Message1.Subject":                              "5.50",
+	"org.bluez.obex.Message1.Timestamp":                            "5.50",
+	"org.bluez.obex.Message1.Type":                                 "5.50",
+	"org.bluez.obex.MessageAccess1":                                "5.50",
+	"org.bluez.obex.MessageAccess1.ListFilterFields":               "5.50",
+	"org.bluez.obex.MessageAccess1.ListFolders":                    "5.50",
+	"org.bluez.obex.MessageAccess1.ListMessages":                   "5.50",
+	"org.bluez.obex.MessageAccess1.SetFolder":                      "5.50",
+	"org.bluez.obex.MessageAccess1.UpdateInbox":                    "5.50",
+	"org.bluez.obex.ObjectPush1":                                   "5.50",
+	"org.bluez.obex.ObjectPush1.ExchangeBusinessCards":             "5.50",
+	"org.bluez.obex.ObjectPush1.PullBusinessCard":                  "5.50",
+	"org.bluez.obex.ObjectPush1.SendFile":                          "5.50",
+	"org.bluez.obex.PhonebookAccess1":                              "5.50",
+	"org.bluez.obex.PhonebookAccess1.DatabaseIdentifier":           "5.50",
+	"org.bluez.obex.PhonebookAccess1.FixedImageSize":               "5.50",
+	"org.bluez.obex.PhonebookAccess1.Folder":                       "5.50",
+	"org.bluez.obex.PhonebookAccess1.GetSize":                      "5.50",
+	"org.bluez.obex.PhonebookAccess1.List":                         "5.50",
+	"org.bluez.obex.PhonebookAccess1.ListFilterFields":             "5.50",
+	"org.bluez.obex.PhonebookAccess1.PrimaryCounter":               "5.50",
+	"org.bluez.obex.PhonebookAccess1.Pull":                         "5.50",
+	"org.bluez.obex.PhonebookAccess1.PullAll":                      "5.50",
+	"org.bluez.obex.PhonebookAccess1.Search":                       "5.50",
+	"org.bluez.obex.PhonebookAccess1.SecondaryCounter":             "5.50",
+	"org.bluez.obex.PhonebookAccess1.Select":                       "5.50",
+	"org.bluez.obex.PhonebookAccess1.UpdateVersion":                "5.50",
+	"org.bluez.obex.Session1":                                      "5.50",
+	"org.bluez.obex.Session1.Channel":                              "5.50",
+	"org.bluez.obex.Session1.Destination":                          "5.50",
+	"org.bluez.obex.Session1.GetCapabilities":                      "5.50",
+	"org.bluez.obex.Session1.Root":                                 "5.50",
+	"org.bluez.obex.Session1.Source":                               "5.50",
+	"org.bluez.obex.Session1.Target":                               "5.50",
+	"org.bluez.obex.Synchronization1":                              "5.50",
+	"org.bluez.obex.Synchronization1.GetPhonebook":                 "5.50",
+	"org.bluez.obex.Synchronization1.PutPhonebook":                 "5.50",
+	"org.bluez.obex.Synchronization1.SetLocation":                  "5.50",
+	"org.bluez.obex.Transfer1":                                     "5.50",
+	"org.bluez.obex.Transfer1.Cancel":                              "5.50",
+	"org.bluez.obex.Transfer1.Filename":                            "5.50",
+	"org.bluez.obex.Transfer1.Name":                                "5.50",
+	"org.bluez.obex.Transfer1.Resume":                              "5.50",
+	"org.bluez.obex.Transfer1.Session":                             "5.50",
+	"org.bluez.obex.Transfer1.Status":                              "5.50",
+	"org.bluez.obex.Transfer1.Suspend":                             "5.50",
+	"org.bluez.obex.Transfer1.Type":                                "5.50",
+}
@@ -4,25 +4,23 @@ import (
 	"github.com/godbus/dbus/v5"
 )
 
-var objectManager *ObjectManager
-
-// GetObjectManager return a client instance of the Bluez object manager
+// GetObjectManager return a client instance of the Bluez object manager on the default system bus connection
 func GetObjectManager() (*ObjectManager, error) {
-	if objectManager != nil {
-		return objectManager, nil
-	}
-
-	om, err := NewObjectManager(OrgBluezInterface, "/")
+	conn, err := DefaultConn(SystemBus)
 	if err != nil {
 		return nil, err
 	}
-
-	objectManager = om
-	return om, nil
+	return conn.ObjectManager()
 }
 
 // NewObjectManager create a new ObjectManager client
 func NewObjectManager(name string, path string) (*ObjectManager, error) {
+	return NewObjectManagerWithConn(nil, name, path)
+}
+
+// NewObjectManagerWithConn create a new ObjectManager client using the given connection.
+// The default system bus connection is used if conn is nil
+func NewObjectManagerWithConn(conn *Conn, name string, path string) (*ObjectManager, error) {
 	om := new(ObjectManager)
 	om.client = NewClient(
 		&Config{
@@ -30,6 +28,7 @@ func NewObjectManager(name string, path string) (*ObjectManager, error) {
 			Iface: "org.freedesktop.DBus.ObjectManager",
 			Path:  dbus.ObjectPath(path),
 			Bus:   SystemBus,
+			Conn:  conn,
 		},
 	)
 	return om, nil
@@ -40,6 +39,11 @@ type ObjectManager struct {
 	client *Client
 }
 
+// Client return the ObjectManager dbus client
+func (o *ObjectManager) Client() *Client {
+	return o.client
+}
+
 // Close the connection
 func (o *ObjectManager) Close() {
 	o.client.Disconnect()
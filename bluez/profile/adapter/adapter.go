@@ -3,21 +3,30 @@ package adapter
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/godbus/dbus/v5"
 	"github.com/muka/go-bluetooth/bluez"
 	"github.com/muka/go-bluetooth/bluez/profile/device"
 )
 
-var defaultAdapterID = "hci0"
+var (
+	defaultAdapterID     = "hci0"
+	defaultAdapterIDLock sync.RWMutex
+)
 
-// SetDefaultAdapterID set the default adapter
+// SetDefaultAdapterID set the default adapter. It is safe to call while
+// other goroutines use the default adapter, eg. on AdapterWatcher events
 func SetDefaultAdapterID(a string) {
+	defaultAdapterIDLock.Lock()
+	defer defaultAdapterIDLock.Unlock()
 	defaultAdapterID = a
 }
 
 // GetDefaultAdapterID get the default adapter
 func GetDefaultAdapterID() string {
+	defaultAdapterIDLock.RLock()
+	defer defaultAdapterIDLock.RUnlock()
 	return defaultAdapterID
 }
 
@@ -42,8 +51,21 @@ func ParseAdapterID(path dbus.ObjectPath) (string, error) {
 
 // AdapterExists checks if an adapter is available
 func AdapterExists(adapterID string) (bool, error) {
+	return AdapterExistsWithConn(nil, adapterID)
+}
+
+// AdapterExistsWithConn checks if an adapter is available using the given connection
+func AdapterExistsWithConn(conn *bluez.Conn, adapterID string) (bool, error) {
 
-	om, err := bluez.GetObjectManager()
+	if conn == nil {
+		c, err := bluez.DefaultConn(bluez.SystemBus)
+		if err != nil {
+			return false, err
+		}
+		conn = c
+	}
+
+	om, err := conn.ObjectManager()
 	if err != nil {
 		return false, err
 	}
@@ -65,15 +87,20 @@ func GetDefaultAdapter() (*Adapter1, error) {
 
 // GetAdapter return an adapter object instance
 func GetAdapter(adapterID string) (*Adapter1, error) {
+	return GetAdapterWithConn(nil, adapterID)
+}
+
+// GetAdapterWithConn return an adapter object instance using the given connection
+func GetAdapterWithConn(conn *bluez.Conn, adapterID string) (*Adapter1, error) {
 
-	if exists, err := AdapterExists(adapterID); !exists {
+	if exists, err := AdapterExistsWithConn(conn, adapterID); !exists {
 		if err != nil {
 			return nil, fmt.Errorf("AdapterExists: %s", err)
 		}
 		return nil, fmt.Errorf("Adapter %s not found", adapterID)
 	}
 
-	return NewAdapter1FromAdapterID(adapterID)
+	return NewAdapter1FromAdapterIDWithConn(conn, adapterID)
 }
 
 // GetAdapterFromDevicePath Return an adapter based on a device path
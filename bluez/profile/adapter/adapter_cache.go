@@ -0,0 +1,412 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/profile/device"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultCacheInterval is the default delay between DeviceCache eviction runs
+const DefaultCacheInterval = 10 * time.Second
+
+// ErrCacheClosed is returned by Evict once the DeviceCache is closed
+var ErrCacheClosed = errors.New("device cache closed")
+
+// seenProperties are the Device1 properties updated when a device advertises
+var seenProperties = map[string]bool{
+	"RSSI":             true,
+	"TxPower":          true,
+	"ManufacturerData": true,
+	"ServiceData":      true,
+}
+
+// keptProperties protect a device from eviction when true
+var keptProperties = []string{"Paired", "Bonded", "Trusted", "Connected"}
+
+// EvictionReason tells why a device has been evicted
+type EvictionReason uint8
+
+const (
+	// EvictedStale the device has not been seen for CacheOptions.MaxAge
+	EvictedStale EvictionReason = iota
+	// EvictedOverflow the cache exceeded CacheOptions.MaxDevices
+	EvictedOverflow
+)
+
+func (r EvictionReason) String() string {
+	switch r {
+	case EvictedStale:
+		return "stale"
+	case EvictedOverflow:
+		return "overflow"
+	}
+	return "unknown"
+}
+
+// DeviceEvicted is emitted when DeviceCache removes a device
+type DeviceEvicted struct {
+	Path     dbus.ObjectPath
+	Address  string
+	Reason   EvictionReason
+	LastSeen time.Time
+}
+
+// CacheOptions configure a DeviceCache
+type CacheOptions struct {
+	// MaxAge evict the devices not seen for the duration, 0 to disable
+	MaxAge time.Duration
+	// MaxDevices cap the cached devices, evicting the least recently seen first, 0 to disable.
+	// Paired, bonded, trusted and connected devices are counted but never evicted
+	MaxDevices int
+	// Interval between eviction runs, default to DefaultCacheInterval
+	Interval time.Duration
+	// Keep protect additional devices from eviction when returning true
+	Keep func(path dbus.ObjectPath, address string) bool
+}
+
+// cachedDevice is the state of a device tracked by DeviceCache
+type cachedDevice struct {
+	path     dbus.ObjectPath
+	address  string
+	lastSeen time.Time
+	props    map[string]bool
+}
+
+func (d *cachedDevice) kept() bool {
+	for _, name := range keptProperties {
+		if d.props[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// DeviceCache evicts the stale devices of an adapter from the bluez cache,
+// unlike FlushDevices keeping the paired and trusted ones
+type DeviceCache struct {
+	adapter *Adapter1
+	options CacheOptions
+	conn    *bluez.Conn
+	om      *bluez.ObjectManager
+	prefix  string
+	match   string
+
+	signals chan *dbus.Signal
+	events  chan DeviceEvicted
+	done    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+
+	lock    sync.Mutex
+	devices map[dbus.ObjectPath]*cachedDevice
+	// evictLock serialize the eviction runs, and Close with them
+	evictLock sync.Mutex
+}
+
+// NewDeviceCache start managing the devices cache of an adapter. Devices already
+// cached are considered seen when the DeviceCache is created
+func NewDeviceCache(a *Adapter1, options CacheOptions) (*DeviceCache, error) {
+
+	if options.Interval <= 0 {
+		options.Interval = DefaultCacheInterval
+	}
+
+	conn, err := a.client.Conn()
+	if err != nil {
+		return nil, err
+	}
+	om, err := conn.ObjectManager()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &DeviceCache{
+		adapter: a,
+		options: options,
+		conn:    conn,
+		om:      om,
+		prefix:  string(a.Path()) + "/",
+		match: fmt.Sprintf(
+			"type='signal',interface='%s',member='PropertiesChanged',arg0='%s',path_namespace='%s'",
+			bluez.PropertiesInterface, device.Device1Interface, a.Path(),
+		),
+		events:  make(chan DeviceEvicted, 32),
+		done:    make(chan struct{}),
+		devices: map[dbus.ObjectPath]*cachedDevice{},
+	}
+
+	err = conn.DBus().BusObject().Call("org.freedesktop.DBus.AddMatch", 0, c.match).Err
+	if err != nil {
+		return nil, fmt.Errorf("AddMatch: %s", err)
+	}
+	c.signals, err = om.Register()
+	if err != nil {
+		c.release()
+		return nil, err
+	}
+
+	objects, err := om.GetManagedObjects()
+	if err != nil {
+		c.release()
+		return nil, fmt.Errorf("GetManagedObjects: %s", err)
+	}
+	now := time.Now()
+	for path, ifaces := range objects {
+		if props, ok := ifaces[device.Device1Interface]; ok && c.owns(path) {
+			c.add(path, props, now)
+		}
+	}
+
+	c.wg.Add(1)
+	go c.run()
+
+	return c, nil
+}
+
+// Events return the evicted devices. Events are dropped if the channel is not consumed
+func (c *DeviceCache) Events() <-chan DeviceEvicted {
+	return c.events
+}
+
+// Len return the number of cached devices
+func (c *DeviceCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.devices)
+}
+
+// LastSeen return when a device has been seen last
+func (c *DeviceCache) LastSeen(path dbus.ObjectPath) (time.Time, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	d, ok := c.devices[path]
+	if !ok {
+		return time.Time{}, false
+	}
+	return d.lastSeen, true
+}
+
+// Close stop evicting devices and close the Events channel
+func (c *DeviceCache) Close() {
+	c.once.Do(func() {
+		close(c.done)
+		c.wg.Wait()
+		c.release()
+		// a running Evict may still send events
+		c.evictLock.Lock()
+		close(c.events)
+		c.evictLock.Unlock()
+	})
+}
+
+// release remove the signal registrations
+func (c *DeviceCache) release() {
+	c.conn.DBus().BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, c.match)
+	if c.signals != nil {
+		c.om.Unregister(c.signals)
+	}
+}
+
+// owns report if path is a device of the adapter
+func (c *DeviceCache) owns(path dbus.ObjectPath) bool {
+	return strings.HasPrefix(string(path), c.prefix)
+}
+
+// add track a device
+func (c *DeviceCache) add(path dbus.ObjectPath, props map[string]dbus.Variant, now time.Time) {
+	d := &cachedDevice{
+		path:     path,
+		lastSeen: now,
+		props:    map[string]bool{},
+	}
+	d.address, _ = props["Address"].Value().(string)
+	for _, name := range keptProperties {
+		d.props[name], _ = props[name].Value().(bool)
+	}
+
+	c.lock.Lock()
+	c.devices[path] = d
+	c.lock.Unlock()
+}
+
+// run process the signals and evict devices until closed
+func (c *DeviceCache) run() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case sig, ok := <-c.signals:
+			if !ok {
+				return
+			}
+			if sig != nil {
+				c.handle(sig)
+			}
+		case <-ticker.C:
+			_, err := c.Evict()
+			if err != nil && err != ErrCacheClosed {
+				log.Warnf("DeviceCache: %s", err)
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// handle update the devices state from a signal
+func (c *DeviceCache) handle(sig *dbus.Signal) {
+
+	switch sig.Name {
+	case bluez.InterfacesAdded:
+		if len(sig.Body) < 2 {
+			return
+		}
+		path, ok := sig.Body[0].(dbus.ObjectPath)
+		if !ok || !c.owns(path) {
+			return
+		}
+		ifaces, ok := sig.Body[1].(map[string]map[string]dbus.Variant)
+		if !ok {
+			return
+		}
+		if props, ok := ifaces[device.Device1Interface]; ok {
+			c.add(path, props, time.Now())
+		}
+
+	case bluez.InterfacesRemoved:
+		if len(sig.Body) < 2 {
+			return
+		}
+		path, ok := sig.Body[0].(dbus.ObjectPath)
+		if !ok {
+			return
+		}
+		ifaces, ok := sig.Body[1].([]string)
+		if !ok {
+			return
+		}
+		for _, iface := range ifaces {
+			if iface == device.Device1Interface {
+				c.lock.Lock()
+				delete(c.devices, path)
+				c.lock.Unlock()
+			}
+		}
+
+	case bluez.PropertiesChanged:
+		if !c.owns(sig.Path) || len(sig.Body) < 2 {
+			return
+		}
+		if iface, ok := sig.Body[0].(string); !ok || iface != device.Device1Interface {
+			return
+		}
+		changes, ok := sig.Body[1].(map[string]dbus.Variant)
+		if !ok {
+			return
+		}
+
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		d, ok := c.devices[sig.Path]
+		if !ok {
+			return
+		}
+		for name, value := range changes {
+			if seenProperties[name] {
+				d.lastSeen = time.Now()
+			}
+			if _, ok := d.props[name]; ok {
+				d.props[name], _ = value.Value().(bool)
+			}
+		}
+	}
+}
+
+// Evict remove the stale devices and the oldest ones exceeding MaxDevices now,
+// returning the evicted devices. Evictions run periodically without calling Evict.
+// ErrCacheClosed is returned after Close
+func (c *DeviceCache) Evict() ([]DeviceEvicted, error) {
+
+	c.evictLock.Lock()
+	defer c.evictLock.Unlock()
+
+	select {
+	case <-c.done:
+		return nil, ErrCacheClosed
+	default:
+	}
+
+	now := time.Now()
+
+	c.lock.Lock()
+	candidates := []cachedDevice{}
+	for _, d := range c.devices {
+		if d.kept() {
+			continue
+		}
+		if c.options.Keep != nil && c.options.Keep(d.path, d.address) {
+			continue
+		}
+		candidates = append(candidates, *d)
+	}
+	total := len(c.devices)
+	c.lock.Unlock()
+
+	// least recently seen first
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].lastSeen.Before(candidates[j].lastSeen)
+	})
+
+	evictions := []DeviceEvicted{}
+	for _, d := range candidates {
+		ev := DeviceEvicted{
+			Path:     d.path,
+			Address:  d.address,
+			LastSeen: d.lastSeen,
+		}
+		switch {
+		case c.options.MaxAge > 0 && now.Sub(d.lastSeen) >= c.options.MaxAge:
+			ev.Reason = EvictedStale
+		case c.options.MaxDevices > 0 && total-len(evictions) > c.options.MaxDevices:
+			ev.Reason = EvictedOverflow
+		default:
+			continue
+		}
+		evictions = append(evictions, ev)
+	}
+
+	evicted := []DeviceEvicted{}
+	var lastErr error
+	for _, ev := range evictions {
+		err := c.adapter.RemoveDevice(ev.Path)
+		if err != nil && !errors.Is(err, bluez.ErrDoesNotExist) {
+			lastErr = fmt.Errorf("RemoveDevice %s: %s", ev.Path, err)
+			continue
+		}
+
+		c.lock.Lock()
+		delete(c.devices, ev.Path)
+		c.lock.Unlock()
+
+		log.Debugf("DeviceCache: evicted %s (%s)", ev.Address, ev.Reason)
+		evicted = append(evicted, ev)
+		select {
+		case c.events <- ev:
+		default:
+			log.Warnf("DeviceCache: events queue full, dropping eviction of %s", ev.Address)
+		}
+	}
+
+	return evicted, lastErr
+}
@@ -0,0 +1,166 @@
+package adapter
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/muka/go-bluetooth/bluez/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+func startFakeAdapter(t *testing.T) (*fake.Bluez, *fake.Adapter, *Adapter1) {
+	b, fa := fake.StartAdapterT(t, "hci0", map[string]interface{}{"Powered": true})
+	conn, err := b.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := NewAdapter1FromAdapterIDWithConn(conn, "hci0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, fa, a
+}
+
+func TestDeviceCacheMaxAge(t *testing.T) {
+	_, fa, a := startFakeAdapter(t)
+
+	stale, err := fa.AddDevice("11:22:33:44:55:01", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paired, err := fa.AddDevice("11:22:33:44:55:02", map[string]interface{}{"Paired": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewDeviceCache(a, CacheOptions{
+		MaxAge:   300 * time.Millisecond,
+		Interval: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	seen, err := fa.AddDevice("11:22:33:44:55:03", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// keep advertising
+	done := make(chan bool)
+	defer close(done)
+	go func() {
+		rssi := int16(-50)
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(50 * time.Millisecond):
+				rssi--
+				seen.Set("RSSI", rssi)
+			}
+		}
+	}()
+
+	select {
+	case ev := <-c.Events():
+		assert.Equal(t, stale.Path(), ev.Path)
+		assert.Equal(t, "11:22:33:44:55:01", ev.Address)
+		assert.Equal(t, EvictedStale, ev.Reason)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Device not evicted")
+	}
+	assert.Nil(t, fa.Device("11:22:33:44:55:01"))
+
+	time.Sleep(300 * time.Millisecond)
+	assert.NotNil(t, fa.Device("11:22:33:44:55:02"))
+	assert.NotNil(t, fa.Device("11:22:33:44:55:03"))
+	_, ok := c.LastSeen(paired.Path())
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestDeviceCacheMaxDevices(t *testing.T) {
+	_, fa, a := startFakeAdapter(t)
+
+	c, err := NewDeviceCache(a, CacheOptions{
+		MaxDevices: 2,
+		Interval:   time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	for _, address := range []string{"11:22:33:44:55:01", "11:22:33:44:55:02", "11:22:33:44:55:03"} {
+		_, err := fa.AddDevice(address, map[string]interface{}{"Trusted": address == "11:22:33:44:55:01"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	for i := 0; c.Len() < 3 && i < 100; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	evicted, err := c.Evict()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the trusted device is the oldest, but is kept
+	assert.Len(t, evicted, 1)
+	assert.Equal(t, "11:22:33:44:55:02", evicted[0].Address)
+	assert.Equal(t, EvictedOverflow, evicted[0].Reason)
+	assert.NotNil(t, fa.Device("11:22:33:44:55:01"))
+	assert.Nil(t, fa.Device("11:22:33:44:55:02"))
+
+	ev := <-c.Events()
+	assert.Equal(t, evicted[0], ev)
+
+	c.Close()
+	_, ok := <-c.Events()
+	assert.False(t, ok)
+}
+
+func TestDeviceCacheEvictClose(t *testing.T) {
+	_, fa, a := startFakeAdapter(t)
+
+	c, err := NewDeviceCache(a, CacheOptions{
+		MaxAge:   time.Millisecond,
+		Interval: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		_, err := fa.AddDevice(fmt.Sprintf("11:22:33:44:55:%02d", i), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; c.Len() < 10 && i < 100; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// evictions running while closing must not send on the closed Events channel
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Evict()
+			if err != nil {
+				assert.Equal(t, ErrCacheClosed, err)
+			}
+		}()
+	}
+	c.Close()
+	wg.Wait()
+
+	_, err = c.Evict()
+	assert.Equal(t, ErrCacheClosed, err)
+}
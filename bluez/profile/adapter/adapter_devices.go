@@ -20,7 +20,7 @@ func (a *Adapter1) GetDeviceByAddress(address string) (*device.Device1, error) {
 
 	for _, path := range list {
 
-		dev, err := device.NewDevice1(path)
+		dev, err := device.NewDevice1WithConn(a.client.Config.Conn, path)
 		if err != nil {
 			return nil, err
 		}
@@ -41,7 +41,7 @@ func (a *Adapter1) GetDevices() ([]*device.Device1, error) {
 		return nil, err
 	}
 
-	om, err := bluez.GetObjectManager()
+	om, err := a.client.ObjectManager()
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +61,7 @@ func (a *Adapter1) GetDevices() ([]*device.Device1, error) {
 		}
 
 		props := object[device.Device1Interface]
-		dev, err := parseDevice(path, props)
+		dev, err := parseDevice(a.client.Config.Conn, path, props)
 		if err != nil {
 			return nil, err
 		}
@@ -75,7 +75,7 @@ func (a *Adapter1) GetDevices() ([]*device.Device1, error) {
 // GetDeviceList returns a list of cached device paths
 func (a *Adapter1) GetDeviceList() ([]dbus.ObjectPath, error) {
 
-	om, err := bluez.GetObjectManager()
+	om, err := a.client.ObjectManager()
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +102,8 @@ func (a *Adapter1) GetDeviceList() ([]dbus.ObjectPath, error) {
 	return devices, nil
 }
 
-// FlushDevices removes device from bluez cache
+// FlushDevices removes all the devices from bluez cache, including paired ones.
+// Use DeviceCache to evict only the stale devices
 func (a *Adapter1) FlushDevices() error {
 
 	devices, err := a.GetDevices()
@@ -121,9 +122,9 @@ func (a *Adapter1) FlushDevices() error {
 }
 
 // ParseDevice parse a Device from a ObjectManager map
-func parseDevice(path dbus.ObjectPath, propsMap map[string]dbus.Variant) (*device.Device1, error) {
+func parseDevice(conn *bluez.Conn, path dbus.ObjectPath, propsMap map[string]dbus.Variant) (*device.Device1, error) {
 
-	dev, err := device.NewDevice1(path)
+	dev, err := device.NewDevice1WithConn(conn, path)
 	if err != nil {
 		return nil, err
 	}
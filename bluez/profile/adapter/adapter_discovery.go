@@ -1,6 +1,9 @@
 package adapter
 
 import (
+	"context"
+	"strings"
+
 	"github.com/godbus/dbus/v5"
 	"github.com/muka/go-bluetooth/bluez"
 	"github.com/muka/go-bluetooth/bluez/profile/device"
@@ -22,75 +25,102 @@ type DeviceDiscovered struct {
 	Type DeviceActions
 }
 
-// OnDeviceDiscovered monitor for new devices and send updates via channel. Use cancel to close the monitoring process
+// OnDeviceDiscovered monitor for new devices and send updates via channel. Use cancel to close the monitoring process.
+// The channel is closed and the monitoring goroutine exited once cancel returns
 func (a *Adapter1) OnDeviceDiscovered() (chan *DeviceDiscovered, func(), error) {
 
-	signal, omSignalCancel, err := a.GetObjectManagerSignal()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, done, err := a.watchDevices(ctx, bluez.SubscribeOptions{})
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	return ch, func() {
+		cancel()
+		<-done
+		log.Trace("OnDeviceDiscovered: cancel() called")
+	}, nil
+}
+
+// OnDeviceDiscoveredContext monitor for new and removed devices of the adapter until ctx is done,
+// then close the channel. options configure the buffering and what to do when the consumer is slow
+func (a *Adapter1) OnDeviceDiscoveredContext(ctx context.Context, options bluez.SubscribeOptions) (<-chan *DeviceDiscovered, error) {
+	ch, _, err := a.watchDevices(ctx, options)
+	return ch, err
+}
+
+// watchDevices forward the devices ObjectManager signals to a channel until ctx is done.
+// done is closed after the channel, when the goroutine exits
+func (a *Adapter1) watchDevices(ctx context.Context, options bluez.SubscribeOptions) (chan *DeviceDiscovered, chan struct{}, error) {
+
+	conn, err := a.client.Conn()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub, err := conn.Subscribe(ctx, bluez.SignalMatch{
+		Path:      "/",
+		Interface: bluez.ObjectManagerInterface,
+	}, options)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var (
-		ch = make(chan *DeviceDiscovered)
-	)
+	ch := make(chan *DeviceDiscovered)
+	done := make(chan struct{})
+	prefix := string(a.Path()) + "/"
 
 	go func() {
-		for v := range signal {
+		defer close(done)
+		defer close(ch)
+		// the subscription ends with ctx, make sure it is released on any exit
+		defer sub.Cancel()
 
-			if v == nil {
-				return
+		for v := range sub.C() {
+
+			if len(v.Body) < 2 {
+				continue
+			}
+			path, ok := v.Body[0].(dbus.ObjectPath)
+			if !ok || !strings.HasPrefix(string(path), prefix) {
+				continue
 			}
 
-			var op DeviceActions
-			if v.Name == bluez.InterfacesAdded {
-				op = DeviceAdded
-			} else {
-				if v.Name == bluez.InterfacesRemoved {
-					op = DeviceRemoved
-				} else {
+			var ev *DeviceDiscovered
+			switch v.Name {
+			case bluez.InterfacesAdded:
+				ifaces, ok := v.Body[1].(map[string]map[string]dbus.Variant)
+				if !ok {
+					continue
+				}
+				if p, ok := ifaces[device.Device1Interface]; ok && p != nil {
+					log.Tracef("Added device %s", path)
+					ev = &DeviceDiscovered{path, DeviceAdded}
+				}
+			case bluez.InterfacesRemoved:
+				ifaces, ok := v.Body[1].([]string)
+				if !ok {
 					continue
 				}
-			}
-
-			path := v.Body[0].(dbus.ObjectPath)
-
-			if op == DeviceRemoved {
-				ifaces := v.Body[1].([]string)
 				for _, iface := range ifaces {
 					if iface == device.Device1Interface {
 						log.Tracef("Removed device %s", path)
-						ch <- &DeviceDiscovered{path, op}
+						ev = &DeviceDiscovered{path, DeviceRemoved}
 					}
 				}
+			}
+			if ev == nil {
 				continue
 			}
 
-			ifaces := v.Body[1].(map[string]map[string]dbus.Variant)
-			if p, ok := ifaces[device.Device1Interface]; ok {
-				if p == nil {
-					continue
-				}
-				log.Tracef("Added device %s", path)
-
-				if ch == nil {
-					return
-				}
-
-				ch <- &DeviceDiscovered{path, op}
-
+			select {
+			case ch <- ev:
+			case <-ctx.Done():
+				return
 			}
-
 		}
 	}()
 
-	cancel := func() {
-		omSignalCancel()
-		if ch != nil {
-			close(ch)
-		}
-		ch = nil
-		log.Trace("OnDeviceDiscovered: cancel() called")
-	}
-
-	return ch, cancel, nil
+	return ch, done, nil
 }
@@ -1,10 +1,16 @@
 package adapter
 
 import (
+	"context"
 	"fmt"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/fake"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestDiscovery(t *testing.T) {
@@ -59,3 +65,119 @@ func TestDiscovery(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestOnDeviceDiscoveredCancel(t *testing.T) {
+	_, fa, a := startFakeAdapter(t)
+
+	// warm up the shared connection
+	_, err := a.GetPowered()
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := runtime.NumGoroutine()
+
+	for i := 0; i < 50; i++ {
+		ch, cancel, err := a.OnDeviceDiscovered()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		address := fmt.Sprintf("11:22:33:44:55:%02X", i)
+		_, err = fa.AddDevice(address, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i%2 == 0 {
+			ev := <-ch
+			assert.Equal(t, DeviceAdded, ev.Type)
+		}
+
+		// the goroutine may be sending the event while cancelling
+		cancel()
+		_, ok := <-ch
+		for ok {
+			_, ok = <-ch
+		}
+	}
+
+	fake.WaitGoroutines(t, start)
+}
+
+func TestOnDeviceDiscoveredContext(t *testing.T) {
+	b, fa, a := startFakeAdapter(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := a.OnDeviceDiscoveredContext(ctx, bluez.SubscribeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fd, err := fa.AddDevice("11:22:33:44:55:66", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := <-ch
+	assert.Equal(t, DeviceAdded, ev.Type)
+	assert.Equal(t, fd.Path(), ev.Path)
+
+	// devices of other adapters are ignored
+	fa2, err := b.AddAdapter("hci1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fa2.AddDevice("11:22:33:44:55:77", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if derr := fa.RemoveDevice(fd.Path()); derr != nil {
+		t.Fatal(derr)
+	}
+	ev = <-ch
+	assert.Equal(t, DeviceRemoved, ev.Type)
+	assert.Equal(t, fd.Path(), ev.Path)
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		for ok {
+			_, ok = <-ch
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Channel not closed")
+	}
+}
+
+func TestOnDeviceDiscoveredDrop(t *testing.T) {
+	_, fa, a := startFakeAdapter(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := a.OnDeviceDiscoveredContext(ctx, bluez.SubscribeOptions{
+		BufferSize: 1,
+		Overflow:   bluez.OverflowDrop,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// with a slow consumer the events exceeding the buffers are dropped
+	for i := 0; i < 10; i++ {
+		_, err = fa.AddDevice(fmt.Sprintf("11:22:33:44:56:%02X", i), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	received := 0
+	for received < 10 {
+		select {
+		case <-ch:
+			received++
+			continue
+		case <-time.After(100 * time.Millisecond):
+		}
+		break
+	}
+	assert.True(t, received > 0 && received < 10, "received %d events", received)
+}
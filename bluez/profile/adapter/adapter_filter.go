@@ -1,6 +1,7 @@
 package adapter
 
 import (
+	"github.com/muka/go-bluetooth/bluez"
 	"github.com/muka/go-bluetooth/util"
 )
 
@@ -24,7 +25,7 @@ type DiscoveryFilter struct {
 	// 	Pathloss param.
 	// - only RSSI param is set, and received RSSI is
 	// 	higher than RSSI param.
-	UUIDs []string
+	UUIDs []bluez.UUID
 
 	// RSSI threshold value.
 	//
@@ -68,17 +69,17 @@ type DiscoveryFilter struct {
 	DuplicateData bool
 }
 
-func (a *DiscoveryFilter) uuidExists(uuid string) bool {
+func (a *DiscoveryFilter) uuidExists(uuid bluez.UUID) bool {
 	for _, uiid1 := range a.UUIDs {
-		if uiid1 == uuid {
+		if uiid1.Equal(uuid) {
 			return true
 		}
 	}
 	return false
 }
 
-// Add an UUID to filter if it does not exists
-func (a *DiscoveryFilter) AddUUIDs(uuids ...string) {
+// Add an UUID to filter if it does not exists, in any form
+func (a *DiscoveryFilter) AddUUIDs(uuids ...bluez.UUID) {
 	for _, uuid := range uuids {
 		if !a.uuidExists(uuid) {
 			a.UUIDs = append(a.UUIDs, uuid)
@@ -94,6 +95,12 @@ func (a *DiscoveryFilter) ToMap() map[string]interface{} {
 
 	if len(a.UUIDs) == 0 {
 		delete(m, "UUIDs")
+	} else {
+		uuids := make([]string, len(a.UUIDs))
+		for i, uuid := range a.UUIDs {
+			uuids[i] = uuid.String()
+		}
+		m["UUIDs"] = uuids
 	}
 	if a.RSSI == 0 {
 		delete(m, "RSSI")
@@ -9,7 +9,7 @@ import (
 func TestDiscoveryFilter(t *testing.T) {
 
 	f := NewDiscoveryFilter()
-	f.AddUUIDs("AAAA", "BBBB")
+	f.AddUUIDs("AAAA", "BBBB", "0000aaaa-0000-1000-8000-00805f9b34fb")
 	f.RSSI = 10
 	f.Pathloss = 1
 	f.DuplicateData = false
@@ -17,7 +17,11 @@ func TestDiscoveryFilter(t *testing.T) {
 
 	m := f.ToMap()
 
-	assert.EqualValues(t, m["UUIDs"].([]string), f.UUIDs)
+	assert.Len(t, f.UUIDs, 2)
+	assert.EqualValues(t, []string{
+		"0000aaaa-0000-1000-8000-00805f9b34fb",
+		"0000bbbb-0000-1000-8000-00805f9b34fb",
+	}, m["UUIDs"].([]string))
 	assert.EqualValues(t, m["RSSI"].(int16), f.RSSI)
 	assert.EqualValues(t, m["Pathloss"].(uint16), f.Pathloss)
 	assert.EqualValues(t, m["DuplicateData"].(bool), f.DuplicateData)
@@ -0,0 +1,451 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	log "github.com/sirupsen/logrus"
+)
+
+// AdapterEventType is the type of an AdapterEvent
+type AdapterEventType uint8
+
+const (
+	// AdapterAdded an adapter has been plugged in or the daemon started
+	AdapterAdded AdapterEventType = iota
+	// AdapterRemoved an adapter has been unplugged or the daemon stopped
+	AdapterRemoved
+	// AdapterPowerChanged the adapter Powered property changed
+	AdapterPowerChanged
+)
+
+func (t AdapterEventType) String() string {
+	switch t {
+	case AdapterAdded:
+		return "added"
+	case AdapterRemoved:
+		return "removed"
+	case AdapterPowerChanged:
+		return "power-changed"
+	}
+	return "unknown"
+}
+
+// AdapterEvent is emitted by AdapterWatcher when an adapter changes
+type AdapterEvent struct {
+	Type AdapterEventType
+	// Adapter the adapter state after the change, or the last known state when removed
+	Adapter AdapterInfo
+}
+
+// AdapterInfo is a snapshot of an adapter state and capabilities
+type AdapterInfo struct {
+	// ID the adapter ID, eg. hci0
+	ID         string
+	Path       dbus.ObjectPath
+	Properties *Adapter1Properties
+	// Interfaces exposed by the adapter, eg. org.bluez.LEAdvertisingManager1
+	Interfaces []string
+}
+
+// Powered report if the adapter is powered
+func (i AdapterInfo) Powered() bool {
+	return i.Properties != nil && i.Properties.Powered
+}
+
+// HasRole report if the adapter supports a role, eg. central or peripheral
+func (i AdapterInfo) HasRole(role string) bool {
+	if i.Properties == nil {
+		return false
+	}
+	for _, r := range i.Properties.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Supports report if the adapter exposes iface
+func (i AdapterInfo) Supports(iface string) bool {
+	for _, v := range i.Interfaces {
+		if v == iface {
+			return true
+		}
+	}
+	return false
+}
+
+// watchedAdapter is the raw state of an adapter
+type watchedAdapter struct {
+	id     string
+	path   dbus.ObjectPath
+	ifaces map[string]map[string]dbus.Variant
+}
+
+func (a *watchedAdapter) info() AdapterInfo {
+	info := AdapterInfo{
+		ID:         a.id,
+		Path:       a.path,
+		Interfaces: []string{},
+	}
+	for iface := range a.ifaces {
+		info.Interfaces = append(info.Interfaces, iface)
+	}
+	sort.Strings(info.Interfaces)
+
+	props, err := new(Adapter1Properties).FromDBusMap(a.ifaces[Adapter1Interface])
+	if err != nil {
+		log.Warnf("AdapterWatcher: %s properties: %s", a.id, err)
+	}
+	info.Properties = props
+	return info
+}
+
+// AdapterWatcher tracks the available adapters, emitting events when they are
+// added, removed or powered on and off
+type AdapterWatcher struct {
+	conn    *bluez.Conn
+	om      *bluez.ObjectManager
+	signals chan *dbus.Signal
+	events  chan AdapterEvent
+	done    chan struct{}
+	wg      sync.WaitGroup
+
+	lock      sync.Mutex
+	adapters  map[dbus.ObjectPath]*watchedAdapter
+	closeOnce sync.Once
+}
+
+// NewAdapterWatcher start watching the adapters on the system bus
+func NewAdapterWatcher() (*AdapterWatcher, error) {
+	return NewAdapterWatcherWithConn(nil)
+}
+
+// NewAdapterWatcherWithConn start watching the adapters using the given connection.
+// The adapters already available are listed by Adapters, events report the following changes
+func NewAdapterWatcherWithConn(conn *bluez.Conn) (*AdapterWatcher, error) {
+
+	if conn == nil {
+		c, err := bluez.DefaultConn(bluez.SystemBus)
+		if err != nil {
+			return nil, err
+		}
+		conn = c
+	}
+
+	om, err := conn.ObjectManager()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &AdapterWatcher{
+		conn:     conn,
+		om:       om,
+		events:   make(chan AdapterEvent, 16),
+		done:     make(chan struct{}),
+		adapters: map[dbus.ObjectPath]*watchedAdapter{},
+	}
+
+	// register before listing the objects, not to miss changes in between
+	signals, err := om.Register()
+	if err != nil {
+		return nil, fmt.Errorf("ObjectManager.Register: %s", err)
+	}
+	w.signals = signals
+
+	objects, err := om.GetManagedObjects()
+	if err != nil {
+		om.Unregister(signals)
+		return nil, fmt.Errorf("GetManagedObjects: %s", err)
+	}
+	for path, ifaces := range objects {
+		if _, ok := ifaces[Adapter1Interface]; !ok {
+			continue
+		}
+		a, err := w.add(path, ifaces)
+		if err != nil {
+			log.Warnf("AdapterWatcher: %s", err)
+			continue
+		}
+		log.Tracef("AdapterWatcher: found %s", a.id)
+	}
+
+	w.wg.Add(1)
+	go w.watch()
+
+	return w, nil
+}
+
+// Events return the adapter changes. Events must be consumed
+// for the watcher to keep processing changes
+func (w *AdapterWatcher) Events() <-chan AdapterEvent {
+	return w.events
+}
+
+// Adapters return the available adapters, sorted by ID
+func (w *AdapterWatcher) Adapters() []AdapterInfo {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	list := []AdapterInfo{}
+	for _, a := range w.adapters {
+		list = append(list, a.info())
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// Adapter return an adapter by ID
+func (w *AdapterWatcher) Adapter(adapterID string) (AdapterInfo, bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	a, ok := w.adapters[adapterPath(adapterID)]
+	if !ok {
+		return AdapterInfo{}, false
+	}
+	return a.info(), true
+}
+
+// Default return the default adapter if available, otherwise the first
+// powered adapter, otherwise the first adapter
+func (w *AdapterWatcher) Default() (AdapterInfo, bool) {
+	if a, ok := w.Adapter(GetDefaultAdapterID()); ok {
+		return a, true
+	}
+	adapters := w.Adapters()
+	for _, a := range adapters {
+		if a.Powered() {
+			return a, true
+		}
+	}
+	if len(adapters) > 0 {
+		return adapters[0], true
+	}
+	return AdapterInfo{}, false
+}
+
+// Close stop watching and close the Events channel
+func (w *AdapterWatcher) Close() {
+	w.closeOnce.Do(func() {
+		close(w.done)
+		w.om.Unregister(w.signals)
+		w.wg.Wait()
+
+		w.lock.Lock()
+		for path := range w.adapters {
+			w.conn.DBus().BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, propertiesMatch(path))
+		}
+		w.adapters = map[dbus.ObjectPath]*watchedAdapter{}
+		w.lock.Unlock()
+
+		close(w.events)
+	})
+}
+
+// add track a new adapter and watch its properties
+func (w *AdapterWatcher) add(path dbus.ObjectPath, ifaces map[string]map[string]dbus.Variant) (*watchedAdapter, error) {
+
+	id, err := ParseAdapterID(path)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.conn.DBus().BusObject().Call("org.freedesktop.DBus.AddMatch", 0, propertiesMatch(path)).Err
+	if err != nil {
+		return nil, fmt.Errorf("AddMatch %s: %s", path, err)
+	}
+
+	a := &watchedAdapter{
+		id:     id,
+		path:   path,
+		ifaces: map[string]map[string]dbus.Variant{},
+	}
+	for iface, props := range ifaces {
+		a.ifaces[iface] = props
+	}
+
+	w.lock.Lock()
+	w.adapters[path] = a
+	w.lock.Unlock()
+
+	return a, nil
+}
+
+// watch process the signals until the watcher is closed
+func (w *AdapterWatcher) watch() {
+	defer w.wg.Done()
+	for {
+		select {
+		case sig, ok := <-w.signals:
+			if !ok {
+				return
+			}
+			if sig == nil {
+				continue
+			}
+			for _, ev := range w.handle(sig) {
+				select {
+				case w.events <- ev:
+				case <-w.done:
+					return
+				}
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// handle update the adapters state from a signal, returning the resulting events
+func (w *AdapterWatcher) handle(sig *dbus.Signal) []AdapterEvent {
+
+	switch sig.Name {
+	case bluez.InterfacesAdded:
+		path, ifaces, err := parseInterfacesAdded(sig)
+		if err != nil {
+			return nil
+		}
+
+		w.lock.Lock()
+		a, ok := w.adapters[path]
+		if ok {
+			// eg. LEAdvertisingManager1 exposed after powering on
+			for iface, props := range ifaces {
+				a.ifaces[iface] = props
+			}
+		}
+		w.lock.Unlock()
+		if ok {
+			return nil
+		}
+
+		if _, isAdapter := ifaces[Adapter1Interface]; !isAdapter {
+			return nil
+		}
+		a, err = w.add(path, ifaces)
+		if err != nil {
+			log.Warnf("AdapterWatcher: %s", err)
+			return nil
+		}
+		log.Debugf("AdapterWatcher: added %s", a.id)
+		return []AdapterEvent{{Type: AdapterAdded, Adapter: w.info(a)}}
+
+	case bluez.InterfacesRemoved:
+		if len(sig.Body) < 2 {
+			return nil
+		}
+		path, ok := sig.Body[0].(dbus.ObjectPath)
+		if !ok {
+			return nil
+		}
+		ifaces, ok := sig.Body[1].([]string)
+		if !ok {
+			return nil
+		}
+
+		w.lock.Lock()
+		a, ok := w.adapters[path]
+		if !ok {
+			w.lock.Unlock()
+			return nil
+		}
+		removed := false
+		for _, iface := range ifaces {
+			if iface == Adapter1Interface {
+				removed = true
+				continue
+			}
+			delete(a.ifaces, iface)
+		}
+		if !removed {
+			w.lock.Unlock()
+			return nil
+		}
+		delete(w.adapters, path)
+		info := a.info()
+		w.lock.Unlock()
+
+		w.conn.DBus().BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, propertiesMatch(path))
+		log.Debugf("AdapterWatcher: removed %s", a.id)
+		return []AdapterEvent{{Type: AdapterRemoved, Adapter: info}}
+
+	case bluez.PropertiesChanged:
+		if len(sig.Body) < 2 {
+			return nil
+		}
+		iface, ok := sig.Body[0].(string)
+		if !ok {
+			return nil
+		}
+		changes, ok := sig.Body[1].(map[string]dbus.Variant)
+		if !ok {
+			return nil
+		}
+
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		a, ok := w.adapters[sig.Path]
+		if !ok {
+			return nil
+		}
+		props, ok := a.ifaces[iface]
+		if !ok {
+			props = map[string]dbus.Variant{}
+			a.ifaces[iface] = props
+		}
+
+		powerChanged := false
+		for name, value := range changes {
+			if iface == Adapter1Interface && name == "Powered" {
+				old, _ := props[name].Value().(bool)
+				powered, _ := value.Value().(bool)
+				powerChanged = old != powered
+			}
+			props[name] = value
+		}
+		if !powerChanged {
+			return nil
+		}
+		return []AdapterEvent{{Type: AdapterPowerChanged, Adapter: a.info()}}
+	}
+
+	return nil
+}
+
+func (w *AdapterWatcher) info(a *watchedAdapter) AdapterInfo {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return a.info()
+}
+
+// parseInterfacesAdded read the body of an InterfacesAdded signal
+func parseInterfacesAdded(sig *dbus.Signal) (dbus.ObjectPath, map[string]map[string]dbus.Variant, error) {
+	if len(sig.Body) < 2 {
+		return "", nil, errors.New("InterfacesAdded: invalid body")
+	}
+	path, ok := sig.Body[0].(dbus.ObjectPath)
+	if !ok {
+		return "", nil, errors.New("InterfacesAdded: invalid path")
+	}
+	ifaces, ok := sig.Body[1].(map[string]map[string]dbus.Variant)
+	if !ok {
+		return "", nil, errors.New("InterfacesAdded: invalid interfaces")
+	}
+	return path, ifaces, nil
+}
+
+func adapterPath(adapterID string) dbus.ObjectPath {
+	return dbus.ObjectPath(fmt.Sprintf("%s/%s", bluez.OrgBluezPath, adapterID))
+}
+
+func propertiesMatch(path dbus.ObjectPath) string {
+	return fmt.Sprintf("type='signal',interface='%s',path='%s'", bluez.PropertiesInterface, path)
+}
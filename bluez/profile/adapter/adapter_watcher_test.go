@@ -0,0 +1,116 @@
+package adapter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/muka/go-bluetooth/bluez/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitAdapterEvent(t *testing.T, w *AdapterWatcher) AdapterEvent {
+	t.Helper()
+	select {
+	case ev := <-w.Events():
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("Adapter event not received")
+		return AdapterEvent{}
+	}
+}
+
+func TestAdapterWatcher(t *testing.T) {
+	b, _ := fake.StartAdapterT(t, "hci0", map[string]interface{}{"Powered": true})
+
+	conn, err := b.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewAdapterWatcherWithConn(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	adapters := w.Adapters()
+	assert.Len(t, adapters, 1)
+	assert.Equal(t, "hci0", adapters[0].ID)
+	assert.True(t, adapters[0].Powered())
+	assert.True(t, adapters[0].HasRole("peripheral"))
+	assert.True(t, adapters[0].Supports("org.bluez.LEAdvertisingManager1"))
+
+	// plug a dongle
+	fa, err := b.AddAdapter("hci1", map[string]interface{}{"Address": "00:1A:7D:DA:71:14"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := waitAdapterEvent(t, w)
+	assert.Equal(t, AdapterAdded, ev.Type)
+	assert.Equal(t, "hci1", ev.Adapter.ID)
+	assert.Equal(t, "00:1A:7D:DA:71:14", ev.Adapter.Properties.Address)
+	assert.False(t, ev.Adapter.Powered())
+	assert.Len(t, w.Adapters(), 2)
+
+	err = fa.Set("Powered", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev = waitAdapterEvent(t, w)
+	assert.Equal(t, AdapterPowerChanged, ev.Type)
+	assert.Equal(t, "hci1", ev.Adapter.ID)
+	assert.True(t, ev.Adapter.Powered())
+
+	info, ok := w.Adapter("hci1")
+	assert.True(t, ok)
+	assert.True(t, info.Powered())
+
+	// unplug it
+	err = b.RemoveAdapter(fa)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev = waitAdapterEvent(t, w)
+	assert.Equal(t, AdapterRemoved, ev.Type)
+	assert.Equal(t, "hci1", ev.Adapter.ID)
+	_, ok = w.Adapter("hci1")
+	assert.False(t, ok)
+
+	w.Close()
+	_, ok = <-w.Events()
+	assert.False(t, ok)
+}
+
+func TestAdapterWatcherDefault(t *testing.T) {
+	b := fake.StartT(t)
+
+	for _, id := range []string{"hci1", "hci2"} {
+		_, err := b.AddAdapter(id, map[string]interface{}{"Powered": id == "hci2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	conn, err := b.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewAdapterWatcherWithConn(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	defaultAdapterID := GetDefaultAdapterID()
+	defer SetDefaultAdapterID(defaultAdapterID)
+
+	// hci0 is not available, the first powered adapter is returned
+	SetDefaultAdapterID("hci0")
+	info, ok := w.Default()
+	assert.True(t, ok)
+	assert.Equal(t, "hci2", info.ID)
+
+	SetDefaultAdapterID("hci1")
+	info, ok = w.Default()
+	assert.True(t, ok)
+	assert.Equal(t, "hci1", info.ID)
+}
@@ -3,6 +3,7 @@
 package adapter
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -19,6 +20,12 @@ var Adapter1Interface = "org.bluez.Adapter1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}
 func NewAdapter1(objectPath dbus.ObjectPath) (*Adapter1, error) {
+	return NewAdapter1WithConn(nil, objectPath)
+}
+
+// NewAdapter1WithConn create a new instance of Adapter1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewAdapter1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*Adapter1, error) {
 	a := new(Adapter1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewAdapter1(objectPath dbus.ObjectPath) (*Adapter1, error) {
 			Iface: Adapter1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Adapter1Properties)
@@ -40,6 +48,12 @@ func NewAdapter1(objectPath dbus.ObjectPath) (*Adapter1, error) {
 // NewAdapter1FromAdapterID create a new instance of Adapter1
 // adapterID: ID of an adapter eg. hci0
 func NewAdapter1FromAdapterID(adapterID string) (*Adapter1, error) {
+	return NewAdapter1FromAdapterIDWithConn(nil, adapterID)
+}
+
+// NewAdapter1FromAdapterIDWithConn create a new instance of Adapter1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewAdapter1FromAdapterIDWithConn(conn *bluez.Conn, adapterID string) (*Adapter1, error) {
 	a := new(Adapter1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -47,6 +61,7 @@ func NewAdapter1FromAdapterID(adapterID string) (*Adapter1, error) {
 			Iface: Adapter1Interface,
 			Path:  dbus.ObjectPath(fmt.Sprintf("/org/bluez/%s", adapterID)),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Adapter1Properties)
@@ -453,7 +468,7 @@ func (a *Adapter1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -577,7 +592,12 @@ StartDiscovery 			This method starts the device discovery session. This
 
 */
 func (a *Adapter1) StartDiscovery() error {
-	return a.client.Call("StartDiscovery", 0).Store()
+	return a.StartDiscoveryContext(context.Background())
+}
+
+// StartDiscoveryContext is like StartDiscovery, the call is cancelled when ctx is done
+func (a *Adapter1) StartDiscoveryContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "StartDiscovery", 0).Store()
 }
 
 /*
@@ -592,7 +612,12 @@ StopDiscovery 			This method will cancel any previous StartDiscovery
 
 */
 func (a *Adapter1) StopDiscovery() error {
-	return a.client.Call("StopDiscovery", 0).Store()
+	return a.StopDiscoveryContext(context.Background())
+}
+
+// StopDiscoveryContext is like StopDiscovery, the call is cancelled when ctx is done
+func (a *Adapter1) StopDiscoveryContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "StopDiscovery", 0).Store()
 }
 
 /*
@@ -603,7 +628,12 @@ RemoveDevice 			This removes the remote device object at the given
 
 */
 func (a *Adapter1) RemoveDevice(device dbus.ObjectPath) error {
-	return a.client.Call("RemoveDevice", 0, device).Store()
+	return a.RemoveDeviceContext(context.Background(), device)
+}
+
+// RemoveDeviceContext is like RemoveDevice, the call is cancelled when ctx is done
+func (a *Adapter1) RemoveDeviceContext(ctx context.Context, device dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "RemoveDevice", 0, device).Store()
 }
 
 /*
@@ -693,7 +723,12 @@ SetDiscoveryFilter 			This method sets the device discovery filter for the
 
 */
 func (a *Adapter1) SetDiscoveryFilter(filter map[string]interface{}) error {
-	return a.client.Call("SetDiscoveryFilter", 0, filter).Store()
+	return a.SetDiscoveryFilterContext(context.Background(), filter)
+}
+
+// SetDiscoveryFilterContext is like SetDiscoveryFilter, the call is cancelled when ctx is done
+func (a *Adapter1) SetDiscoveryFilterContext(ctx context.Context, filter map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "SetDiscoveryFilter", 0, filter).Store()
 }
 
 /*
@@ -703,8 +738,13 @@ GetDiscoveryFilters 			Return available filters that can be given to
 
 */
 func (a *Adapter1) GetDiscoveryFilters() ([]string, error) {
+	return a.GetDiscoveryFiltersContext(context.Background())
+}
+
+// GetDiscoveryFiltersContext is like GetDiscoveryFilters, the call is cancelled when ctx is done
+func (a *Adapter1) GetDiscoveryFiltersContext(ctx context.Context) ([]string, error) {
 	val0 := []string{}
-	err := a.client.Call("GetDiscoveryFilters", 0).Store(&val0)
+	err := a.client.CallWithContext(ctx, "GetDiscoveryFilters", 0).Store(&val0)
 	return val0, err
 }
 
@@ -740,7 +780,12 @@ ConnectDevice 			This method connects to device without need of
 
 */
 func (a *Adapter1) ConnectDevice(properties map[string]interface{}) (dbus.ObjectPath, error) {
+	return a.ConnectDeviceContext(context.Background(), properties)
+}
+
+// ConnectDeviceContext is like ConnectDevice, the call is cancelled when ctx is done
+func (a *Adapter1) ConnectDeviceContext(ctx context.Context, properties map[string]interface{}) (dbus.ObjectPath, error) {
 	var val0 dbus.ObjectPath
-	err := a.client.Call("ConnectDevice", 0, properties).Store(&val0)
+	err := a.client.CallWithContext(ctx, "ConnectDevice", 0, properties).Store(&val0)
 	return val0, err
 }
@@ -0,0 +1,60 @@
+package adapter
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez/fake"
+	log "github.com/sirupsen/logrus"
+)
+
+// TestMain run the tests against a fake bluez daemon, if available
+func TestMain(m *testing.M) {
+
+	b, err := fake.Start()
+	if err != nil {
+		log.Warnf("Fake bluez not available, using system bus: %s", err)
+		os.Exit(m.Run())
+	}
+
+	err = setupFakeBluez(b)
+	if err != nil {
+		b.Close()
+		log.Fatalf("Fake bluez setup: %s", err)
+	}
+
+	code := m.Run()
+	b.Close()
+	os.Exit(code)
+}
+
+func setupFakeBluez(b *fake.Bluez) error {
+	for _, adapterID := range []string{"hci0", "hci1"} {
+		a, err := b.AddAdapter(adapterID, map[string]interface{}{
+			"Address": "00:1A:7D:DA:71:13",
+			"Powered": true,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = a.AddDevice("11:22:33:44:55:66", nil)
+		if err != nil {
+			return err
+		}
+
+		// simulate a device found shortly after discovery starts
+		a.OnCall("StartDiscovery", func() *dbus.Error {
+			go func() {
+				time.Sleep(time.Millisecond * 100)
+				if a.Device("22:33:44:55:66:77") == nil {
+					a.AddDevice("22:33:44:55:66:77", nil)
+				}
+			}()
+			return nil
+		})
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package advertisement_monitor
+
+import "fmt"
+
+// RSSI thresholds and timers limits, as checked by bluez
+const (
+	// RSSIMin is the lowest RSSI threshold, in dBm
+	RSSIMin = -127
+	// RSSIMax is the highest RSSI threshold, in dBm
+	RSSIMax = 20
+	// TimerMin is the shortest RSSI threshold timer, in seconds
+	TimerMin = 1
+	// TimerMax is the longest RSSI threshold timer, in seconds
+	TimerMax = 300
+)
+
+// RSSIVersion is the bluez version replacing RSSIThresholdsAndTimers with the
+// RSSIHighThreshold, RSSIHighTimeout, RSSILowThreshold, RSSILowTimeout and
+// RSSISamplingPeriod properties, see RSSIProperties
+const RSSIVersion = "5.56"
+
+// RSSISamplingPeriod values, periods from 1 to 254 group the advertisements of an
+// in-range device in periods of 100ms * N, reported once with the average RSSI
+const (
+	// SamplingPeriodAll report all the advertisements of in-range devices
+	SamplingPeriodAll uint16 = 0
+	// SamplingPeriodFirst report the first advertisement of in-range devices only
+	SamplingPeriodFirst uint16 = 255
+	// SamplingPeriodUnset leave the sampling period to bluez
+	SamplingPeriodUnset uint16 = 256
+)
+
+// MaxPatternLength is the AD data length a pattern, StartPosition included, must fit in
+const MaxPatternLength = 31
+
+// AddPattern add a pattern matching content in the data of the AD structures of
+// type adType, starting at startPosition
+func (a *AdvertisementMonitor1Properties) AddPattern(startPosition uint8, adType uint8, content []byte) {
+	a.Patterns = append(a.Patterns, Pattern{
+		StartPosition: startPosition,
+		ADType:        adType,
+		Content:       content,
+	})
+}
+
+// SetRSSI set the RSSI a device must exceed for highTimer seconds to be found, and
+// the RSSI it must stay below for lowTimer seconds to be lost
+func (a *AdvertisementMonitor1Properties) SetRSSI(high int16, highTimer uint16, low int16, lowTimer uint16) {
+	a.RSSIThresholdsAndTimers = RSSIThresholdsAndTimers{
+		HighThreshold: high,
+		HighTimer:     highTimer,
+		LowThreshold:  low,
+		LowTimer:      lowTimer,
+	}
+}
+
+// RSSIProperties return RSSIThresholdsAndTimers and samplingPeriod as the separate
+// properties read by bluez since RSSIVersion. Unset values are not included
+func (a *AdvertisementMonitor1Properties) RSSIProperties(samplingPeriod uint16) (map[string]interface{}, error) {
+
+	if samplingPeriod > SamplingPeriodUnset {
+		return nil, fmt.Errorf("RSSI sampling period %d out of range [0, %d]", samplingPeriod, SamplingPeriodUnset)
+	}
+
+	props := map[string]interface{}{}
+	r := a.RSSIThresholdsAndTimers
+	if r != (RSSIThresholdsAndTimers{}) {
+		props["RSSIHighThreshold"] = r.HighThreshold
+		props["RSSIHighTimeout"] = r.HighTimer
+		props["RSSILowThreshold"] = r.LowThreshold
+		props["RSSILowTimeout"] = r.LowTimer
+	}
+	if samplingPeriod != SamplingPeriodUnset {
+		props["RSSISamplingPeriod"] = samplingPeriod
+	}
+	return props, nil
+}
+
+// Validate check the monitor properties, as bluez does when the monitor is exposed.
+// A zero RSSIThresholdsAndTimers is not sent, leaving the RSSI unfiltered
+func (a *AdvertisementMonitor1Properties) Validate() error {
+
+	if a.Type != MonitorTypeOrPatterns {
+		return fmt.Errorf("Type %q is not supported", a.Type)
+	}
+
+	if len(a.Patterns) == 0 {
+		return fmt.Errorf("Patterns are required by %s", a.Type)
+	}
+	for i, p := range a.Patterns {
+		if len(p.Content) == 0 {
+			return fmt.Errorf("Pattern %d: empty Content", i)
+		}
+		if int(p.StartPosition)+len(p.Content) > MaxPatternLength {
+			return fmt.Errorf("Pattern %d: exceeds %d bytes", i, MaxPatternLength)
+		}
+	}
+
+	r := a.RSSIThresholdsAndTimers
+	if r == (RSSIThresholdsAndTimers{}) {
+		return nil
+	}
+	for _, threshold := range []int16{r.HighThreshold, r.LowThreshold} {
+		if threshold < RSSIMin || threshold > RSSIMax {
+			return fmt.Errorf("RSSI threshold %d out of range [%d, %d]", threshold, RSSIMin, RSSIMax)
+		}
+	}
+	for _, timer := range []uint16{r.HighTimer, r.LowTimer} {
+		if timer < TimerMin || timer > TimerMax {
+			return fmt.Errorf("RSSI timer %d out of range [%d, %d]", timer, TimerMin, TimerMax)
+		}
+	}
+	if r.HighThreshold < r.LowThreshold {
+		return fmt.Errorf("RSSI high threshold %d is lower than the low threshold %d", r.HighThreshold, r.LowThreshold)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package advertisement_monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+
+	props := &AdvertisementMonitor1Properties{
+		Type: MonitorTypeOrPatterns,
+	}
+	assert.Error(t, props.Validate())
+
+	props.AddPattern(0, 0xff, []byte{0x4c, 0x00})
+	assert.NoError(t, props.Validate())
+
+	props.SetRSSI(-60, 5, -80, 10)
+	assert.NoError(t, props.Validate())
+
+	// hysteresis is inverted
+	props.SetRSSI(-80, 5, -60, 10)
+	assert.Error(t, props.Validate())
+
+	props.SetRSSI(-60, 0, -80, 10)
+	assert.Error(t, props.Validate())
+
+	props.SetRSSI(-60, 5, -128, 10)
+	assert.Error(t, props.Validate())
+
+	props.RSSIThresholdsAndTimers = RSSIThresholdsAndTimers{}
+	props.AddPattern(30, 0x09, []byte("go"))
+	assert.Error(t, props.Validate())
+
+	props.Patterns = props.Patterns[:1]
+	props.Type = "and_patterns"
+	assert.Error(t, props.Validate())
+}
+
+func TestToMapOmitEmpty(t *testing.T) {
+
+	props := &AdvertisementMonitor1Properties{
+		Type: MonitorTypeOrPatterns,
+	}
+	props.AddPattern(0, 0x09, []byte("go"))
+
+	m, err := props.ToMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, m, "Patterns")
+	assert.NotContains(t, m, "RSSIThresholdsAndTimers")
+
+	props.SetRSSI(-60, 5, -80, 10)
+	m, err = props.ToMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, props.RSSIThresholdsAndTimers, m["RSSIThresholdsAndTimers"])
+}
+
+func TestRSSIProperties(t *testing.T) {
+
+	props := &AdvertisementMonitor1Properties{
+		Type: MonitorTypeOrPatterns,
+	}
+
+	m, err := props.RSSIProperties(SamplingPeriodUnset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, m)
+
+	props.SetRSSI(-60, 5, -80, 10)
+	m, err = props.RSSIProperties(SamplingPeriodAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]interface{}{
+		"RSSIHighThreshold":  int16(-60),
+		"RSSIHighTimeout":    uint16(5),
+		"RSSILowThreshold":   int16(-80),
+		"RSSILowTimeout":     uint16(10),
+		"RSSISamplingPeriod": uint16(0),
+	}, m)
+
+	_, err = props.RSSIProperties(SamplingPeriodUnset + 1)
+	assert.Error(t, err)
+}
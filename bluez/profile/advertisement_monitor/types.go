@@ -0,0 +1,25 @@
+package advertisement_monitor
+
+// MonitorTypeOrPatterns match the advertisements containing any of the Patterns
+const MonitorTypeOrPatterns = "or_patterns"
+
+// FeatureControllerPatterns the controller filters the advertisements by pattern
+const FeatureControllerPatterns = "controller-patterns"
+
+// Pattern array{(uint8, uint8, string)}, matching Content in the data of the
+// AD structures of type ADType, starting at StartPosition
+type Pattern struct {
+	StartPosition uint8
+	ADType        uint8
+	Content       []byte
+}
+
+// RSSIThresholdsAndTimers (Int16, Uint16, Int16, Uint16)
+// A device is found once its RSSI exceeds HighThreshold (dBm) for HighTimer
+// seconds, and lost once it does not reach LowThreshold for LowTimer seconds
+type RSSIThresholdsAndTimers struct {
+	HighThreshold int16
+	HighTimer     uint16
+	LowThreshold  int16
+	LowTimer      uint16
+}
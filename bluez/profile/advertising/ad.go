@@ -0,0 +1,337 @@
+package advertising
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/muka/go-bluetooth/bluez"
+)
+
+// AD types, as assigned in the Bluetooth Core Specification Supplement
+const (
+	ADTypeFlags             byte = 0x01
+	ADTypeIncompleteUUID16  byte = 0x02
+	ADTypeCompleteUUID16    byte = 0x03
+	ADTypeIncompleteUUID32  byte = 0x04
+	ADTypeCompleteUUID32    byte = 0x05
+	ADTypeIncompleteUUID128 byte = 0x06
+	ADTypeCompleteUUID128   byte = 0x07
+	ADTypeShortLocalName    byte = 0x08
+	ADTypeCompleteLocalName byte = 0x09
+	ADTypeTxPower           byte = 0x0A
+	ADTypeSolicitUUID16     byte = 0x14
+	ADTypeSolicitUUID128    byte = 0x15
+	ADTypeServiceData16     byte = 0x16
+	ADTypeAppearance        byte = 0x19
+	ADTypeSolicitUUID32     byte = 0x1F
+	ADTypeServiceData32     byte = 0x20
+	ADTypeServiceData128    byte = 0x21
+	ADTypeManufacturerData  byte = 0xFF
+)
+
+// maxADStructureDataLength is the data limit of a structure, the length byte counts the type too
+const maxADStructureDataLength = 254
+
+const bluetoothBaseUUIDSuffix = "-0000-1000-8000-00805f9b34fb"
+
+// Flags AD values
+const (
+	FlagLimitedDiscoverable byte = 0x01
+	FlagGeneralDiscoverable byte = 0x02
+	FlagBREDRNotSupported   byte = 0x04
+)
+
+// Maximum advertising or scan response data length
+const (
+	// MaxLegacyDataLength is the limit of legacy advertising PDUs
+	MaxLegacyDataLength = 31
+	// MaxExtendedDataLength is the limit of extended advertising, as supported by bluez
+	MaxExtendedDataLength = 251
+)
+
+// ErrInvalidAD is returned when raw advertising data cannot be decoded
+var ErrInvalidAD = errors.New("invalid advertising data")
+
+// ADStructure is a single AD structure of advertising or scan response data
+type ADStructure struct {
+	Type byte
+	Data []byte
+}
+
+// Len return the encoded length, including the length and type bytes
+func (s ADStructure) Len() int {
+	return 2 + len(s.Data)
+}
+
+func (s ADStructure) String() string {
+	return fmt.Sprintf("0x%02x:%s", s.Type, hex.EncodeToString(s.Data))
+}
+
+// AD is a list of AD structures, as found in advertising or scan response data
+type AD []ADStructure
+
+// Len return the encoded length
+func (ad AD) Len() int {
+	l := 0
+	for _, s := range ad {
+		l += s.Len()
+	}
+	return l
+}
+
+// Encode return the raw data
+func (ad AD) Encode() ([]byte, error) {
+	b := make([]byte, 0, ad.Len())
+	for _, s := range ad {
+		if len(s.Data) > maxADStructureDataLength {
+			return nil, fmt.Errorf("AD type 0x%02x: %d bytes exceed the structure size", s.Type, len(s.Data))
+		}
+		b = append(b, byte(len(s.Data)+1), s.Type)
+		b = append(b, s.Data...)
+	}
+	return b, nil
+}
+
+// DecodeAD parse raw advertising or scan response data. Zero length
+// structures, used as padding, end the data
+func DecodeAD(b []byte) (AD, error) {
+	ad := AD{}
+	for i := 0; i < len(b); {
+		l := int(b[i])
+		if l == 0 {
+			break
+		}
+		if i+1+l > len(b) {
+			return nil, fmt.Errorf("offset %d: length %d exceeds the data: %w", i, l, ErrInvalidAD)
+		}
+		data := make([]byte, l-1)
+		copy(data, b[i+2:i+1+l])
+		ad = append(ad, ADStructure{Type: b[i+1], Data: data})
+		i += 1 + l
+	}
+	return ad, nil
+}
+
+// Find return the first structure of type t
+func (ad AD) Find(t byte) (ADStructure, bool) {
+	for _, s := range ad {
+		if s.Type == t {
+			return s, true
+		}
+	}
+	return ADStructure{}, false
+}
+
+// Flags return the flags value
+func (ad AD) Flags() (byte, bool) {
+	s, ok := ad.Find(ADTypeFlags)
+	if !ok || len(s.Data) < 1 {
+		return 0, false
+	}
+	return s.Data[0], true
+}
+
+// LocalName return the local name and if it is complete or shortened
+func (ad AD) LocalName() (name string, complete bool, ok bool) {
+	if s, ok := ad.Find(ADTypeCompleteLocalName); ok {
+		return string(s.Data), true, true
+	}
+	if s, ok := ad.Find(ADTypeShortLocalName); ok {
+		return string(s.Data), false, true
+	}
+	return "", false, false
+}
+
+// TxPower return the TX power level in dBm
+func (ad AD) TxPower() (int8, bool) {
+	s, ok := ad.Find(ADTypeTxPower)
+	if !ok || len(s.Data) != 1 {
+		return 0, false
+	}
+	return int8(s.Data[0]), true
+}
+
+// Appearance return the appearance value
+func (ad AD) Appearance() (uint16, bool) {
+	s, ok := ad.Find(ADTypeAppearance)
+	if !ok || len(s.Data) != 2 {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint16(s.Data), true
+}
+
+// ServiceUUIDs return the complete and incomplete service UUIDs lists
+func (ad AD) ServiceUUIDs() []bluez.UUID {
+	return ad.uuids(map[byte]int{
+		ADTypeIncompleteUUID16:  2,
+		ADTypeCompleteUUID16:    2,
+		ADTypeIncompleteUUID32:  4,
+		ADTypeCompleteUUID32:    4,
+		ADTypeIncompleteUUID128: 16,
+		ADTypeCompleteUUID128:   16,
+	})
+}
+
+// SolicitUUIDs return the service solicitation UUIDs
+func (ad AD) SolicitUUIDs() []bluez.UUID {
+	return ad.uuids(map[byte]int{
+		ADTypeSolicitUUID16:  2,
+		ADTypeSolicitUUID32:  4,
+		ADTypeSolicitUUID128: 16,
+	})
+}
+
+func (ad AD) uuids(types map[byte]int) []bluez.UUID {
+	list := []bluez.UUID{}
+	for _, s := range ad {
+		size, ok := types[s.Type]
+		if !ok {
+			continue
+		}
+		for i := 0; i+size <= len(s.Data); i += size {
+			list = append(list, decodeUUID(s.Data[i:i+size]))
+		}
+	}
+	return list
+}
+
+// ServiceData return the service data by UUID
+func (ad AD) ServiceData() map[bluez.UUID][]byte {
+	sizes := map[byte]int{
+		ADTypeServiceData16:  2,
+		ADTypeServiceData32:  4,
+		ADTypeServiceData128: 16,
+	}
+	data := map[bluez.UUID][]byte{}
+	for _, s := range ad {
+		size, ok := sizes[s.Type]
+		if !ok || len(s.Data) < size {
+			continue
+		}
+		data[decodeUUID(s.Data[:size])] = s.Data[size:]
+	}
+	return data
+}
+
+// ManufacturerData return the manufacturer data by company ID
+func (ad AD) ManufacturerData() map[uint16][]byte {
+	data := map[uint16][]byte{}
+	for _, s := range ad {
+		if s.Type != ADTypeManufacturerData || len(s.Data) < 2 {
+			continue
+		}
+		data[binary.LittleEndian.Uint16(s.Data)] = s.Data[2:]
+	}
+	return data
+}
+
+// FlagsAD return a flags structure
+func FlagsAD(flags byte) ADStructure {
+	return ADStructure{Type: ADTypeFlags, Data: []byte{flags}}
+}
+
+// LocalNameAD return a complete or shortened local name structure
+func LocalNameAD(name string, complete bool) ADStructure {
+	t := ADTypeShortLocalName
+	if complete {
+		t = ADTypeCompleteLocalName
+	}
+	return ADStructure{Type: t, Data: []byte(name)}
+}
+
+// TxPowerAD return a TX power level structure
+func TxPowerAD(dbm int8) ADStructure {
+	return ADStructure{Type: ADTypeTxPower, Data: []byte{byte(dbm)}}
+}
+
+// AppearanceAD return an appearance structure
+func AppearanceAD(appearance uint16) ADStructure {
+	data := make([]byte, 2)
+	binary.LittleEndian.PutUint16(data, appearance)
+	return ADStructure{Type: ADTypeAppearance, Data: data}
+}
+
+// ServiceUUIDsAD return the complete service UUIDs lists, one per UUID size,
+// using the shortest form of each UUID
+func ServiceUUIDsAD(uuids ...bluez.UUID) (AD, error) {
+	return uuidsAD(uuids, [3]byte{ADTypeCompleteUUID16, ADTypeCompleteUUID32, ADTypeCompleteUUID128})
+}
+
+// SolicitUUIDsAD return the service solicitation lists, one per UUID size,
+// using the shortest form of each UUID
+func SolicitUUIDsAD(uuids ...bluez.UUID) (AD, error) {
+	return uuidsAD(uuids, [3]byte{ADTypeSolicitUUID16, ADTypeSolicitUUID32, ADTypeSolicitUUID128})
+}
+
+func uuidsAD(uuids []bluez.UUID, types [3]byte) (AD, error) {
+	lists := [3][]byte{}
+	for _, uuid := range uuids {
+		b, err := encodeUUID(uuid)
+		if err != nil {
+			return nil, err
+		}
+		i := map[int]int{2: 0, 4: 1, 16: 2}[len(b)]
+		lists[i] = append(lists[i], b...)
+	}
+	ad := AD{}
+	for i, data := range lists {
+		if len(data) > 0 {
+			ad = append(ad, ADStructure{Type: types[i], Data: data})
+		}
+	}
+	return ad, nil
+}
+
+// ServiceDataAD return a service data structure, using the shortest form of the UUID
+func ServiceDataAD(uuid bluez.UUID, data []byte) (ADStructure, error) {
+	b, err := encodeUUID(uuid)
+	if err != nil {
+		return ADStructure{}, err
+	}
+	t := map[int]byte{2: ADTypeServiceData16, 4: ADTypeServiceData32, 16: ADTypeServiceData128}[len(b)]
+	return ADStructure{Type: t, Data: append(b, data...)}, nil
+}
+
+// ManufacturerDataAD return a manufacturer specific data structure
+func ManufacturerDataAD(companyID uint16, data []byte) ADStructure {
+	b := make([]byte, 2, 2+len(data))
+	binary.LittleEndian.PutUint16(b, companyID)
+	return ADStructure{Type: ADTypeManufacturerData, Data: append(b, data...)}
+}
+
+// encodeUUID return the little endian 16, 32 or 128 bit form of an UUID,
+// the shortest possible one
+func encodeUUID(uuid bluez.UUID) ([]byte, error) {
+	s, err := bluez.ParseUUID(string(uuid))
+	if err != nil {
+		return nil, err
+	}
+	raw, err := hex.DecodeString(strings.Replace(string(s), "-", "", -1))
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(string(s), bluetoothBaseUUIDSuffix) {
+		if raw[0] == 0 && raw[1] == 0 {
+			raw = raw[2:4]
+		} else {
+			raw = raw[:4]
+		}
+	}
+	b := make([]byte, len(raw))
+	for i := range raw {
+		b[i] = raw[len(raw)-1-i]
+	}
+	return b, nil
+}
+
+// decodeUUID return the canonical form of a little endian 16, 32 or 128 bit UUID
+func decodeUUID(b []byte) bluez.UUID {
+	raw := make([]byte, len(b))
+	for i := range b {
+		raw[i] = b[len(b)-1-i]
+	}
+	return bluez.UUID(bluez.UUID(hex.EncodeToString(raw)).String())
+}
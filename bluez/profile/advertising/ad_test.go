@@ -0,0 +1,88 @@
+package advertising
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestADEncodeDecode(t *testing.T) {
+
+	uuids, err := ServiceUUIDsAD("180f", "12345678", "6e400001-b5a3-f393-e0a9-e50e24dcca9e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	solicit, err := SolicitUUIDsAD("1812")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serviceData, err := ServiceDataAD("feaa", []byte{0x10, 0x20})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ad := AD{FlagsAD(FlagGeneralDiscoverable | FlagBREDRNotSupported)}
+	ad = append(ad, uuids...)
+	ad = append(ad, solicit...)
+	ad = append(ad,
+		serviceData,
+		ManufacturerDataAD(0x004c, []byte{0x02, 0x15}),
+		LocalNameAD("go", true),
+		TxPowerAD(-8),
+		AppearanceAD(0x03c1),
+	)
+
+	b, err := ad.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ad.Len(), len(b))
+	assert.Equal(t, []byte{0x02, ADTypeFlags, 0x06, 0x03, ADTypeCompleteUUID16, 0x0f, 0x18}, b[:7])
+
+	decoded, err := DecodeAD(append(b, 0, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ad, decoded)
+
+	flags, ok := decoded.Flags()
+	assert.True(t, ok)
+	assert.Equal(t, FlagGeneralDiscoverable|FlagBREDRNotSupported, flags)
+
+	assert.Equal(t, []bluez.UUID{
+		"0000180f-0000-1000-8000-00805f9b34fb",
+		"12345678-0000-1000-8000-00805f9b34fb",
+		"6e400001-b5a3-f393-e0a9-e50e24dcca9e",
+	}, decoded.ServiceUUIDs())
+	assert.Equal(t, []bluez.UUID{"00001812-0000-1000-8000-00805f9b34fb"}, decoded.SolicitUUIDs())
+	assert.Equal(t, map[bluez.UUID][]byte{
+		"0000feaa-0000-1000-8000-00805f9b34fb": {0x10, 0x20},
+	}, decoded.ServiceData())
+	assert.Equal(t, map[uint16][]byte{0x004c: {0x02, 0x15}}, decoded.ManufacturerData())
+
+	name, complete, ok := decoded.LocalName()
+	assert.True(t, ok)
+	assert.True(t, complete)
+	assert.Equal(t, "go", name)
+
+	txPower, ok := decoded.TxPower()
+	assert.True(t, ok)
+	assert.Equal(t, int8(-8), txPower)
+
+	appearance, ok := decoded.Appearance()
+	assert.True(t, ok)
+	assert.Equal(t, uint16(0x03c1), appearance)
+}
+
+func TestADInvalid(t *testing.T) {
+	_, err := DecodeAD([]byte{0x05, ADTypeCompleteLocalName, 'g', 'o'})
+	assert.True(t, errors.Is(err, ErrInvalidAD))
+
+	_, err = AD{{Type: ADTypeManufacturerData, Data: make([]byte, 255)}}.Encode()
+	assert.Error(t, err)
+
+	_, err = ServiceUUIDsAD("not-an-uuid")
+	assert.Error(t, err)
+}
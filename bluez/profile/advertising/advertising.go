@@ -1,5 +1,7 @@
 package advertising
 
+import "github.com/muka/go-bluetooth/bluez"
+
 const (
 	SecondaryChannel1M    = "1M"
 	SecondaryChannel2M    = "2M"
@@ -20,17 +22,20 @@ const (
 	AdvertisementTypePeripheral = "peripheral"
 )
 
-func (a *LEAdvertisement1Properties) AddServiceUUID(uuids ...string) {
+// AddServiceUUID add service UUIDs, in any form, skipping the ones already listed.
+// UUIDs are stored in the canonical 128 bit form
+func (a *LEAdvertisement1Properties) AddServiceUUID(uuids ...bluez.UUID) {
 	if a.ServiceUUIDs == nil {
 		a.ServiceUUIDs = make([]string, 0)
 	}
+next:
 	for _, uuid := range uuids {
 		for _, uuid1 := range a.ServiceUUIDs {
-			if uuid1 == uuid {
-				continue
+			if uuid.Equal(bluez.UUID(uuid1)) {
+				continue next
 			}
 		}
-		a.ServiceUUIDs = append(a.ServiceUUIDs, uuid)
+		a.ServiceUUIDs = append(a.ServiceUUIDs, uuid.String())
 	}
 }
 
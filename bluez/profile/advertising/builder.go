@@ -0,0 +1,324 @@
+package advertising
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+)
+
+// reservedDataTypes are the AD types bluez builds from the other properties,
+// refused in LEAdvertisement1Properties.Data
+var reservedDataTypes = map[byte]string{
+	ADTypeFlags:             "Flags",
+	ADTypeIncompleteUUID16:  "ServiceUUIDs",
+	ADTypeCompleteUUID16:    "ServiceUUIDs",
+	ADTypeIncompleteUUID32:  "ServiceUUIDs",
+	ADTypeCompleteUUID32:    "ServiceUUIDs",
+	ADTypeIncompleteUUID128: "ServiceUUIDs",
+	ADTypeCompleteUUID128:   "ServiceUUIDs",
+	ADTypeShortLocalName:    "LocalName",
+	ADTypeCompleteLocalName: "LocalName",
+	ADTypeTxPower:           "Includes",
+	ADTypeSolicitUUID16:     "SolicitUUIDs",
+	ADTypeSolicitUUID32:     "SolicitUUIDs",
+	ADTypeSolicitUUID128:    "SolicitUUIDs",
+	ADTypeServiceData16:     "ServiceData",
+	ADTypeServiceData32:     "ServiceData",
+	ADTypeServiceData128:    "ServiceData",
+	ADTypeAppearance:        "Appearance",
+	ADTypeManufacturerData:  "ManufacturerData",
+}
+
+// Payload is the advertising and scan response data bluez broadcasts for an
+// advertisement. Values set by bluez or the kernel, as the flags or the TX
+// power level, are placeholders accounting for their size
+type Payload struct {
+	Advertising  AD
+	ScanResponse AD
+	// MaxLength of the advertising and of the scan response data
+	MaxLength int
+}
+
+// MaxLength return the data length limit, extended advertising is used when a
+// SecondaryChannel is set
+func (a *LEAdvertisement1Properties) MaxLength() int {
+	if a.SecondaryChannel != "" {
+		return MaxExtendedDataLength
+	}
+	return MaxLegacyDataLength
+}
+
+// Payload lay out the advertisement data as bluez does: the local name is sent in
+// the scan response, truncated on a character boundary if too long, the other fields
+// in the advertising data. The split follows the fixed layout of bluez, fields are not
+// moved to the scan response when the advertising data is full.
+// The adapter name, when included, is not known and not accounted for
+func (a *LEAdvertisement1Properties) Payload() (*Payload, error) {
+
+	p := &Payload{
+		Advertising:  AD{},
+		ScanResponse: AD{},
+		MaxLength:    a.MaxLength(),
+	}
+
+	// the kernel add the flags to almost any advertisement
+	var flags byte
+	if a.Discoverable {
+		flags |= FlagGeneralDiscoverable
+	}
+	p.Advertising = append(p.Advertising, FlagsAD(flags))
+
+	uuids, err := ServiceUUIDsAD(toUUIDs(a.ServiceUUIDs)...)
+	if err != nil {
+		return nil, fmt.Errorf("ServiceUUIDs: %s", err)
+	}
+	p.Advertising = append(p.Advertising, uuids...)
+
+	uuids, err = SolicitUUIDsAD(toUUIDs(a.SolicitUUIDs)...)
+	if err != nil {
+		return nil, fmt.Errorf("SolicitUUIDs: %s", err)
+	}
+	p.Advertising = append(p.Advertising, uuids...)
+
+	ids := []int{}
+	for id := range a.ManufacturerData {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		data, err := toBytes(a.ManufacturerData[uint16(id)])
+		if err != nil {
+			return nil, fmt.Errorf("ManufacturerData 0x%04x: %s", id, err)
+		}
+		p.Advertising = append(p.Advertising, ManufacturerDataAD(uint16(id), data))
+	}
+
+	keys := []string{}
+	for key := range a.ServiceData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		data, err := toBytes(a.ServiceData[key])
+		if err != nil {
+			return nil, fmt.Errorf("ServiceData %s: %s", key, err)
+		}
+		s, err := ServiceDataAD(bluez.UUID(key), data)
+		if err != nil {
+			return nil, fmt.Errorf("ServiceData: %s", err)
+		}
+		p.Advertising = append(p.Advertising, s)
+	}
+
+	types := []int{}
+	for t := range a.Data {
+		types = append(types, int(t))
+	}
+	sort.Ints(types)
+	for _, t := range types {
+		if field, ok := reservedDataTypes[byte(t)]; ok {
+			return nil, fmt.Errorf("Data 0x%02x: use %s", t, field)
+		}
+		data, err := toBytes(a.Data[byte(t)])
+		if err != nil {
+			return nil, fmt.Errorf("Data 0x%02x: %s", t, err)
+		}
+		p.Advertising = append(p.Advertising, ADStructure{Type: byte(t), Data: data})
+	}
+
+	// the adapter appearance is used when included, 0 (unknown) is not advertised
+	if a.Appearance != 0 || a.includes(SupportedIncludesAppearance) {
+		p.Advertising = append(p.Advertising, AppearanceAD(a.Appearance))
+	}
+	if a.includes(SupportedIncludesTxPower) {
+		p.Advertising = append(p.Advertising, TxPowerAD(0))
+	}
+
+	if a.LocalName != "" {
+		name := a.LocalName
+		complete := true
+		if max := p.MaxLength - 2; len(name) > max {
+			// do not split a multi-byte character
+			for max > 0 && !utf8.RuneStart(name[max]) {
+				max--
+			}
+			name = name[:max]
+			complete = false
+		}
+		p.ScanResponse = append(p.ScanResponse, LocalNameAD(name, complete))
+	}
+
+	if l := p.Advertising.Len(); l > p.MaxLength {
+		return p, fmt.Errorf("Advertising data is %d bytes, exceeding %d", l, p.MaxLength)
+	}
+
+	return p, nil
+}
+
+// Validate check the properties and the advertising data size, as bluez does when
+// registering the advertisement
+func (a *LEAdvertisement1Properties) Validate() error {
+
+	switch a.Type {
+	case "", AdvertisementTypePeripheral:
+	case AdvertisementTypeBroadcast:
+		if a.Discoverable {
+			return fmt.Errorf("Discoverable cannot be set on a broadcast advertisement")
+		}
+	default:
+		return fmt.Errorf("Type %q is not supported", a.Type)
+	}
+
+	switch a.SecondaryChannel {
+	case "", SecondaryChannel1M, SecondaryChannel2M, SecondaryChannelCoded:
+	default:
+		return fmt.Errorf("SecondaryChannel %q is not supported", a.SecondaryChannel)
+	}
+
+	for _, include := range a.Includes {
+		switch include {
+		case SupportedIncludesTxPower, SupportedIncludesAppearance, SupportedIncludesLocalName:
+		default:
+			return fmt.Errorf("Includes %q is not supported", include)
+		}
+	}
+	if a.LocalName != "" && a.includes(SupportedIncludesLocalName) {
+		return fmt.Errorf("LocalName and Includes %s are exclusive", SupportedIncludesLocalName)
+	}
+
+	_, err := a.Payload()
+	return err
+}
+
+func (a *LEAdvertisement1Properties) includes(include string) bool {
+	for _, v := range a.Includes {
+		if v == include {
+			return true
+		}
+	}
+	return false
+}
+
+// Builder compose advertisement properties, checking the data fits the advertisement
+type Builder struct {
+	props *LEAdvertisement1Properties
+}
+
+// NewBuilder create a builder of a legacy peripheral advertisement
+func NewBuilder() *Builder {
+	return &Builder{
+		props: &LEAdvertisement1Properties{
+			Type: AdvertisementTypePeripheral,
+		},
+	}
+}
+
+// Broadcast advertise as a non connectable broadcaster
+func (b *Builder) Broadcast() *Builder {
+	b.props.Type = AdvertisementTypeBroadcast
+	return b
+}
+
+// Extended use extended advertising on a secondary channel, eg. SecondaryChannel1M,
+// allowing up to MaxExtendedDataLength bytes of data
+func (b *Builder) Extended(secondaryChannel string) *Builder {
+	b.props.SecondaryChannel = secondaryChannel
+	return b
+}
+
+// Discoverable advertise as general discoverable
+func (b *Builder) Discoverable(discoverable bool) *Builder {
+	b.props.Discoverable = discoverable
+	return b
+}
+
+// LocalName set the name sent in the scan response
+func (b *Builder) LocalName(name string) *Builder {
+	b.props.LocalName = name
+	return b
+}
+
+// Appearance set the appearance value
+func (b *Builder) Appearance(appearance uint16) *Builder {
+	b.props.Appearance = appearance
+	return b
+}
+
+// TxPower include the TX power level
+func (b *Builder) TxPower() *Builder {
+	if !b.props.includes(SupportedIncludesTxPower) {
+		b.props.Includes = append(b.props.Includes, SupportedIncludesTxPower)
+	}
+	return b
+}
+
+// ServiceUUID add service UUIDs
+func (b *Builder) ServiceUUID(uuids ...bluez.UUID) *Builder {
+	b.props.AddServiceUUID(uuids...)
+	return b
+}
+
+// SolicitUUID add service solicitation UUIDs
+func (b *Builder) SolicitUUID(uuids ...bluez.UUID) *Builder {
+	for _, uuid := range uuids {
+		b.props.SolicitUUIDs = append(b.props.SolicitUUIDs, uuid.String())
+	}
+	return b
+}
+
+// ServiceData set the data of a service
+func (b *Builder) ServiceData(uuid bluez.UUID, data []byte) *Builder {
+	b.props.AddServiceData(uuid.String(), data)
+	return b
+}
+
+// ManufacturerData set the data of a company ID
+func (b *Builder) ManufacturerData(companyID uint16, data []byte) *Builder {
+	b.props.AddManifacturerData(companyID, data)
+	return b
+}
+
+// Data set an AD structure not covered by the other fields
+func (b *Builder) Data(adType byte, data []byte) *Builder {
+	b.props.AddData(adType, data)
+	return b
+}
+
+// Build validate and return the advertisement properties, with the resulting payload
+func (b *Builder) Build() (*LEAdvertisement1Properties, *Payload, error) {
+	err := b.props.Validate()
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err := b.props.Payload()
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.props, p, nil
+}
+
+func toUUIDs(list []string) []bluez.UUID {
+	uuids := make([]bluez.UUID, len(list))
+	for i, uuid := range list {
+		uuids[i] = bluez.UUID(uuid)
+	}
+	return uuids
+}
+
+// toBytes read a data value, as set by the Add* helpers
+func toBytes(v interface{}) ([]byte, error) {
+	switch d := v.(type) {
+	case []byte:
+		return d, nil
+	case string:
+		return []byte(d), nil
+	case dbus.Variant:
+		return toBytes(d.Value())
+	case nil:
+		return []byte{}, nil
+	}
+	return nil, fmt.Errorf("unsupported value type %T", v)
+}
@@ -0,0 +1,97 @@
+package advertising
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+
+	props, payload, err := NewBuilder().
+		Discoverable(true).
+		LocalName("go-bluetooth").
+		ServiceUUID("180f", "180F").
+		ManufacturerData(0xffff, []byte{1, 2, 3}).
+		TxPower().
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, AdvertisementTypePeripheral, props.Type)
+	assert.Equal(t, []string{"0000180f-0000-1000-8000-00805f9b34fb"}, props.ServiceUUIDs)
+	assert.Equal(t, MaxLegacyDataLength, payload.MaxLength)
+
+	// flags, 16 bit UUIDs, manufacturer data and TX power
+	assert.Equal(t, 3+4+7+3, payload.Advertising.Len())
+	name, complete, ok := payload.ScanResponse.LocalName()
+	assert.True(t, ok)
+	assert.True(t, complete)
+	assert.Equal(t, "go-bluetooth", name)
+}
+
+func TestBuilderTooLarge(t *testing.T) {
+
+	b := NewBuilder().
+		ServiceUUID("6e400001-b5a3-f393-e0a9-e50e24dcca9e").
+		ManufacturerData(0xffff, make([]byte, 10))
+
+	_, _, err := b.Build()
+	assert.Error(t, err)
+
+	// extended advertising allows larger data
+	_, payload, err := b.Extended(SecondaryChannel1M).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, MaxExtendedDataLength, payload.MaxLength)
+	assert.Equal(t, 3+18+14, payload.Advertising.Len())
+}
+
+func TestBuilderLongName(t *testing.T) {
+	_, payload, err := NewBuilder().LocalName(strings.Repeat("a", 40)).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, complete, _ := payload.ScanResponse.LocalName()
+	assert.False(t, complete)
+	assert.Equal(t, MaxLegacyDataLength, payload.ScanResponse.Len())
+	assert.Equal(t, strings.Repeat("a", 29), name)
+
+	// 2 bytes characters, the 15th does not fit
+	_, payload, err = NewBuilder().LocalName(strings.Repeat("è", 20)).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, complete, _ = payload.ScanResponse.LocalName()
+	assert.False(t, complete)
+	assert.True(t, utf8.ValidString(name))
+	assert.Equal(t, strings.Repeat("è", 14), name)
+}
+
+func TestValidate(t *testing.T) {
+
+	props := &LEAdvertisement1Properties{
+		Type:         AdvertisementTypeBroadcast,
+		Discoverable: true,
+	}
+	assert.Error(t, props.Validate())
+
+	props = &LEAdvertisement1Properties{
+		LocalName: "go",
+		Includes:  []string{SupportedIncludesLocalName},
+	}
+	assert.Error(t, props.Validate())
+
+	props = &LEAdvertisement1Properties{}
+	props.AddData(ADTypeFlags, []byte{0x06})
+	assert.Error(t, props.Validate())
+
+	props = &LEAdvertisement1Properties{}
+	props.AddData(0x26, []byte{0x01, 0x01})
+	props.AddServiceData("feaa", []byte{0x10})
+	assert.NoError(t, props.Validate())
+}
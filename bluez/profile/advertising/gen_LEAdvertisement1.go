@@ -3,6 +3,7 @@
 package advertising
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var LEAdvertisement1Interface = "org.bluez.LEAdvertisement1"
 // Args:
 // - objectPath: freely definable
 func NewLEAdvertisement1(objectPath dbus.ObjectPath) (*LEAdvertisement1, error) {
+	return NewLEAdvertisement1WithConn(nil, objectPath)
+}
+
+// NewLEAdvertisement1WithConn create a new instance of LEAdvertisement1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewLEAdvertisement1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*LEAdvertisement1, error) {
 	a := new(LEAdvertisement1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewLEAdvertisement1(objectPath dbus.ObjectPath) (*LEAdvertisement1, error)
 			Iface: LEAdvertisement1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(LEAdvertisement1Properties)
@@ -410,7 +418,7 @@ func (a *LEAdvertisement1) GetObjectManagerSignal() (chan *dbus.Signal, func(),
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -530,5 +538,10 @@ Release 			This method gets called when the service daemon
 
 */
 func (a *LEAdvertisement1) Release() error {
-	return a.client.Call("Release", 0).Store()
+	return a.ReleaseContext(context.Background())
+}
+
+// ReleaseContext is like Release, the call is cancelled when ctx is done
+func (a *LEAdvertisement1) ReleaseContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Release", 0).Store()
 }
@@ -3,6 +3,7 @@
 package advertising
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -19,6 +20,12 @@ var LEAdvertisingManager1Interface = "org.bluez.LEAdvertisingManager1"
 // Args:
 // - objectPath: /org/bluez/{hci0,hci1,...}
 func NewLEAdvertisingManager1(objectPath dbus.ObjectPath) (*LEAdvertisingManager1, error) {
+	return NewLEAdvertisingManager1WithConn(nil, objectPath)
+}
+
+// NewLEAdvertisingManager1WithConn create a new instance of LEAdvertisingManager1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewLEAdvertisingManager1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*LEAdvertisingManager1, error) {
 	a := new(LEAdvertisingManager1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewLEAdvertisingManager1(objectPath dbus.ObjectPath) (*LEAdvertisingManager
 			Iface: LEAdvertisingManager1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(LEAdvertisingManager1Properties)
@@ -40,6 +48,12 @@ func NewLEAdvertisingManager1(objectPath dbus.ObjectPath) (*LEAdvertisingManager
 // NewLEAdvertisingManager1FromAdapterID create a new instance of LEAdvertisingManager1
 // adapterID: ID of an adapter eg. hci0
 func NewLEAdvertisingManager1FromAdapterID(adapterID string) (*LEAdvertisingManager1, error) {
+	return NewLEAdvertisingManager1FromAdapterIDWithConn(nil, adapterID)
+}
+
+// NewLEAdvertisingManager1FromAdapterIDWithConn create a new instance of LEAdvertisingManager1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewLEAdvertisingManager1FromAdapterIDWithConn(conn *bluez.Conn, adapterID string) (*LEAdvertisingManager1, error) {
 	a := new(LEAdvertisingManager1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -47,6 +61,7 @@ func NewLEAdvertisingManager1FromAdapterID(adapterID string) (*LEAdvertisingMana
 			Iface: LEAdvertisingManager1Interface,
 			Path:  dbus.ObjectPath(fmt.Sprintf("/org/bluez/%s", adapterID)),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(LEAdvertisingManager1Properties)
@@ -202,7 +217,7 @@ func (a *LEAdvertisingManager1) GetObjectManagerSignal() (chan *dbus.Signal, fun
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -334,7 +349,12 @@ RegisterAdvertisement 			Registers an advertisement object to be sent over the L
 
 */
 func (a *LEAdvertisingManager1) RegisterAdvertisement(advertisement dbus.ObjectPath, options map[string]interface{}) error {
-	return a.client.Call("RegisterAdvertisement", 0, advertisement, options).Store()
+	return a.RegisterAdvertisementContext(context.Background(), advertisement, options)
+}
+
+// RegisterAdvertisementContext is like RegisterAdvertisement, the call is cancelled when ctx is done
+func (a *LEAdvertisingManager1) RegisterAdvertisementContext(ctx context.Context, advertisement dbus.ObjectPath, options map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "RegisterAdvertisement", 0, advertisement, options).Store()
 }
 
 /*
@@ -346,5 +366,10 @@ UnregisterAdvertisement 			This unregisters an advertisement that has been
 
 */
 func (a *LEAdvertisingManager1) UnregisterAdvertisement(advertisement dbus.ObjectPath) error {
-	return a.client.Call("UnregisterAdvertisement", 0, advertisement).Store()
+	return a.UnregisterAdvertisementContext(context.Background(), advertisement)
+}
+
+// UnregisterAdvertisementContext is like UnregisterAdvertisement, the call is cancelled when ctx is done
+func (a *LEAdvertisingManager1) UnregisterAdvertisementContext(ctx context.Context, advertisement dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "UnregisterAdvertisement", 0, advertisement).Store()
 }
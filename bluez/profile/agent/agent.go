@@ -67,8 +67,13 @@ func SetTrusted(adapterID string, devicePath dbus.ObjectPath) error {
 
 // RemoveAgent remove an Agent1 implementation from AgentManager1
 func RemoveAgent(ag Agent1Client) error {
+	return RemoveAgentWithConn(nil, ag)
+}
 
-	am, err := NewAgentManager1()
+// RemoveAgentWithConn remove an Agent1 implementation from AgentManager1 using the given connection
+func RemoveAgentWithConn(conn *bluez.Conn, ag Agent1Client) error {
+
+	am, err := NewAgentManager1WithConn(conn)
 	if err != nil {
 		return fmt.Errorf("NewAgentManager1: %s", err)
 	}
@@ -91,8 +96,32 @@ func ExposeAgent(conn *dbus.Conn, ag Agent1Client, caps string, setAsDefaultAgen
 		return fmt.Errorf("NewAgentManager1: %s", err)
 	}
 
+	return registerAgent(am, conn, ag, caps, setAsDefaultAgent)
+}
+
+// ExposeAgentWithConn expose an Agent1 implementation and register it with the AgentManager1 reachable on conn
+func ExposeAgentWithConn(conn *bluez.Conn, ag Agent1Client, caps string, setAsDefaultAgent bool) error {
+
+	if conn == nil {
+		c, err := bluez.DefaultConn(bluez.SystemBus)
+		if err != nil {
+			return err
+		}
+		conn = c
+	}
+
+	am, err := NewAgentManager1WithConn(conn)
+	if err != nil {
+		return fmt.Errorf("NewAgentManager1: %s", err)
+	}
+
+	return registerAgent(am, conn.DBus(), ag, caps, setAsDefaultAgent)
+}
+
+func registerAgent(am *AgentManager1, conn *dbus.Conn, ag Agent1Client, caps string, setAsDefaultAgent bool) error {
+
 	// Export the Go interface to DBus
-	err = exportAgent(conn, ag)
+	err := exportAgent(conn, ag)
 	if err != nil {
 		return err
 	}
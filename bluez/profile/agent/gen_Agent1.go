@@ -3,6 +3,7 @@
 package agent
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -17,6 +18,12 @@ var Agent1Interface = "org.bluez.Agent1"
 // - servicePath: unique name
 // - objectPath: freely definable
 func NewAgent1(servicePath string, objectPath dbus.ObjectPath) (*Agent1, error) {
+	return NewAgent1WithConn(nil, servicePath, objectPath)
+}
+
+// NewAgent1WithConn create a new instance of Agent1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewAgent1WithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*Agent1, error) {
 	a := new(Agent1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -24,6 +31,7 @@ func NewAgent1(servicePath string, objectPath dbus.ObjectPath) (*Agent1, error)
 			Iface: Agent1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	return a, nil
@@ -82,7 +90,7 @@ func (a *Agent1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -117,7 +125,12 @@ Release 			This method gets called when the service daemon
 
 */
 func (a *Agent1) Release() error {
-	return a.client.Call("Release", 0).Store()
+	return a.ReleaseContext(context.Background())
+}
+
+// ReleaseContext is like Release, the call is cancelled when ctx is done
+func (a *Agent1) ReleaseContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Release", 0).Store()
 }
 
 /*
@@ -130,8 +143,13 @@ RequestPinCode 			This method gets called when the service daemon
 
 */
 func (a *Agent1) RequestPinCode(device dbus.ObjectPath) (string, error) {
+	return a.RequestPinCodeContext(context.Background(), device)
+}
+
+// RequestPinCodeContext is like RequestPinCode, the call is cancelled when ctx is done
+func (a *Agent1) RequestPinCodeContext(ctx context.Context, device dbus.ObjectPath) (string, error) {
 	var val0 string
-	err := a.client.Call("RequestPinCode", 0, device).Store(&val0)
+	err := a.client.CallWithContext(ctx, "RequestPinCode", 0, device).Store(&val0)
 	return val0, err
 }
 
@@ -155,7 +173,12 @@ DisplayPinCode 			This method gets called when the service daemon
 
 */
 func (a *Agent1) DisplayPinCode(device dbus.ObjectPath, pincode string) error {
-	return a.client.Call("DisplayPinCode", 0, device, pincode).Store()
+	return a.DisplayPinCodeContext(context.Background(), device, pincode)
+}
+
+// DisplayPinCodeContext is like DisplayPinCode, the call is cancelled when ctx is done
+func (a *Agent1) DisplayPinCodeContext(ctx context.Context, device dbus.ObjectPath, pincode string) error {
+	return a.client.CallWithContext(ctx, "DisplayPinCode", 0, device, pincode).Store()
 }
 
 /*
@@ -168,8 +191,13 @@ RequestPasskey 			This method gets called when the service daemon
 
 */
 func (a *Agent1) RequestPasskey(device dbus.ObjectPath) (uint32, error) {
+	return a.RequestPasskeyContext(context.Background(), device)
+}
+
+// RequestPasskeyContext is like RequestPasskey, the call is cancelled when ctx is done
+func (a *Agent1) RequestPasskeyContext(ctx context.Context, device dbus.ObjectPath) (uint32, error) {
 	var val0 uint32
-	err := a.client.Call("RequestPasskey", 0, device).Store(&val0)
+	err := a.client.CallWithContext(ctx, "RequestPasskey", 0, device).Store(&val0)
 	return val0, err
 }
 
@@ -189,7 +217,12 @@ DisplayPasskey 			This method gets called when the service daemon
 
 */
 func (a *Agent1) DisplayPasskey(device dbus.ObjectPath, passkey uint32, entered uint16) error {
-	return a.client.Call("DisplayPasskey", 0, device, passkey, entered).Store()
+	return a.DisplayPasskeyContext(context.Background(), device, passkey, entered)
+}
+
+// DisplayPasskeyContext is like DisplayPasskey, the call is cancelled when ctx is done
+func (a *Agent1) DisplayPasskeyContext(ctx context.Context, device dbus.ObjectPath, passkey uint32, entered uint16) error {
+	return a.client.CallWithContext(ctx, "DisplayPasskey", 0, device, passkey, entered).Store()
 }
 
 /*
@@ -205,7 +238,12 @@ RequestConfirmation 			This method gets called when the service daemon
 
 */
 func (a *Agent1) RequestConfirmation(device dbus.ObjectPath, passkey uint32) error {
-	return a.client.Call("RequestConfirmation", 0, device, passkey).Store()
+	return a.RequestConfirmationContext(context.Background(), device, passkey)
+}
+
+// RequestConfirmationContext is like RequestConfirmation, the call is cancelled when ctx is done
+func (a *Agent1) RequestConfirmationContext(ctx context.Context, device dbus.ObjectPath, passkey uint32) error {
+	return a.client.CallWithContext(ctx, "RequestConfirmation", 0, device, passkey).Store()
 }
 
 /*
@@ -221,7 +259,12 @@ RequestAuthorization 			This method gets called to request the user to
 
 */
 func (a *Agent1) RequestAuthorization(device dbus.ObjectPath) error {
-	return a.client.Call("RequestAuthorization", 0, device).Store()
+	return a.RequestAuthorizationContext(context.Background(), device)
+}
+
+// RequestAuthorizationContext is like RequestAuthorization, the call is cancelled when ctx is done
+func (a *Agent1) RequestAuthorizationContext(ctx context.Context, device dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "RequestAuthorization", 0, device).Store()
 }
 
 /*
@@ -232,7 +275,12 @@ AuthorizeService 			This method gets called when the service daemon
 
 */
 func (a *Agent1) AuthorizeService(device dbus.ObjectPath, uuid string) error {
-	return a.client.Call("AuthorizeService", 0, device, uuid).Store()
+	return a.AuthorizeServiceContext(context.Background(), device, uuid)
+}
+
+// AuthorizeServiceContext is like AuthorizeService, the call is cancelled when ctx is done
+func (a *Agent1) AuthorizeServiceContext(ctx context.Context, device dbus.ObjectPath, uuid string) error {
+	return a.client.CallWithContext(ctx, "AuthorizeService", 0, device, uuid).Store()
 }
 
 /*
@@ -241,5 +289,10 @@ Cancel 			This method gets called to indicate that the agent
 
 */
 func (a *Agent1) Cancel() error {
-	return a.client.Call("Cancel", 0).Store()
+	return a.CancelContext(context.Background())
+}
+
+// CancelContext is like Cancel, the call is cancelled when ctx is done
+func (a *Agent1) CancelContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Cancel", 0).Store()
 }
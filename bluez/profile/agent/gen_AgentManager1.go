@@ -3,6 +3,7 @@
 package agent
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -16,6 +17,12 @@ var AgentManager1Interface = "org.bluez.AgentManager1"
 // Args:
 
 func NewAgentManager1() (*AgentManager1, error) {
+	return NewAgentManager1WithConn(nil)
+}
+
+// NewAgentManager1WithConn create a new instance of AgentManager1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewAgentManager1WithConn(conn *bluez.Conn) (*AgentManager1, error) {
 	a := new(AgentManager1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -23,6 +30,7 @@ func NewAgentManager1() (*AgentManager1, error) {
 			Iface: AgentManager1Interface,
 			Path:  dbus.ObjectPath("/org/bluez"),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	return a, nil
@@ -81,7 +89,7 @@ func (a *AgentManager1) GetObjectManagerSignal() (chan *dbus.Signal, func(), err
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -134,7 +142,12 @@ RegisterAgent 			This registers an agent handler.
 
 */
 func (a *AgentManager1) RegisterAgent(agent dbus.ObjectPath, capability string) error {
-	return a.client.Call("RegisterAgent", 0, agent, capability).Store()
+	return a.RegisterAgentContext(context.Background(), agent, capability)
+}
+
+// RegisterAgentContext is like RegisterAgent, the call is cancelled when ctx is done
+func (a *AgentManager1) RegisterAgentContext(ctx context.Context, agent dbus.ObjectPath, capability string) error {
+	return a.client.CallWithContext(ctx, "RegisterAgent", 0, agent, capability).Store()
 }
 
 /*
@@ -145,7 +158,12 @@ UnregisterAgent 			This unregisters the agent that has been previously
 
 */
 func (a *AgentManager1) UnregisterAgent(agent dbus.ObjectPath) error {
-	return a.client.Call("UnregisterAgent", 0, agent).Store()
+	return a.UnregisterAgentContext(context.Background(), agent)
+}
+
+// UnregisterAgentContext is like UnregisterAgent, the call is cancelled when ctx is done
+func (a *AgentManager1) UnregisterAgentContext(ctx context.Context, agent dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "UnregisterAgent", 0, agent).Store()
 }
 
 /*
@@ -158,5 +176,10 @@ RequestDefaultAgent 			This requests is to make the application agent
 
 */
 func (a *AgentManager1) RequestDefaultAgent(agent dbus.ObjectPath) error {
-	return a.client.Call("RequestDefaultAgent", 0, agent).Store()
+	return a.RequestDefaultAgentContext(context.Background(), agent)
+}
+
+// RequestDefaultAgentContext is like RequestDefaultAgent, the call is cancelled when ctx is done
+func (a *AgentManager1) RequestDefaultAgentContext(ctx context.Context, agent dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "RequestDefaultAgent", 0, agent).Store()
 }
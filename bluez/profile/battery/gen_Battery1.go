@@ -18,6 +18,12 @@ var Battery1Interface = "org.bluez.Battery1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX
 func NewBattery1(objectPath dbus.ObjectPath) (*Battery1, error) {
+	return NewBattery1WithConn(nil, objectPath)
+}
+
+// NewBattery1WithConn create a new instance of Battery1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewBattery1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*Battery1, error) {
 	a := new(Battery1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +31,7 @@ func NewBattery1(objectPath dbus.ObjectPath) (*Battery1, error) {
 			Iface: Battery1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Battery1Properties)
@@ -109,7 +116,7 @@ func (a *Battery1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -11,55 +11,31 @@ import (
 )
 
 func NewDevice(adapterID string, address string) (*Device1, error) {
+	return NewDeviceWithConn(nil, adapterID, address)
+}
+
+// NewDeviceWithConn return a device by adapter and address using the given connection
+func NewDeviceWithConn(conn *bluez.Conn, adapterID string, address string) (*Device1, error) {
 	path := fmt.Sprintf("%s/%s/dev_%s", bluez.OrgBluezPath, adapterID, strings.Replace(address, ":", "_", -1))
-	return NewDevice1(dbus.ObjectPath(path))
+	return NewDevice1WithConn(conn, dbus.ObjectPath(path))
 }
 
 // GetCharacteristicsList return device characteristics object path list
 func (d *Device1) GetCharacteristicsList() ([]dbus.ObjectPath, error) {
-
-	var chars []dbus.ObjectPath
-
-	om, err := bluez.GetObjectManager()
-	if err != nil {
-		return nil, err
-	}
-
-	list, err := om.GetManagedObjects()
-	if err != nil {
-		return nil, err
-	}
-
-	for path := range list {
-
-		spath := string(path)
-
-		// log.Debugf("%s=%s", string(d.Path()), spath)
-
-		if !strings.HasPrefix(spath, string(d.Path())) {
-			continue
-		}
-
-		charPos := strings.Index(spath, "char")
-		if charPos == -1 {
-			continue
-		}
-
-		if strings.Contains(spath[charPos:], "desc") {
-			continue
-		}
-
-		chars = append(chars, path)
-	}
-
-	return chars, nil
+	return d.getGattObjectList(gatt.GattCharacteristic1Interface)
 }
 
 // GetDescriptorList returns all descriptors
 func (d *Device1) GetDescriptorList() ([]dbus.ObjectPath, error) {
-	var descr []dbus.ObjectPath
+	return d.getGattObjectList(gatt.GattDescriptor1Interface)
+}
+
+// getGattObjectList return the object paths below the device exposing iface
+func (d *Device1) getGattObjectList(iface string) ([]dbus.ObjectPath, error) {
+
+	var paths []dbus.ObjectPath
 
-	om, err := bluez.GetObjectManager()
+	om, err := d.client.ObjectManager()
 	if err != nil {
 		return nil, err
 	}
@@ -68,27 +44,22 @@ func (d *Device1) GetDescriptorList() ([]dbus.ObjectPath, error) {
 	if err != nil {
 		return nil, err
 	}
-	for path := range list {
 
-		spath := string(path)
+	prefix := string(d.Path()) + "/"
+	for path, ifaces := range list {
 
-		if !strings.HasPrefix(spath, string(d.Path())) {
+		if !strings.HasPrefix(string(path), prefix) {
 			continue
 		}
 
-		charPos := strings.Index(spath, "char")
-		if charPos == -1 {
+		if _, ok := ifaces[iface]; !ok {
 			continue
 		}
 
-		if strings.Contains(spath[charPos:], "desc") {
-			continue
-		}
-
-		descr = append(descr, path)
+		paths = append(paths, path)
 	}
 
-	return descr, nil
+	return paths, nil
 }
 
 //GetDescriptors returns all descriptors for a given characteristic
@@ -100,7 +71,7 @@ func (d *Device1) GetDescriptors(char *gatt.GattCharacteristic1) ([]*gatt.GattDe
 
 	descrFound := []*gatt.GattDescriptor1{}
 	for _, path := range descrPaths {
-		descr, err := gatt.NewGattDescriptor1(path)
+		descr, err := gatt.NewGattDescriptor1WithConn(d.client.Config.Conn, path)
 		if err != nil {
 			return nil, err
 		}
@@ -127,7 +98,7 @@ func (d *Device1) GetCharacteristics() ([]*gatt.GattCharacteristic1, error) {
 	chars := []*gatt.GattCharacteristic1{}
 	for _, path := range list {
 
-		char, err := gatt.NewGattCharacteristic1(path)
+		char, err := gatt.NewGattCharacteristic1WithConn(d.client.Config.Conn, path)
 		if err != nil {
 			return nil, err
 		}
@@ -151,7 +122,7 @@ func (d *Device1) GetAllServicesAndUUID() ([]string, error) {
 	var uuidAndService string
 	for _, path := range list {
 
-		char, err := gatt.NewGattCharacteristic1(path)
+		char, err := gatt.NewGattCharacteristic1WithConn(d.client.Config.Conn, path)
 		if err != nil {
 			return nil, err
 		}
@@ -169,7 +140,7 @@ func (d *Device1) GetAllServicesAndUUID() ([]string, error) {
 }
 
 //GetCharByUUID return a GattService by its uuid, return nil if not found
-func (d *Device1) GetCharByUUID(uuid string) (*gatt.GattCharacteristic1, error) {
+func (d *Device1) GetCharByUUID(uuid bluez.UUID) (*gatt.GattCharacteristic1, error) {
 	devices, err := d.GetCharsByUUID(uuid)
 	if len(devices) > 0 {
 		return devices[0], err
@@ -177,9 +148,8 @@ func (d *Device1) GetCharByUUID(uuid string) (*gatt.GattCharacteristic1, error)
 	return nil, err
 }
 
-// GetCharsByUUID returns all characteristics that match the given UUID.
-func (d *Device1) GetCharsByUUID(uuid string) ([]*gatt.GattCharacteristic1, error) {
-	uuid = strings.ToUpper(uuid)
+// GetCharsByUUID returns all characteristics that match the given UUID, in any form.
+func (d *Device1) GetCharsByUUID(uuid bluez.UUID) ([]*gatt.GattCharacteristic1, error) {
 
 	list, err := d.GetCharacteristicsList()
 	if err != nil {
@@ -190,14 +160,12 @@ func (d *Device1) GetCharsByUUID(uuid string) ([]*gatt.GattCharacteristic1, erro
 
 	for _, path := range list {
 
-		char, err := gatt.NewGattCharacteristic1(path)
+		char, err := gatt.NewGattCharacteristic1WithConn(d.client.Config.Conn, path)
 		if err != nil {
 			return nil, err
 		}
 
-		cuuid := strings.ToUpper(char.Properties.UUID)
-
-		if cuuid == uuid {
+		if uuid.Equal(bluez.UUID(char.Properties.UUID)) {
 			charsFound = append(charsFound, char)
 		}
 	}
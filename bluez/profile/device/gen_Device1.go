@@ -3,6 +3,7 @@
 package device
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var Device1Interface = "org.bluez.Device1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX
 func NewDevice1(objectPath dbus.ObjectPath) (*Device1, error) {
+	return NewDevice1WithConn(nil, objectPath)
+}
+
+// NewDevice1WithConn create a new instance of Device1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewDevice1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*Device1, error) {
 	a := new(Device1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewDevice1(objectPath dbus.ObjectPath) (*Device1, error) {
 			Iface: Device1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Device1Properties)
@@ -588,7 +596,7 @@ func (a *Device1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -725,7 +733,12 @@ Connect 			This is a generic method to connect any profiles
 
 */
 func (a *Device1) Connect() error {
-	return a.client.Call("Connect", 0).Store()
+	return a.ConnectContext(context.Background())
+}
+
+// ConnectContext is like Connect, the call is cancelled when ctx is done
+func (a *Device1) ConnectContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Connect", 0).Store()
 }
 
 /*
@@ -743,7 +756,12 @@ Disconnect 			This method gracefully disconnects all connected
 
 */
 func (a *Device1) Disconnect() error {
-	return a.client.Call("Disconnect", 0).Store()
+	return a.DisconnectContext(context.Background())
+}
+
+// DisconnectContext is like Disconnect, the call is cancelled when ctx is done
+func (a *Device1) DisconnectContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Disconnect", 0).Store()
 }
 
 /*
@@ -758,7 +776,12 @@ ConnectProfile 			This method connects a specific profile of this
 
 */
 func (a *Device1) ConnectProfile(uuid string) error {
-	return a.client.Call("ConnectProfile", 0, uuid).Store()
+	return a.ConnectProfileContext(context.Background(), uuid)
+}
+
+// ConnectProfileContext is like ConnectProfile, the call is cancelled when ctx is done
+func (a *Device1) ConnectProfileContext(ctx context.Context, uuid string) error {
+	return a.client.CallWithContext(ctx, "ConnectProfile", 0, uuid).Store()
 }
 
 /*
@@ -775,7 +798,12 @@ DisconnectProfile 			This method disconnects a specific profile of
 
 */
 func (a *Device1) DisconnectProfile(uuid string) error {
-	return a.client.Call("DisconnectProfile", 0, uuid).Store()
+	return a.DisconnectProfileContext(context.Background(), uuid)
+}
+
+// DisconnectProfileContext is like DisconnectProfile, the call is cancelled when ctx is done
+func (a *Device1) DisconnectProfileContext(ctx context.Context, uuid string) error {
+	return a.client.CallWithContext(ctx, "DisconnectProfile", 0, uuid).Store()
 }
 
 /*
@@ -802,7 +830,12 @@ Pair 			This method will connect to the remote device,
 
 */
 func (a *Device1) Pair() error {
-	return a.client.Call("Pair", 0).Store()
+	return a.PairContext(context.Background())
+}
+
+// PairContext is like Pair, the call is cancelled when ctx is done
+func (a *Device1) PairContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Pair", 0).Store()
 }
 
 /*
@@ -813,5 +846,10 @@ CancelPairing 			This method can be used to cancel a pairing
 
 */
 func (a *Device1) CancelPairing() error {
-	return a.client.Call("CancelPairing", 0).Store()
+	return a.CancelPairingContext(context.Background())
+}
+
+// CancelPairingContext is like CancelPairing, the call is cancelled when ctx is done
+func (a *Device1) CancelPairingContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "CancelPairing", 0).Store()
 }
@@ -20,6 +20,7 @@ const (
 	FlagCharacteristicEncryptAuthenticatedWrite = "encrypt-authenticated-write"
 	FlagCharacteristicSecureRead                = "secure-read"
 	FlagCharacteristicSecureWrite               = "secure-write"
+	FlagCharacteristicAuthorize                 = "authorize"
 )
 
 // Descriptor specific flags
@@ -32,4 +33,5 @@ const (
 	FlagDescriptorEncryptAuthenticatedWrite = "encrypt-authenticated-write"
 	FlagDescriptorSecureRead                = "secure-read"
 	FlagDescriptorSecureWrite               = "secure-write"
+	FlagDescriptorAuthorize                 = "authorize"
 )
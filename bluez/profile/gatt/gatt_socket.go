@@ -0,0 +1,172 @@
+package gatt
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// DefaultMTU is used when the MTU of an acquired socket is unknown
+const DefaultMTU = 23
+
+// ErrMTUExceeded is returned when writing a packet larger than the socket MTU
+var ErrMTUExceeded = errors.New("Packet exceeds MTU")
+
+// Socket is the SEQPACKET socket returned by AcquireWrite and AcquireNotify.
+// Each Write sends a single packet of up to MTU bytes, each Read receives
+// a single packet: use a buffer of at least MTU bytes to avoid truncation.
+type Socket struct {
+	file *os.File
+	mtu  uint16
+
+	// remote is the socket pair fd returned to bluez, -1 once closed
+	remoteLock sync.Mutex
+	remote     int
+	written    bool
+}
+
+// NewSocket wrap an acquired fd. The socket is set in non-blocking mode to allow Close to interrupt reads
+func NewSocket(fd dbus.UnixFD, mtu uint16) (*Socket, error) {
+	err := syscall.SetNonblock(int(fd), true)
+	if err != nil {
+		syscall.Close(int(fd))
+		return nil, err
+	}
+	if mtu == 0 {
+		mtu = DefaultMTU
+	}
+	return &Socket{
+		file:   os.NewFile(uintptr(fd), "gatt"),
+		mtu:    mtu,
+		remote: -1,
+	}, nil
+}
+
+// NewSocketPair create a connected socket pair, returning the local Socket and the fd to
+// return to bluez from a server AcquireWrite or AcquireNotify implementation.
+// The DBus reply carrying fd is sent after the method returns, so the Socket keeps fd
+// open until bluez owns its copy: when a packet is received from bluez, when bluez
+// read a written packet or when the Socket is closed. Until then a close by bluez is not
+// reported by Read and Write
+func NewSocketPair(mtu uint16) (*Socket, dbus.UnixFD, error) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_SEQPACKET|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sock, err := NewSocket(dbus.UnixFD(fds[0]), mtu)
+	if err != nil {
+		syscall.Close(fds[1])
+		return nil, 0, err
+	}
+	sock.remote = fds[1]
+
+	return sock, dbus.UnixFD(fds[1]), nil
+}
+
+// releaseRemote close the fd returned to bluez, if still open
+func (s *Socket) releaseRemote() {
+	s.remoteLock.Lock()
+	defer s.remoteLock.Unlock()
+	if s.remote == -1 {
+		return
+	}
+	syscall.Close(s.remote)
+	s.remote = -1
+}
+
+// checkRemote close the fd returned to bluez once the packets written have been read
+func (s *Socket) checkRemote() {
+	s.remoteLock.Lock()
+	check := s.remote != -1 && s.written
+	s.remoteLock.Unlock()
+	if !check {
+		return
+	}
+
+	rc, err := s.file.SyscallConn()
+	if err != nil {
+		return
+	}
+	var queued int32 = -1
+	rc.Control(func(fd uintptr) {
+		_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCOUTQ, uintptr(unsafe.Pointer(&queued)))
+		if errno != 0 {
+			queued = -1
+		}
+	})
+	if queued == 0 {
+		s.releaseRemote()
+	}
+}
+
+// MTU return the maximum packet size
+func (s *Socket) MTU() uint16 {
+	return s.mtu
+}
+
+// Read a packet. io.EOF is returned when the remote end closed the socket
+func (s *Socket) Read(p []byte) (int, error) {
+	n, err := s.file.Read(p)
+	if err == nil {
+		s.releaseRemote()
+	}
+	return n, err
+}
+
+// Write a packet, ErrMTUExceeded is returned if p is larger than the MTU
+func (s *Socket) Write(p []byte) (int, error) {
+	if len(p) > int(s.mtu) {
+		return 0, ErrMTUExceeded
+	}
+	s.checkRemote()
+	n, err := s.file.Write(p)
+	if err == nil {
+		s.remoteLock.Lock()
+		s.written = true
+		s.remoteLock.Unlock()
+	}
+	return n, err
+}
+
+// SetDeadline set the read and write deadline
+func (s *Socket) SetDeadline(t time.Time) error {
+	return s.file.SetDeadline(t)
+}
+
+// Close the socket, releasing the acquired write or notify lock
+func (s *Socket) Close() error {
+	s.releaseRemote()
+	return s.file.Close()
+}
+
+// AcquireWriteSocket acquire a socket to write the characteristic value without response
+func (a *GattCharacteristic1) AcquireWriteSocket(ctx context.Context, options map[string]interface{}) (*Socket, error) {
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+	fd, mtu, err := a.AcquireWriteContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	return NewSocket(fd, mtu)
+}
+
+// AcquireNotifySocket acquire a socket receiving the characteristic notifications.
+// Notifications stop when the socket is closed
+func (a *GattCharacteristic1) AcquireNotifySocket(ctx context.Context, options map[string]interface{}) (*Socket, error) {
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+	fd, mtu, err := a.AcquireNotifyContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	return NewSocket(fd, mtu)
+}
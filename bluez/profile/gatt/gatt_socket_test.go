@@ -0,0 +1,209 @@
+package gatt
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/bluez/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcquireWriteSocket(t *testing.T) {
+	_, _, fc := startFake(t)
+
+	char, err := NewGattCharacteristic1(fc.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = char.AcquireWriteSocket(context.Background(), nil)
+	assert.True(t, errors.Is(err, bluez.ErrNotSupported))
+
+	files := make(chan *os.File, 1)
+	fc.OnAcquireWrite(func(file *os.File, options map[string]dbus.Variant) {
+		files <- file
+	})
+
+	sock, err := char.AcquireWriteSocket(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := <-files
+	defer file.Close()
+
+	assert.Equal(t, fake.MTU, sock.MTU())
+
+	_, err = sock.Write([]byte{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sock.Write(make([]byte, fake.MTU+1))
+	assert.Equal(t, ErrMTUExceeded, err)
+
+	buf := make([]byte, fake.MTU)
+	n, err := file.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{1, 2, 3}, buf[:n])
+
+	// closing the socket releases the acquired lock
+	err = sock.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = file.Read(buf)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestAcquireNotifySocket(t *testing.T) {
+	_, _, fc := startFake(t)
+
+	char, err := NewGattCharacteristic1(fc.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(chan *os.File, 1)
+	fc.OnAcquireNotify(func(file *os.File, options map[string]dbus.Variant) {
+		files <- file
+	})
+
+	sock, err := char.AcquireNotifySocket(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sock.Close()
+	file := <-files
+
+	_, err = file.Write([]byte{4, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, sock.MTU())
+	n, err := sock.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{4, 5}, buf[:n])
+
+	// bluez closes the socket on disconnection
+	file.Close()
+	_, err = sock.Read(buf)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestSubscribeAcquireNotify(t *testing.T) {
+	_, _, fc := startFake(t)
+
+	files := make(chan *os.File, 1)
+	fc.OnAcquireNotify(func(file *os.File, options map[string]dbus.Variant) {
+		files <- file
+	})
+
+	char, err := NewGattCharacteristic1(fc.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := char.Subscribe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := <-files
+	defer file.Close()
+	assert.False(t, fc.Get("Notifying").(bool))
+
+	_, err = file.Write([]byte{6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{6}, receive(t, ch))
+
+	cancel()
+	waitClosed(t, ch)
+}
+
+// bluezSocket return a copy of a socket pair fd, as received by bluez
+func bluezSocket(t *testing.T, fd dbus.UnixFD) *Socket {
+	dup, err := syscall.Dup(int(fd))
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote, err := NewSocket(dbus.UnixFD(dup), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return remote
+}
+
+func TestNewSocketPair(t *testing.T) {
+
+	local, fd, err := NewSocketPair(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer local.Close()
+	remote := bluezSocket(t, fd)
+
+	_, err = remote.Write([]byte{1, 2, 3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, local.MTU())
+	n, err := local.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{1, 2, 3, 4}, buf[:n])
+
+	_, err = local.Write([]byte{1, 2, 3, 4, 5})
+	assert.Equal(t, ErrMTUExceeded, err)
+
+	// the fd returned to bluez has been closed on read, bluez closing its copy is reported
+	remote.Close()
+	local.SetDeadline(time.Now().Add(time.Second * 2))
+	_, err = local.Read(buf)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestNewSocketPairWrite(t *testing.T) {
+
+	local, fd, err := NewSocketPair(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer local.Close()
+	remote := bluezSocket(t, fd)
+
+	_, err = local.Write([]byte{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, remote.MTU())
+	_, err = remote.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the fd returned to bluez is closed once a written packet has been read
+	_, err = local.Write([]byte{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote.Close()
+	local.SetDeadline(time.Now().Add(time.Second * 2))
+	_, err = local.Read(buf)
+	assert.Error(t, err)
+	assert.False(t, os.IsTimeout(err))
+}
@@ -0,0 +1,402 @@
+package gatt
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
+	log "github.com/sirupsen/logrus"
+)
+
+// SubscribeQueueSize is the number of values buffered for each subscriber
+var SubscribeQueueSize = 16
+
+// StopNotifyTimeout is the maximum time waited for StopNotify when a notification session ends
+var StopNotifyTimeout = 5 * time.Second
+
+// subscriptionKey identify a notification session of a characteristic on a connection
+type subscriptionKey struct {
+	conn *bluez.Conn
+	path dbus.ObjectPath
+}
+
+// subscriptionsLock guards the subscriptions map only, the D-Bus calls starting
+// and stopping a session are done without holding it
+var (
+	subscriptionsLock sync.Mutex
+	subscriptions     = map[subscriptionKey]*subscription{}
+)
+
+// subscriber receives the values of a notification session
+type subscriber struct {
+	ch   chan []byte
+	quit chan struct{}
+}
+
+// subscription is a notification session shared by the subscribers of a characteristic
+type subscription struct {
+	key  subscriptionKey
+	char *GattCharacteristic1
+
+	lock        sync.Mutex
+	subscribers map[*subscriber]bool
+	// closing is set when the session is being stopped and can not be joined anymore
+	closing bool
+
+	// started is closed when the session start completed, err is set when it failed
+	started chan struct{}
+	err     error
+	// stopped is closed when the session is stopped and removed from subscriptions
+	stopped chan struct{}
+
+	// done is closed when the session ends
+	done     chan struct{}
+	doneOnce sync.Once
+	// disconnected is set when the session ended as the device disconnected
+	disconnected bool
+
+	// sock is the AcquireNotify socket, nil when using StartNotify
+	sock *Socket
+	// signals PropertiesChanged of the characteristic and its device, when using StartNotify
+	signals    chan *dbus.Signal
+	devicePath dbus.ObjectPath
+}
+
+// Subscribe enable notifications and return a channel receiving the characteristic values.
+// AcquireNotify is used when supported by the characteristic, StartNotify otherwise.
+// Subscribers of the same characteristic share the notification session, which is
+// stopped when the last subscriber context is done. The channel is closed
+// when ctx is done or the device disconnects. Each subscriber buffers up to
+// SubscribeQueueSize values, a full buffer delays the delivery to the other subscribers.
+func (a *GattCharacteristic1) Subscribe(ctx context.Context) (<-chan []byte, error) {
+
+	conn, err := a.client.Conn()
+	if err != nil {
+		return nil, err
+	}
+
+	key := subscriptionKey{conn, a.Path()}
+
+	for {
+		subscriptionsLock.Lock()
+		s, ok := subscriptions[key]
+		if !ok {
+			// a pending session, the other subscribers wait for it to start
+			s = newSubscription(a, key)
+			subscriptions[key] = s
+		}
+		subscriptionsLock.Unlock()
+
+		if !ok {
+			s.start(ctx)
+		}
+
+		select {
+		case <-s.started:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if s.err != nil {
+			if !ok {
+				return nil, s.err
+			}
+			// the session failed to start for another subscriber, try again
+			continue
+		}
+
+		sub := s.add()
+		if sub == nil {
+			// the session is ending, eg. on disconnection. A new one is
+			// started once it is stopped
+			select {
+			case <-s.stopped:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			continue
+		}
+
+		go func() {
+			select {
+			case <-ctx.Done():
+			case <-s.done:
+			}
+			s.remove(sub)
+		}()
+
+		return sub.ch, nil
+	}
+}
+
+// newSubscription create a pending notification session
+func newSubscription(a *GattCharacteristic1, key subscriptionKey) *subscription {
+	return &subscription{
+		key:         key,
+		char:        a,
+		subscribers: map[*subscriber]bool{},
+		started:     make(chan struct{}),
+		stopped:     make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// start the notification session, removing it from subscriptions on failure
+func (s *subscription) start(ctx context.Context) {
+	s.err = s.char.startSubscription(ctx, s)
+	if s.err != nil {
+		s.release()
+	}
+	close(s.started)
+}
+
+// startSubscription start a notification session
+func (a *GattCharacteristic1) startSubscription(ctx context.Context, s *subscription) error {
+
+	if hasFlag(a.Properties.Flags, FlagCharacteristicNotify) {
+		sock, err := a.AcquireNotifySocket(ctx, nil)
+		if err == nil {
+			s.sock = sock
+			go s.readSocket(sock)
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		log.Debugf("%s: AcquireNotify failed, using StartNotify: %s", a.Path(), err)
+	}
+
+	signals, err := a.client.Register(a.Path(), bluez.PropertiesInterface)
+	if err != nil {
+		return err
+	}
+	s.signals = signals
+
+	// the notification session ends when the device disconnects
+	spath := string(a.Path())
+	if idx := strings.Index(spath, "/service"); idx > -1 {
+		s.devicePath = dbus.ObjectPath(spath[:idx])
+		// signals are delivered to all the registered channels, only the match is needed
+		err = a.client.AddMatch(s.devicePath, bluez.PropertiesInterface)
+		if err != nil {
+			s.devicePath = ""
+			s.unregister()
+			return err
+		}
+	}
+
+	err = a.StartNotifyContext(ctx)
+	if err != nil {
+		s.unregister()
+		return err
+	}
+
+	go s.readSignals()
+	return nil
+}
+
+// add a subscriber, return nil when the session is ending
+func (s *subscription) add() *subscriber {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closing {
+		return nil
+	}
+	select {
+	case <-s.done:
+		// do not join a session which ended, stop it if nobody else will
+		if len(s.subscribers) == 0 {
+			s.closing = true
+			go s.stop()
+		}
+		return nil
+	default:
+	}
+
+	sub := &subscriber{
+		ch:   make(chan []byte, SubscribeQueueSize),
+		quit: make(chan struct{}),
+	}
+	s.subscribers[sub] = true
+	return sub
+}
+
+// remove a subscriber, stopping the session when it was the last one
+func (s *subscription) remove(sub *subscriber) {
+
+	// unblock pending sends to the subscriber
+	close(sub.quit)
+
+	s.lock.Lock()
+	delete(s.subscribers, sub)
+	close(sub.ch)
+	last := len(s.subscribers) == 0 && !s.closing
+	if last {
+		s.closing = true
+	}
+	s.lock.Unlock()
+
+	if last {
+		s.stop()
+	}
+}
+
+// release remove the session from subscriptions, allowing a new one to start
+func (s *subscription) release() {
+	subscriptionsLock.Lock()
+	if subscriptions[s.key] == s {
+		delete(subscriptions, s.key)
+	}
+	subscriptionsLock.Unlock()
+	close(s.stopped)
+}
+
+// publish a value to all the subscribers
+func (s *subscription) publish(value []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for sub := range s.subscribers {
+		select {
+		case sub.ch <- append([]byte{}, value...):
+		case <-sub.quit:
+		}
+	}
+}
+
+// end mark the session as ended, closing the subscribers channels
+func (s *subscription) end() {
+	s.doneOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+// stop the notification session. The session stays in subscriptions until
+// stopped, so a new session of the same characteristic starts after it
+func (s *subscription) stop() {
+	s.end()
+	s.stopSession()
+	s.release()
+}
+
+// stopSession close the notify socket or call StopNotify
+func (s *subscription) stopSession() {
+
+	if s.sock != nil {
+		err := s.sock.Close()
+		if err != nil {
+			log.Debugf("%s: close notify socket: %s", s.char.Path(), err)
+		}
+		return
+	}
+
+	s.unregister()
+
+	s.lock.Lock()
+	disconnected := s.disconnected
+	s.lock.Unlock()
+	if disconnected {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), StopNotifyTimeout)
+	defer cancel()
+	err := s.char.StopNotifyContext(ctx)
+	if err != nil {
+		log.Debugf("%s: StopNotify: %s", s.char.Path(), err)
+	}
+}
+
+// unregister the signals of the StartNotify session
+func (s *subscription) unregister() {
+	client := s.char.client
+	if s.signals != nil {
+		client.Unregister(s.char.Path(), bluez.PropertiesInterface, s.signals)
+	}
+	if s.devicePath != "" {
+		client.RemoveMatch(s.devicePath, bluez.PropertiesInterface)
+	}
+}
+
+// readSocket publish the packets received on the AcquireNotify socket
+func (s *subscription) readSocket(sock *Socket) {
+	defer s.end()
+
+	buf := make([]byte, sock.MTU())
+	for {
+		n, err := sock.Read(buf)
+		if err != nil {
+			if err != io.EOF {
+				log.Debugf("%s: read notify socket: %s", s.char.Path(), err)
+			}
+			return
+		}
+		s.publish(buf[:n])
+	}
+}
+
+// readSignals publish the Value changes and ends the session on device disconnection
+func (s *subscription) readSignals() {
+	defer s.end()
+
+	for {
+		var sig *dbus.Signal
+		var ok bool
+		select {
+		case sig, ok = <-s.signals:
+		case <-s.done:
+			return
+		}
+		if !ok {
+			return
+		}
+
+		path, changes := parsePropertiesChanged(sig)
+		if changes == nil {
+			continue
+		}
+
+		switch path {
+		case s.char.Path():
+			if v, ok := changes["Value"]; ok {
+				if value, ok := v.Value().([]byte); ok {
+					s.publish(value)
+				}
+			}
+		case s.devicePath:
+			if v, ok := changes["Connected"]; ok {
+				if connected, ok := v.Value().(bool); ok && !connected {
+					s.lock.Lock()
+					s.disconnected = true
+					s.lock.Unlock()
+					return
+				}
+			}
+		}
+	}
+}
+
+// parsePropertiesChanged return the path and changed properties of a PropertiesChanged signal
+func parsePropertiesChanged(sig *dbus.Signal) (dbus.ObjectPath, map[string]dbus.Variant) {
+	if sig == nil || sig.Name != bluez.PropertiesChanged || len(sig.Body) < 2 {
+		return "", nil
+	}
+	changes, ok := sig.Body[1].(map[string]dbus.Variant)
+	if !ok {
+		return "", nil
+	}
+	return sig.Path, changes
+}
+
+// hasFlag check if a flag is in the list
+func hasFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,258 @@
+package gatt
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+func startFake(t *testing.T) (*fake.Bluez, *fake.Device, *fake.Characteristic) {
+	b, fa := fake.StartAdapterT(t, "hci0", nil)
+	fd, err := fa.AddDevice("11:22:33:44:55:66", map[string]interface{}{"Connected": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs, err := fd.AddService("0000180f-0000-1000-8000-00805f9b34fb", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc, err := fs.AddCharacteristic("00002a19-0000-1000-8000-00805f9b34fb", []string{"read", "notify"}, []byte{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b, fd, fc
+}
+
+func receive(t *testing.T, ch <-chan []byte) []byte {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second * 2):
+		t.Fatal("Value not received")
+	}
+	return nil
+}
+
+func waitClosed(t *testing.T, ch <-chan []byte) {
+	t.Helper()
+	timeout := time.After(time.Second * 2)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Channel not closed")
+		}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	_, _, fc := startFake(t)
+
+	char, err := NewGattCharacteristic1(fc.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	ch1, err := char.Subscribe(ctx1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	ch2, err := char.Subscribe(ctx2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, fc.Get("Notifying").(bool))
+
+	err = fc.Notify([]byte{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{1}, receive(t, ch1))
+	assert.Equal(t, []byte{1}, receive(t, ch2))
+
+	cancel1()
+	waitClosed(t, ch1)
+	assert.True(t, fc.Get("Notifying").(bool))
+
+	err = fc.Notify([]byte{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{2}, receive(t, ch2))
+
+	cancel2()
+	waitClosed(t, ch2)
+
+	assert.Eventually(t, func() bool {
+		return !fc.Get("Notifying").(bool)
+	}, time.Second*2, time.Millisecond*10)
+}
+
+func TestSubscribeDisconnect(t *testing.T) {
+	_, fd, fc := startFake(t)
+
+	char, err := NewGattCharacteristic1(fc.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch, err := char.Subscribe(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = fd.Set("Connected", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitClosed(t, ch)
+
+	// a new subscription starts a new session
+	err = fd.Set("Connected", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err = char.Subscribe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fc.Notify([]byte{3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{3}, receive(t, ch))
+}
+
+func TestSubscribeNotifySocket(t *testing.T) {
+
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_SEQPACKET, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer syscall.Close(fds[1])
+
+	sock, err := NewSocket(dbus.UnixFD(fds[0]), 23)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &subscription{
+		char:        &GattCharacteristic1{},
+		subscribers: map[*subscriber]bool{},
+		done:        make(chan struct{}),
+		sock:        sock,
+	}
+	sub := s.add()
+	go s.readSocket(sock)
+
+	_, err = syscall.Write(fds[1], []byte{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = syscall.Write(fds[1], []byte{3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []byte{1, 2}, receive(t, sub.ch))
+	assert.Equal(t, []byte{3}, receive(t, sub.ch))
+
+	// the session ends when bluez closes the socket
+	syscall.Close(fds[1])
+	select {
+	case <-s.done:
+	case <-time.After(time.Second * 2):
+		t.Fatal("Session not ended")
+	}
+	sock.Close()
+}
+
+func TestSubscribeBlockingCalls(t *testing.T) {
+	_, fd, fc1 := startFake(t)
+	fs, err := fd.AddService("0000180d-0000-1000-8000-00805f9b34fb", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc2, err := fs.AddCharacteristic("00002a37-0000-1000-8000-00805f9b34fb", []string{"notify"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	char1, err := NewGattCharacteristic1(fc1.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	char2, err := NewGattCharacteristic1(fc2.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a StartNotify waiting for the peripheral does not block other characteristics
+	unblock := make(chan struct{})
+	fc1.OnCall("StartNotify", func() *dbus.Error {
+		<-unblock
+		return nil
+	})
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	res := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := char1.Subscribe(ctx1)
+			res <- err
+		}()
+	}
+
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	ch2, err := char2.Subscribe(ctx2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-res:
+		t.Fatal("Subscribe returned before StartNotify completed")
+	default:
+	}
+
+	// the pending subscribers share the session once started
+	close(unblock)
+	assert.NoError(t, <-res)
+	assert.NoError(t, <-res)
+
+	// StopNotify is bounded by StopNotifyTimeout
+	timeout := StopNotifyTimeout
+	StopNotifyTimeout = time.Millisecond * 100
+	defer func() {
+		StopNotifyTimeout = timeout
+	}()
+	stop := make(chan struct{})
+	defer close(stop)
+	fc2.OnCall("StopNotify", func() *dbus.Error {
+		<-stop
+		return nil
+	})
+	cancel2()
+	waitClosed(t, ch2)
+
+	ctx3, cancel3 := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel3()
+	_, err = char2.Subscribe(ctx3)
+	assert.NoError(t, err)
+}
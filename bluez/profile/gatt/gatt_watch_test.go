@@ -0,0 +1,98 @@
+package gatt
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/muka/go-bluetooth/bluez/fake"
+)
+
+// TestWatchPropertiesLeak subscribe and unsubscribe repeatedly, as fast as possible,
+// see https://github.com/muka/go-bluetooth/issues/113
+func TestWatchPropertiesLeak(t *testing.T) {
+	_, _, fc := startFake(t)
+
+	char, err := NewGattCharacteristic1(fc.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer char.Close()
+
+	// warm up the shared connection
+	_, err = char.GetNotifying()
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := runtime.NumGoroutine()
+
+	for i := 0; i < 100; i++ {
+		ch, err := char.WatchProperties()
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = char.StartNotify()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// changes are emitted while the consumer goes away
+		fc.Notify([]byte{byte(i)})
+		if i%2 == 0 {
+			go func() {
+				for range ch {
+				}
+			}()
+		}
+
+		err = char.UnwatchProperties(ch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, ok := <-ch
+		for ok {
+			_, ok = <-ch
+		}
+		err = char.StopNotify()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fake.WaitGoroutines(t, start)
+	// the channel is not watched anymore
+	err = char.UnwatchProperties(nil)
+	if err == nil {
+		t.Fatal("Expected UnwatchProperties to fail")
+	}
+}
+
+func TestWatchPropertiesReplace(t *testing.T) {
+	_, _, fc := startFake(t)
+
+	char, err := NewGattCharacteristic1(fc.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer char.Close()
+
+	ch1, err := char.WatchProperties()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// watching again ends the previous watch
+	ch2, err := char.WatchProperties()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range ch1 {
+	}
+
+	err = char.UnwatchProperties(ch2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ok := <-ch2
+	if ok {
+		t.Fatal("Expected the channel to be closed")
+	}
+}
@@ -3,6 +3,7 @@
 package gatt
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var GattCharacteristic1Interface = "org.bluez.GattCharacteristic1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX/serviceXX/charYYYY
 func NewGattCharacteristic1(objectPath dbus.ObjectPath) (*GattCharacteristic1, error) {
+	return NewGattCharacteristic1WithConn(nil, objectPath)
+}
+
+// NewGattCharacteristic1WithConn create a new instance of GattCharacteristic1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewGattCharacteristic1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*GattCharacteristic1, error) {
 	a := new(GattCharacteristic1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewGattCharacteristic1(objectPath dbus.ObjectPath) (*GattCharacteristic1, e
 			Iface: GattCharacteristic1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(GattCharacteristic1Properties)
@@ -106,7 +114,7 @@ type GattCharacteristic1Properties struct {
 				For server the presence of this property indicates
 				that AcquireNotify is supported.
 	*/
-	NotifyAcquired bool `dbus:"ignore"`
+	NotifyAcquired bool `dbus:"omitEmpty"`
 
 	/*
 		Notifying True, if notifications or indications on this
@@ -143,7 +151,7 @@ type GattCharacteristic1Properties struct {
 				For server the presence of this property indicates
 				that AcquireWrite is supported.
 	*/
-	WriteAcquired bool `dbus:"ignore"`
+	WriteAcquired bool `dbus:"omitEmpty"`
 }
 
 //Lock access to properties
@@ -273,7 +281,7 @@ func (a *GattCharacteristic1) GetObjectManagerSignal() (chan *dbus.Signal, func(
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -400,8 +408,13 @@ ReadValue 			Issues a request to read the value of the
 
 */
 func (a *GattCharacteristic1) ReadValue(options map[string]interface{}) ([]byte, error) {
+	return a.ReadValueContext(context.Background(), options)
+}
+
+// ReadValueContext is like ReadValue, the call is cancelled when ctx is done
+func (a *GattCharacteristic1) ReadValueContext(ctx context.Context, options map[string]interface{}) ([]byte, error) {
 	val0 := []byte{}
-	err := a.client.Call("ReadValue", 0, options).Store(&val0)
+	err := a.client.CallWithContext(ctx, "ReadValue", 0, options).Store(&val0)
 	return val0, err
 }
 
@@ -430,7 +443,12 @@ WriteValue 			Issues a request to write the value of the
 
 */
 func (a *GattCharacteristic1) WriteValue(value []byte, options map[string]interface{}) error {
-	return a.client.Call("WriteValue", 0, value, options).Store()
+	return a.WriteValueContext(context.Background(), value, options)
+}
+
+// WriteValueContext is like WriteValue, the call is cancelled when ctx is done
+func (a *GattCharacteristic1) WriteValueContext(ctx context.Context, value []byte, options map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "WriteValue", 0, value, options).Store()
 }
 
 /*
@@ -458,9 +476,14 @@ AcquireWrite 			Acquire file descriptor and MTU for writing. Only
 
 */
 func (a *GattCharacteristic1) AcquireWrite(options map[string]interface{}) (dbus.UnixFD, uint16, error) {
+	return a.AcquireWriteContext(context.Background(), options)
+}
+
+// AcquireWriteContext is like AcquireWrite, the call is cancelled when ctx is done
+func (a *GattCharacteristic1) AcquireWriteContext(ctx context.Context, options map[string]interface{}) (dbus.UnixFD, uint16, error) {
 	var val0 dbus.UnixFD
 	var val1 uint16
-	err := a.client.Call("AcquireWrite", 0, options).Store(&val0, &val1)
+	err := a.client.CallWithContext(ctx, "AcquireWrite", 0, options).Store(&val0, &val1)
 	return val0, val1, err
 }
 
@@ -494,9 +517,14 @@ AcquireNotify 			Acquire file descriptor and MTU for notify. Only
 
 */
 func (a *GattCharacteristic1) AcquireNotify(options map[string]interface{}) (dbus.UnixFD, uint16, error) {
+	return a.AcquireNotifyContext(context.Background(), options)
+}
+
+// AcquireNotifyContext is like AcquireNotify, the call is cancelled when ctx is done
+func (a *GattCharacteristic1) AcquireNotifyContext(ctx context.Context, options map[string]interface{}) (dbus.UnixFD, uint16, error) {
 	var val0 dbus.UnixFD
 	var val1 uint16
-	err := a.client.Call("AcquireNotify", 0, options).Store(&val0, &val1)
+	err := a.client.CallWithContext(ctx, "AcquireNotify", 0, options).Store(&val0, &val1)
 	return val0, val1, err
 }
 
@@ -510,7 +538,12 @@ StartNotify 			Starts a notification session from this characteristic
 
 */
 func (a *GattCharacteristic1) StartNotify() error {
-	return a.client.Call("StartNotify", 0).Store()
+	return a.StartNotifyContext(context.Background())
+}
+
+// StartNotifyContext is like StartNotify, the call is cancelled when ctx is done
+func (a *GattCharacteristic1) StartNotifyContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "StartNotify", 0).Store()
 }
 
 /*
@@ -522,7 +555,12 @@ StopNotify 			This method will cancel any previous StartNotify
 
 */
 func (a *GattCharacteristic1) StopNotify() error {
-	return a.client.Call("StopNotify", 0).Store()
+	return a.StopNotifyContext(context.Background())
+}
+
+// StopNotifyContext is like StopNotify, the call is cancelled when ctx is done
+func (a *GattCharacteristic1) StopNotifyContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "StopNotify", 0).Store()
 }
 
 /*
@@ -532,5 +570,10 @@ Confirm 			This method doesn't expect a reply so it is just a
 
 */
 func (a *GattCharacteristic1) Confirm() error {
-	return a.client.Call("Confirm", 0).Store()
+	return a.ConfirmContext(context.Background())
+}
+
+// ConfirmContext is like Confirm, the call is cancelled when ctx is done
+func (a *GattCharacteristic1) ConfirmContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Confirm", 0).Store()
 }
@@ -3,6 +3,7 @@
 package gatt
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var GattDescriptor1Interface = "org.bluez.GattDescriptor1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX/serviceXX/charYYYY/descriptorZZZ
 func NewGattDescriptor1(objectPath dbus.ObjectPath) (*GattDescriptor1, error) {
+	return NewGattDescriptor1WithConn(nil, objectPath)
+}
+
+// NewGattDescriptor1WithConn create a new instance of GattDescriptor1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewGattDescriptor1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*GattDescriptor1, error) {
 	a := new(GattDescriptor1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewGattDescriptor1(objectPath dbus.ObjectPath) (*GattDescriptor1, error) {
 			Iface: GattDescriptor1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(GattDescriptor1Properties)
@@ -186,7 +194,7 @@ func (a *GattDescriptor1) GetObjectManagerSignal() (chan *dbus.Signal, func(), e
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -312,8 +320,13 @@ ReadValue 			Issues a request to read the value of the
 
 */
 func (a *GattDescriptor1) ReadValue(flags map[string]interface{}) ([]byte, error) {
+	return a.ReadValueContext(context.Background(), flags)
+}
+
+// ReadValueContext is like ReadValue, the call is cancelled when ctx is done
+func (a *GattDescriptor1) ReadValueContext(ctx context.Context, flags map[string]interface{}) ([]byte, error) {
 	val0 := []byte{}
-	err := a.client.Call("ReadValue", 0, flags).Store(&val0)
+	err := a.client.CallWithContext(ctx, "ReadValue", 0, flags).Store(&val0)
 	return val0, err
 }
 
@@ -335,5 +348,10 @@ WriteValue 			Issues a request to write the value of the
 
 */
 func (a *GattDescriptor1) WriteValue(value []byte, flags map[string]interface{}) error {
-	return a.client.Call("WriteValue", 0, value, flags).Store()
+	return a.WriteValueContext(context.Background(), value, flags)
+}
+
+// WriteValueContext is like WriteValue, the call is cancelled when ctx is done
+func (a *GattDescriptor1) WriteValueContext(ctx context.Context, value []byte, flags map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "WriteValue", 0, value, flags).Store()
 }
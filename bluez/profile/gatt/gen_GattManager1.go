@@ -3,6 +3,7 @@
 package gatt
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -19,6 +20,12 @@ var GattManager1Interface = "org.bluez.GattManager1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}
 func NewGattManager1(objectPath dbus.ObjectPath) (*GattManager1, error) {
+	return NewGattManager1WithConn(nil, objectPath)
+}
+
+// NewGattManager1WithConn create a new instance of GattManager1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewGattManager1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*GattManager1, error) {
 	a := new(GattManager1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewGattManager1(objectPath dbus.ObjectPath) (*GattManager1, error) {
 			Iface: GattManager1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(GattManager1Properties)
@@ -40,6 +48,12 @@ func NewGattManager1(objectPath dbus.ObjectPath) (*GattManager1, error) {
 // NewGattManager1FromAdapterID create a new instance of GattManager1
 // adapterID: ID of an adapter eg. hci0
 func NewGattManager1FromAdapterID(adapterID string) (*GattManager1, error) {
+	return NewGattManager1FromAdapterIDWithConn(nil, adapterID)
+}
+
+// NewGattManager1FromAdapterIDWithConn create a new instance of GattManager1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewGattManager1FromAdapterIDWithConn(conn *bluez.Conn, adapterID string) (*GattManager1, error) {
 	a := new(GattManager1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -47,6 +61,7 @@ func NewGattManager1FromAdapterID(adapterID string) (*GattManager1, error) {
 			Iface: GattManager1Interface,
 			Path:  dbus.ObjectPath(fmt.Sprintf("/org/bluez/%s", adapterID)),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(GattManager1Properties)
@@ -178,7 +193,7 @@ func (a *GattManager1) GetObjectManagerSignal() (chan *dbus.Signal, func(), erro
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -301,7 +316,12 @@ RegisterApplication 			Registers a local GATT services hierarchy as described
 
 */
 func (a *GattManager1) RegisterApplication(application dbus.ObjectPath, options map[string]interface{}) error {
-	return a.client.Call("RegisterApplication", 0, application, options).Store()
+	return a.RegisterApplicationContext(context.Background(), application, options)
+}
+
+// RegisterApplicationContext is like RegisterApplication, the call is cancelled when ctx is done
+func (a *GattManager1) RegisterApplicationContext(ctx context.Context, application dbus.ObjectPath, options map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "RegisterApplication", 0, application, options).Store()
 }
 
 /*
@@ -314,5 +334,10 @@ UnregisterApplication 			This unregisters the services that has been
 
 */
 func (a *GattManager1) UnregisterApplication(application dbus.ObjectPath) error {
-	return a.client.Call("UnregisterApplication", 0, application).Store()
+	return a.UnregisterApplicationContext(context.Background(), application)
+}
+
+// UnregisterApplicationContext is like UnregisterApplication, the call is cancelled when ctx is done
+func (a *GattManager1) UnregisterApplicationContext(ctx context.Context, application dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "UnregisterApplication", 0, application).Store()
 }
@@ -3,6 +3,7 @@
 package gatt
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -19,6 +20,12 @@ var GattProfile1Interface = "org.bluez.GattProfile1"
 // - servicePath: <application dependent>
 // - objectPath: <application dependent>
 func NewGattProfile1(servicePath string, objectPath dbus.ObjectPath) (*GattProfile1, error) {
+	return NewGattProfile1WithConn(nil, servicePath, objectPath)
+}
+
+// NewGattProfile1WithConn create a new instance of GattProfile1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewGattProfile1WithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*GattProfile1, error) {
 	a := new(GattProfile1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewGattProfile1(servicePath string, objectPath dbus.ObjectPath) (*GattProfi
 			Iface: GattProfile1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(GattProfile1Properties)
@@ -110,7 +118,7 @@ func (a *GattProfile1) GetObjectManagerSignal() (chan *dbus.Signal, func(), erro
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -230,5 +238,10 @@ Release 			This method gets called when the service daemon
 
 */
 func (a *GattProfile1) Release() error {
-	return a.client.Call("Release", 0).Store()
+	return a.ReleaseContext(context.Background())
+}
+
+// ReleaseContext is like Release, the call is cancelled when ctx is done
+func (a *GattProfile1) ReleaseContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Release", 0).Store()
 }
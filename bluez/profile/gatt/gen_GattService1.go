@@ -18,6 +18,12 @@ var GattService1Interface = "org.bluez.GattService1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX/serviceXX
 func NewGattService1(objectPath dbus.ObjectPath) (*GattService1, error) {
+	return NewGattService1WithConn(nil, objectPath)
+}
+
+// NewGattService1WithConn create a new instance of GattService1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewGattService1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*GattService1, error) {
 	a := new(GattService1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +31,7 @@ func NewGattService1(objectPath dbus.ObjectPath) (*GattService1, error) {
 			Iface: GattService1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(GattService1Properties)
@@ -218,7 +225,7 @@ func (a *GattService1) GetObjectManagerSignal() (chan *dbus.Signal, func(), erro
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -62,4 +62,69 @@ var (
 		Name: "org.bluez.Error.AlreadyConnected",
 		Body: []interface{}{"AlreadyConnected"},
 	}
+	// InProgress map to org.bluez.Error.InProgress
+	ErrInProgress = dbus.Error{
+		Name: "org.bluez.Error.InProgress",
+		Body: []interface{}{"InProgress"},
+	}
+	// AlreadyExists map to org.bluez.Error.AlreadyExists
+	ErrAlreadyExists = dbus.Error{
+		Name: "org.bluez.Error.AlreadyExists",
+		Body: []interface{}{"AlreadyExists"},
+	}
+	// NotPermitted map to org.bluez.Error.NotPermitted
+	ErrNotPermitted = dbus.Error{
+		Name: "org.bluez.Error.NotPermitted",
+		Body: []interface{}{"NotPermitted"},
+	}
+	// NoSuchAdapter map to org.bluez.Error.NoSuchAdapter
+	ErrNoSuchAdapter = dbus.Error{
+		Name: "org.bluez.Error.NoSuchAdapter",
+		Body: []interface{}{"NoSuchAdapter"},
+	}
+	// AgentNotAvailable map to org.bluez.Error.AgentNotAvailable
+	ErrAgentNotAvailable = dbus.Error{
+		Name: "org.bluez.Error.AgentNotAvailable",
+		Body: []interface{}{"AgentNotAvailable"},
+	}
+	// Canceled map to org.bluez.Error.Canceled
+	ErrCanceled = dbus.Error{
+		Name: "org.bluez.Error.Canceled",
+		Body: []interface{}{"Canceled"},
+	}
+	// InvalidOffset map to org.bluez.Error.InvalidOffset
+	ErrInvalidOffset = dbus.Error{
+		Name: "org.bluez.Error.InvalidOffset",
+		Body: []interface{}{"InvalidOffset"},
+	}
+	// InvalidValueLength map to org.bluez.Error.InvalidValueLength
+	ErrInvalidValueLength = dbus.Error{
+		Name: "org.bluez.Error.InvalidValueLength",
+		Body: []interface{}{"InvalidValueLength"},
+	}
+	// AuthenticationCanceled map to org.bluez.Error.AuthenticationCanceled
+	ErrAuthenticationCanceled = dbus.Error{
+		Name: "org.bluez.Error.AuthenticationCanceled",
+		Body: []interface{}{"AuthenticationCanceled"},
+	}
+	// AuthenticationFailed map to org.bluez.Error.AuthenticationFailed
+	ErrAuthenticationFailed = dbus.Error{
+		Name: "org.bluez.Error.AuthenticationFailed",
+		Body: []interface{}{"AuthenticationFailed"},
+	}
+	// AuthenticationRejected map to org.bluez.Error.AuthenticationRejected
+	ErrAuthenticationRejected = dbus.Error{
+		Name: "org.bluez.Error.AuthenticationRejected",
+		Body: []interface{}{"AuthenticationRejected"},
+	}
+	// AuthenticationTimeout map to org.bluez.Error.AuthenticationTimeout
+	ErrAuthenticationTimeout = dbus.Error{
+		Name: "org.bluez.Error.AuthenticationTimeout",
+		Body: []interface{}{"AuthenticationTimeout"},
+	}
+	// ConnectionAttemptFailed map to org.bluez.Error.ConnectionAttemptFailed
+	ErrConnectionAttemptFailed = dbus.Error{
+		Name: "org.bluez.Error.ConnectionAttemptFailed",
+		Body: []interface{}{"ConnectionAttemptFailed"},
+	}
 )
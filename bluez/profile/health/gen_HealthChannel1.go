@@ -3,6 +3,7 @@
 package health
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var HealthChannel1Interface = "org.bluez.HealthChannel1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX/chanZZZ
 func NewHealthChannel1(objectPath dbus.ObjectPath) (*HealthChannel1, error) {
+	return NewHealthChannel1WithConn(nil, objectPath)
+}
+
+// NewHealthChannel1WithConn create a new instance of HealthChannel1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewHealthChannel1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*HealthChannel1, error) {
 	a := new(HealthChannel1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewHealthChannel1(objectPath dbus.ObjectPath) (*HealthChannel1, error) {
 			Iface: HealthChannel1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(HealthChannel1Properties)
@@ -151,7 +159,7 @@ func (a *HealthChannel1) GetObjectManagerSignal() (chan *dbus.Signal, func(), er
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -271,8 +279,13 @@ Acquire 			Returns the file descriptor for this data channel. If
 
 */
 func (a *HealthChannel1) Acquire() (dbus.UnixFD, error) {
+	return a.AcquireContext(context.Background())
+}
+
+// AcquireContext is like Acquire, the call is cancelled when ctx is done
+func (a *HealthChannel1) AcquireContext(ctx context.Context) (dbus.UnixFD, error) {
 	var val0 dbus.UnixFD
-	err := a.client.Call("Acquire", 0).Store(&val0)
+	err := a.client.CallWithContext(ctx, "Acquire", 0).Store(&val0)
 	return val0, err
 }
 
@@ -284,5 +297,10 @@ Release 			Releases the fd. Application should also need to
 
 */
 func (a *HealthChannel1) Release() error {
-	return a.client.Call("Release", 0).Store()
+	return a.ReleaseContext(context.Background())
+}
+
+// ReleaseContext is like Release, the call is cancelled when ctx is done
+func (a *HealthChannel1) ReleaseContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Release", 0).Store()
 }
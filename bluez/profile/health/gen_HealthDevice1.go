@@ -3,6 +3,7 @@
 package health
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var HealthDevice1Interface = "org.bluez.HealthDevice1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX
 func NewHealthDevice1(objectPath dbus.ObjectPath) (*HealthDevice1, error) {
+	return NewHealthDevice1WithConn(nil, objectPath)
+}
+
+// NewHealthDevice1WithConn create a new instance of HealthDevice1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewHealthDevice1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*HealthDevice1, error) {
 	a := new(HealthDevice1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewHealthDevice1(objectPath dbus.ObjectPath) (*HealthDevice1, error) {
 			Iface: HealthDevice1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(HealthDevice1Properties)
@@ -112,7 +120,7 @@ func (a *HealthDevice1) GetObjectManagerSignal() (chan *dbus.Signal, func(), err
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -232,8 +240,13 @@ Echo 			Sends an echo petition to the remote service. Returns
 
 */
 func (a *HealthDevice1) Echo() (bool, error) {
+	return a.EchoContext(context.Background())
+}
+
+// EchoContext is like Echo, the call is cancelled when ctx is done
+func (a *HealthDevice1) EchoContext(ctx context.Context) (bool, error) {
 	var val0 bool
-	err := a.client.Call("Echo", 0).Store(&val0)
+	err := a.client.CallWithContext(ctx, "Echo", 0).Store(&val0)
 	return val0, err
 }
 
@@ -248,8 +261,13 @@ CreateChannel 			Creates a new data channel.  The configuration should
 
 */
 func (a *HealthDevice1) CreateChannel(application dbus.ObjectPath, configuration string) (dbus.ObjectPath, error) {
+	return a.CreateChannelContext(context.Background(), application, configuration)
+}
+
+// CreateChannelContext is like CreateChannel, the call is cancelled when ctx is done
+func (a *HealthDevice1) CreateChannelContext(ctx context.Context, application dbus.ObjectPath, configuration string) (dbus.ObjectPath, error) {
 	var val0 dbus.ObjectPath
-	err := a.client.Call("CreateChannel", 0, application, configuration).Store(&val0)
+	err := a.client.CallWithContext(ctx, "CreateChannel", 0, application, configuration).Store(&val0)
 	return val0, err
 }
 
@@ -264,5 +282,10 @@ DestroyChannel 			Destroys the data channel object. Only the creator of
 
 */
 func (a *HealthDevice1) DestroyChannel(channel dbus.ObjectPath) error {
-	return a.client.Call("DestroyChannel", 0, channel).Store()
+	return a.DestroyChannelContext(context.Background(), channel)
+}
+
+// DestroyChannelContext is like DestroyChannel, the call is cancelled when ctx is done
+func (a *HealthDevice1) DestroyChannelContext(ctx context.Context, channel dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "DestroyChannel", 0, channel).Store()
 }
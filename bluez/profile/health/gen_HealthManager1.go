@@ -3,6 +3,7 @@
 package health
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var HealthManager1Interface = "org.bluez.HealthManager1"
 // Args:
 
 func NewHealthManager1() (*HealthManager1, error) {
+	return NewHealthManager1WithConn(nil)
+}
+
+// NewHealthManager1WithConn create a new instance of HealthManager1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewHealthManager1WithConn(conn *bluez.Conn) (*HealthManager1, error) {
 	a := new(HealthManager1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewHealthManager1() (*HealthManager1, error) {
 			Iface: HealthManager1Interface,
 			Path:  dbus.ObjectPath("/org/bluez/"),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(HealthManager1Properties)
@@ -90,7 +98,7 @@ func (a *HealthManager1) GetObjectManagerSignal() (chan *dbus.Signal, func(), er
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -220,8 +228,13 @@ CreateApplication 			Returns the path of the new registered application.
 
 */
 func (a *HealthManager1) CreateApplication(config map[string]interface{}) (dbus.ObjectPath, error) {
+	return a.CreateApplicationContext(context.Background(), config)
+}
+
+// CreateApplicationContext is like CreateApplication, the call is cancelled when ctx is done
+func (a *HealthManager1) CreateApplicationContext(ctx context.Context, config map[string]interface{}) (dbus.ObjectPath, error) {
 	var val0 dbus.ObjectPath
-	err := a.client.Call("CreateApplication", 0, config).Store(&val0)
+	err := a.client.CallWithContext(ctx, "CreateApplication", 0, config).Store(&val0)
 	return val0, err
 }
 
@@ -236,5 +249,10 @@ DestroyApplication 			Closes the HDP application identified by the object
 
 */
 func (a *HealthManager1) DestroyApplication(application dbus.ObjectPath) error {
-	return a.client.Call("DestroyApplication", 0, application).Store()
+	return a.DestroyApplicationContext(context.Background(), application)
+}
+
+// DestroyApplicationContext is like DestroyApplication, the call is cancelled when ctx is done
+func (a *HealthManager1) DestroyApplicationContext(ctx context.Context, application dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "DestroyApplication", 0, application).Store()
 }
@@ -18,6 +18,12 @@ var Input1Interface = "org.bluez.Input1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX
 func NewInput1(objectPath dbus.ObjectPath) (*Input1, error) {
+	return NewInput1WithConn(nil, objectPath)
+}
+
+// NewInput1WithConn create a new instance of Input1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewInput1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*Input1, error) {
 	a := new(Input1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +31,7 @@ func NewInput1(objectPath dbus.ObjectPath) (*Input1, error) {
 			Iface: Input1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Input1Properties)
@@ -128,7 +135,7 @@ func (a *Input1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
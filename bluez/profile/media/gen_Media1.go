@@ -3,6 +3,7 @@
 package media
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var Media1Interface = "org.bluez.Media1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}
 func NewMedia1(objectPath dbus.ObjectPath) (*Media1, error) {
+	return NewMedia1WithConn(nil, objectPath)
+}
+
+// NewMedia1WithConn create a new instance of Media1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMedia1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*Media1, error) {
 	a := new(Media1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewMedia1(objectPath dbus.ObjectPath) (*Media1, error) {
 			Iface: Media1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Media1Properties)
@@ -90,7 +98,7 @@ func (a *Media1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -225,7 +233,12 @@ RegisterEndpoint 			Register a local end point to sender, the sender can
 
 */
 func (a *Media1) RegisterEndpoint(endpoint dbus.ObjectPath, properties map[string]interface{}) error {
-	return a.client.Call("RegisterEndpoint", 0, endpoint, properties).Store()
+	return a.RegisterEndpointContext(context.Background(), endpoint, properties)
+}
+
+// RegisterEndpointContext is like RegisterEndpoint, the call is cancelled when ctx is done
+func (a *Media1) RegisterEndpointContext(ctx context.Context, endpoint dbus.ObjectPath, properties map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "RegisterEndpoint", 0, endpoint, properties).Store()
 }
 
 /*
@@ -233,7 +246,12 @@ UnregisterEndpoint 			Unregister sender end point.
 
 */
 func (a *Media1) UnregisterEndpoint(endpoint dbus.ObjectPath) error {
-	return a.client.Call("UnregisterEndpoint", 0, endpoint).Store()
+	return a.UnregisterEndpointContext(context.Background(), endpoint)
+}
+
+// UnregisterEndpointContext is like UnregisterEndpoint, the call is cancelled when ctx is done
+func (a *Media1) UnregisterEndpointContext(ctx context.Context, endpoint dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "UnregisterEndpoint", 0, endpoint).Store()
 }
 
 /*
@@ -250,7 +268,12 @@ RegisterPlayer 			Register a media player object to sender, the sender
 
 */
 func (a *Media1) RegisterPlayer(player dbus.ObjectPath, properties map[string]interface{}) error {
-	return a.client.Call("RegisterPlayer", 0, player, properties).Store()
+	return a.RegisterPlayerContext(context.Background(), player, properties)
+}
+
+// RegisterPlayerContext is like RegisterPlayer, the call is cancelled when ctx is done
+func (a *Media1) RegisterPlayerContext(ctx context.Context, player dbus.ObjectPath, properties map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "RegisterPlayer", 0, player, properties).Store()
 }
 
 /*
@@ -258,7 +281,12 @@ UnregisterPlayer 			Unregister sender media player.
 
 */
 func (a *Media1) UnregisterPlayer(player dbus.ObjectPath) error {
-	return a.client.Call("UnregisterPlayer", 0, player).Store()
+	return a.UnregisterPlayerContext(context.Background(), player)
+}
+
+// UnregisterPlayerContext is like UnregisterPlayer, the call is cancelled when ctx is done
+func (a *Media1) UnregisterPlayerContext(ctx context.Context, player dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "UnregisterPlayer", 0, player).Store()
 }
 
 /*
@@ -272,7 +300,12 @@ RegisterApplication 			Register endpoints an player objects within root
 
 */
 func (a *Media1) RegisterApplication(root dbus.ObjectPath, options map[string]interface{}) error {
-	return a.client.Call("RegisterApplication", 0, root, options).Store()
+	return a.RegisterApplicationContext(context.Background(), root, options)
+}
+
+// RegisterApplicationContext is like RegisterApplication, the call is cancelled when ctx is done
+func (a *Media1) RegisterApplicationContext(ctx context.Context, root dbus.ObjectPath, options map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "RegisterApplication", 0, root, options).Store()
 }
 
 /*
@@ -285,5 +318,10 @@ UnregisterApplication 			This unregisters the services that has been
 
 */
 func (a *Media1) UnregisterApplication(application dbus.ObjectPath) error {
-	return a.client.Call("UnregisterApplication", 0, application).Store()
+	return a.UnregisterApplicationContext(context.Background(), application)
+}
+
+// UnregisterApplicationContext is like UnregisterApplication, the call is cancelled when ctx is done
+func (a *Media1) UnregisterApplicationContext(ctx context.Context, application dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "UnregisterApplication", 0, application).Store()
 }
@@ -3,6 +3,7 @@
 package media
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -19,6 +20,12 @@ var MediaControl1Interface = "org.bluez.MediaControl1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX
 func NewMediaControl1(objectPath dbus.ObjectPath) (*MediaControl1, error) {
+	return NewMediaControl1WithConn(nil, objectPath)
+}
+
+// NewMediaControl1WithConn create a new instance of MediaControl1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMediaControl1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*MediaControl1, error) {
 	a := new(MediaControl1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewMediaControl1(objectPath dbus.ObjectPath) (*MediaControl1, error) {
 			Iface: MediaControl1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(MediaControl1Properties)
@@ -40,6 +48,12 @@ func NewMediaControl1(objectPath dbus.ObjectPath) (*MediaControl1, error) {
 // NewMediaControl1FromAdapterID create a new instance of MediaControl1
 // adapterID: ID of an adapter eg. hci0
 func NewMediaControl1FromAdapterID(adapterID string) (*MediaControl1, error) {
+	return NewMediaControl1FromAdapterIDWithConn(nil, adapterID)
+}
+
+// NewMediaControl1FromAdapterIDWithConn create a new instance of MediaControl1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMediaControl1FromAdapterIDWithConn(conn *bluez.Conn, adapterID string) (*MediaControl1, error) {
 	a := new(MediaControl1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -47,6 +61,7 @@ func NewMediaControl1FromAdapterID(adapterID string) (*MediaControl1, error) {
 			Iface: MediaControl1Interface,
 			Path:  dbus.ObjectPath(fmt.Sprintf("/org/bluez/%s", adapterID)),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(MediaControl1Properties)
@@ -150,7 +165,7 @@ func (a *MediaControl1) GetObjectManagerSignal() (chan *dbus.Signal, func(), err
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -266,7 +281,12 @@ Play 			Resume playback.
 
 */
 func (a *MediaControl1) Play() error {
-	return a.client.Call("Play", 0).Store()
+	return a.PlayContext(context.Background())
+}
+
+// PlayContext is like Play, the call is cancelled when ctx is done
+func (a *MediaControl1) PlayContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Play", 0).Store()
 }
 
 /*
@@ -274,7 +294,12 @@ Pause 			Pause playback.
 
 */
 func (a *MediaControl1) Pause() error {
-	return a.client.Call("Pause", 0).Store()
+	return a.PauseContext(context.Background())
+}
+
+// PauseContext is like Pause, the call is cancelled when ctx is done
+func (a *MediaControl1) PauseContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Pause", 0).Store()
 }
 
 /*
@@ -282,7 +307,12 @@ Stop 			Stop playback.
 
 */
 func (a *MediaControl1) Stop() error {
-	return a.client.Call("Stop", 0).Store()
+	return a.StopContext(context.Background())
+}
+
+// StopContext is like Stop, the call is cancelled when ctx is done
+func (a *MediaControl1) StopContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Stop", 0).Store()
 }
 
 /*
@@ -290,7 +320,12 @@ Next 			Next item.
 
 */
 func (a *MediaControl1) Next() error {
-	return a.client.Call("Next", 0).Store()
+	return a.NextContext(context.Background())
+}
+
+// NextContext is like Next, the call is cancelled when ctx is done
+func (a *MediaControl1) NextContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Next", 0).Store()
 }
 
 /*
@@ -298,7 +333,12 @@ Previous 			Previous item.
 
 */
 func (a *MediaControl1) Previous() error {
-	return a.client.Call("Previous", 0).Store()
+	return a.PreviousContext(context.Background())
+}
+
+// PreviousContext is like Previous, the call is cancelled when ctx is done
+func (a *MediaControl1) PreviousContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Previous", 0).Store()
 }
 
 /*
@@ -306,7 +346,12 @@ VolumeUp 			Adjust remote volume one step up
 
 */
 func (a *MediaControl1) VolumeUp() error {
-	return a.client.Call("VolumeUp", 0).Store()
+	return a.VolumeUpContext(context.Background())
+}
+
+// VolumeUpContext is like VolumeUp, the call is cancelled when ctx is done
+func (a *MediaControl1) VolumeUpContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "VolumeUp", 0).Store()
 }
 
 /*
@@ -314,7 +359,12 @@ VolumeDown 			Adjust remote volume one step down
 
 */
 func (a *MediaControl1) VolumeDown() error {
-	return a.client.Call("VolumeDown", 0).Store()
+	return a.VolumeDownContext(context.Background())
+}
+
+// VolumeDownContext is like VolumeDown, the call is cancelled when ctx is done
+func (a *MediaControl1) VolumeDownContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "VolumeDown", 0).Store()
 }
 
 /*
@@ -323,7 +373,12 @@ FastForward 			Fast forward playback, this action is only stopped
 
 */
 func (a *MediaControl1) FastForward() error {
-	return a.client.Call("FastForward", 0).Store()
+	return a.FastForwardContext(context.Background())
+}
+
+// FastForwardContext is like FastForward, the call is cancelled when ctx is done
+func (a *MediaControl1) FastForwardContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "FastForward", 0).Store()
 }
 
 /*
@@ -332,5 +387,10 @@ Rewind 			Rewind playback, this action is only stopped
 
 */
 func (a *MediaControl1) Rewind() error {
-	return a.client.Call("Rewind", 0).Store()
+	return a.RewindContext(context.Background())
+}
+
+// RewindContext is like Rewind, the call is cancelled when ctx is done
+func (a *MediaControl1) RewindContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Rewind", 0).Store()
 }
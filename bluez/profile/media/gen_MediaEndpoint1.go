@@ -3,6 +3,7 @@
 package media
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var MediaEndpoint1Interface = "org.bluez.MediaEndpoint1"
 // Args:
 // - servicePath: unique name
 func NewMediaEndpoint1Server(servicePath string, objectPath dbus.ObjectPath) (*MediaEndpoint1, error) {
+	return NewMediaEndpoint1ServerWithConn(nil, servicePath, objectPath)
+}
+
+// NewMediaEndpoint1ServerWithConn create a new instance of MediaEndpoint1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMediaEndpoint1ServerWithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*MediaEndpoint1, error) {
 	a := new(MediaEndpoint1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewMediaEndpoint1Server(servicePath string, objectPath dbus.ObjectPath) (*M
 			Iface: MediaEndpoint1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(MediaEndpoint1Properties)
@@ -41,6 +49,12 @@ func NewMediaEndpoint1Server(servicePath string, objectPath dbus.ObjectPath) (*M
 // Args:
 
 func NewMediaEndpoint1Client(objectPath dbus.ObjectPath) (*MediaEndpoint1, error) {
+	return NewMediaEndpoint1ClientWithConn(nil, objectPath)
+}
+
+// NewMediaEndpoint1ClientWithConn create a new instance of MediaEndpoint1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMediaEndpoint1ClientWithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*MediaEndpoint1, error) {
 	a := new(MediaEndpoint1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -48,6 +62,7 @@ func NewMediaEndpoint1Client(objectPath dbus.ObjectPath) (*MediaEndpoint1, error
 			Iface: MediaEndpoint1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(MediaEndpoint1Properties)
@@ -211,7 +226,7 @@ func (a *MediaEndpoint1) GetObjectManagerSignal() (chan *dbus.Signal, func(), er
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -331,7 +346,12 @@ SetConfiguration 			Set configuration for the transport.
 
 */
 func (a *MediaEndpoint1) SetConfiguration(transport dbus.ObjectPath, properties map[string]interface{}) error {
-	return a.client.Call("SetConfiguration", 0, transport, properties).Store()
+	return a.SetConfigurationContext(context.Background(), transport, properties)
+}
+
+// SetConfigurationContext is like SetConfiguration, the call is cancelled when ctx is done
+func (a *MediaEndpoint1) SetConfigurationContext(ctx context.Context, transport dbus.ObjectPath, properties map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "SetConfiguration", 0, transport, properties).Store()
 }
 
 /*
@@ -345,8 +365,13 @@ SelectConfiguration 			Select preferable configuration from the supported
 
 */
 func (a *MediaEndpoint1) SelectConfiguration(capabilities []byte) ([]byte, error) {
+	return a.SelectConfigurationContext(context.Background(), capabilities)
+}
+
+// SelectConfigurationContext is like SelectConfiguration, the call is cancelled when ctx is done
+func (a *MediaEndpoint1) SelectConfigurationContext(ctx context.Context, capabilities []byte) ([]byte, error) {
 	val0 := []byte{}
-	err := a.client.Call("SelectConfiguration", 0, capabilities).Store(&val0)
+	err := a.client.CallWithContext(ctx, "SelectConfiguration", 0, capabilities).Store(&val0)
 	return val0, err
 }
 
@@ -355,7 +380,12 @@ ClearConfiguration 			Clear transport configuration.
 
 */
 func (a *MediaEndpoint1) ClearConfiguration(transport dbus.ObjectPath) error {
-	return a.client.Call("ClearConfiguration", 0, transport).Store()
+	return a.ClearConfigurationContext(context.Background(), transport)
+}
+
+// ClearConfigurationContext is like ClearConfiguration, the call is cancelled when ctx is done
+func (a *MediaEndpoint1) ClearConfigurationContext(ctx context.Context, transport dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "ClearConfiguration", 0, transport).Store()
 }
 
 /*
@@ -367,5 +397,10 @@ Release 			This method gets called when the service daemon
 
 */
 func (a *MediaEndpoint1) Release() error {
-	return a.client.Call("Release", 0).Store()
+	return a.ReleaseContext(context.Background())
+}
+
+// ReleaseContext is like Release, the call is cancelled when ctx is done
+func (a *MediaEndpoint1) ReleaseContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Release", 0).Store()
 }
@@ -3,6 +3,7 @@
 package media
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -19,6 +20,12 @@ var MediaFolder1Interface = "org.bluez.MediaFolder1"
 // - servicePath: unique name
 // - objectPath: freely definable
 func NewMediaFolder1(servicePath string, objectPath dbus.ObjectPath) (*MediaFolder1, error) {
+	return NewMediaFolder1WithConn(nil, servicePath, objectPath)
+}
+
+// NewMediaFolder1WithConn create a new instance of MediaFolder1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMediaFolder1WithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*MediaFolder1, error) {
 	a := new(MediaFolder1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewMediaFolder1(servicePath string, objectPath dbus.ObjectPath) (*MediaFold
 			Iface: MediaFolder1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(MediaFolder1Properties)
@@ -42,6 +50,12 @@ func NewMediaFolder1(servicePath string, objectPath dbus.ObjectPath) (*MediaFold
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX/playerX
 func NewMediaFolder1Controller(objectPath dbus.ObjectPath) (*MediaFolder1, error) {
+	return NewMediaFolder1ControllerWithConn(nil, objectPath)
+}
+
+// NewMediaFolder1ControllerWithConn create a new instance of MediaFolder1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMediaFolder1ControllerWithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*MediaFolder1, error) {
 	a := new(MediaFolder1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -49,6 +63,7 @@ func NewMediaFolder1Controller(objectPath dbus.ObjectPath) (*MediaFolder1, error
 			Iface: MediaFolder1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(MediaFolder1Properties)
@@ -231,7 +246,7 @@ func (a *MediaFolder1) GetObjectManagerSignal() (chan *dbus.Signal, func(), erro
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -351,8 +366,13 @@ Search 			Return a folder object containing the search result.
 
 */
 func (a *MediaFolder1) Search(value string, filter map[string]interface{}) (dbus.ObjectPath, error) {
+	return a.SearchContext(context.Background(), value, filter)
+}
+
+// SearchContext is like Search, the call is cancelled when ctx is done
+func (a *MediaFolder1) SearchContext(ctx context.Context, value string, filter map[string]interface{}) (dbus.ObjectPath, error) {
 	var val0 dbus.ObjectPath
-	err := a.client.Call("Search", 0, value, filter).Store(&val0)
+	err := a.client.CallWithContext(ctx, "Search", 0, value, filter).Store(&val0)
 	return val0, err
 }
 
@@ -364,8 +384,13 @@ ListItems 			Return a list of items found
 
 */
 func (a *MediaFolder1) ListItems(filter map[string]interface{}) ([]Item, error) {
+	return a.ListItemsContext(context.Background(), filter)
+}
+
+// ListItemsContext is like ListItems, the call is cancelled when ctx is done
+func (a *MediaFolder1) ListItemsContext(ctx context.Context, filter map[string]interface{}) ([]Item, error) {
 	val0 := []Item{}
-	err := a.client.Call("ListItems", 0, filter).Store(&val0)
+	err := a.client.CallWithContext(ctx, "ListItems", 0, filter).Store(&val0)
 	return val0, err
 }
 
@@ -381,5 +406,10 @@ ChangeFolder 			Change current folder.
 
 */
 func (a *MediaFolder1) ChangeFolder(folder dbus.ObjectPath) error {
-	return a.client.Call("ChangeFolder", 0, folder).Store()
+	return a.ChangeFolderContext(context.Background(), folder)
+}
+
+// ChangeFolderContext is like ChangeFolder, the call is cancelled when ctx is done
+func (a *MediaFolder1) ChangeFolderContext(ctx context.Context, folder dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "ChangeFolder", 0, folder).Store()
 }
@@ -3,6 +3,7 @@
 package media
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -19,6 +20,12 @@ var MediaItem1Interface = "org.bluez.MediaItem1"
 // - servicePath: unique name
 // - objectPath: freely definable
 func NewMediaItem1(servicePath string, objectPath dbus.ObjectPath) (*MediaItem1, error) {
+	return NewMediaItem1WithConn(nil, servicePath, objectPath)
+}
+
+// NewMediaItem1WithConn create a new instance of MediaItem1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMediaItem1WithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*MediaItem1, error) {
 	a := new(MediaItem1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewMediaItem1(servicePath string, objectPath dbus.ObjectPath) (*MediaItem1,
 			Iface: MediaItem1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(MediaItem1Properties)
@@ -42,6 +50,12 @@ func NewMediaItem1(servicePath string, objectPath dbus.ObjectPath) (*MediaItem1,
 // Args:
 // - objectPath: [variable	prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX/playerX/itemX
 func NewMediaItem1Controller(objectPath dbus.ObjectPath) (*MediaItem1, error) {
+	return NewMediaItem1ControllerWithConn(nil, objectPath)
+}
+
+// NewMediaItem1ControllerWithConn create a new instance of MediaItem1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMediaItem1ControllerWithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*MediaItem1, error) {
 	a := new(MediaItem1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -49,6 +63,7 @@ func NewMediaItem1Controller(objectPath dbus.ObjectPath) (*MediaItem1, error) {
 			Iface: MediaItem1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(MediaItem1Properties)
@@ -392,7 +407,7 @@ func (a *MediaItem1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error)
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -510,7 +525,12 @@ Play 			Play item
 
 */
 func (a *MediaItem1) Play() error {
-	return a.client.Call("Play", 0).Store()
+	return a.PlayContext(context.Background())
+}
+
+// PlayContext is like Play, the call is cancelled when ctx is done
+func (a *MediaItem1) PlayContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Play", 0).Store()
 }
 
 /*
@@ -520,5 +540,10 @@ AddtoNowPlaying 			Add item to now playing list
 
 */
 func (a *MediaItem1) AddtoNowPlaying() error {
-	return a.client.Call("AddtoNowPlaying", 0).Store()
+	return a.AddtoNowPlayingContext(context.Background())
+}
+
+// AddtoNowPlayingContext is like AddtoNowPlaying, the call is cancelled when ctx is done
+func (a *MediaItem1) AddtoNowPlayingContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "AddtoNowPlaying", 0).Store()
 }
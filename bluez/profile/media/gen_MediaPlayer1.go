@@ -3,6 +3,7 @@
 package media
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var MediaPlayer1Interface = "org.bluez.MediaPlayer1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX/playerX
 func NewMediaPlayer1(objectPath dbus.ObjectPath) (*MediaPlayer1, error) {
+	return NewMediaPlayer1WithConn(nil, objectPath)
+}
+
+// NewMediaPlayer1WithConn create a new instance of MediaPlayer1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMediaPlayer1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*MediaPlayer1, error) {
 	a := new(MediaPlayer1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewMediaPlayer1(objectPath dbus.ObjectPath) (*MediaPlayer1, error) {
 			Iface: MediaPlayer1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(MediaPlayer1Properties)
@@ -533,7 +541,7 @@ func (a *MediaPlayer1) GetObjectManagerSignal() (chan *dbus.Signal, func(), erro
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -651,7 +659,12 @@ Play 			Resume playback.
 
 */
 func (a *MediaPlayer1) Play() error {
-	return a.client.Call("Play", 0).Store()
+	return a.PlayContext(context.Background())
+}
+
+// PlayContext is like Play, the call is cancelled when ctx is done
+func (a *MediaPlayer1) PlayContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Play", 0).Store()
 }
 
 /*
@@ -661,7 +674,12 @@ Pause 			Pause playback.
 
 */
 func (a *MediaPlayer1) Pause() error {
-	return a.client.Call("Pause", 0).Store()
+	return a.PauseContext(context.Background())
+}
+
+// PauseContext is like Pause, the call is cancelled when ctx is done
+func (a *MediaPlayer1) PauseContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Pause", 0).Store()
 }
 
 /*
@@ -671,7 +689,12 @@ Stop 			Stop playback.
 
 */
 func (a *MediaPlayer1) Stop() error {
-	return a.client.Call("Stop", 0).Store()
+	return a.StopContext(context.Background())
+}
+
+// StopContext is like Stop, the call is cancelled when ctx is done
+func (a *MediaPlayer1) StopContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Stop", 0).Store()
 }
 
 /*
@@ -681,7 +704,12 @@ Next 			Next item.
 
 */
 func (a *MediaPlayer1) Next() error {
-	return a.client.Call("Next", 0).Store()
+	return a.NextContext(context.Background())
+}
+
+// NextContext is like Next, the call is cancelled when ctx is done
+func (a *MediaPlayer1) NextContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Next", 0).Store()
 }
 
 /*
@@ -691,7 +719,12 @@ Previous 			Previous item.
 
 */
 func (a *MediaPlayer1) Previous() error {
-	return a.client.Call("Previous", 0).Store()
+	return a.PreviousContext(context.Background())
+}
+
+// PreviousContext is like Previous, the call is cancelled when ctx is done
+func (a *MediaPlayer1) PreviousContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Previous", 0).Store()
 }
 
 /*
@@ -702,7 +735,12 @@ FastForward 			Fast forward playback, this action is only stopped
 
 */
 func (a *MediaPlayer1) FastForward() error {
-	return a.client.Call("FastForward", 0).Store()
+	return a.FastForwardContext(context.Background())
+}
+
+// FastForwardContext is like FastForward, the call is cancelled when ctx is done
+func (a *MediaPlayer1) FastForwardContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "FastForward", 0).Store()
 }
 
 /*
@@ -713,7 +751,12 @@ Rewind 			Rewind playback, this action is only stopped
 
 */
 func (a *MediaPlayer1) Rewind() error {
-	return a.client.Call("Rewind", 0).Store()
+	return a.RewindContext(context.Background())
+}
+
+// RewindContext is like Rewind, the call is cancelled when ctx is done
+func (a *MediaPlayer1) RewindContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Rewind", 0).Store()
 }
 
 /*
@@ -726,7 +769,12 @@ Press 			Press a specific key to send as passthrough command.
 
 */
 func (a *MediaPlayer1) Press(avc_key byte) error {
-	return a.client.Call("Press", 0, avc_key).Store()
+	return a.PressContext(context.Background(), avc_key)
+}
+
+// PressContext is like Press, the call is cancelled when ctx is done
+func (a *MediaPlayer1) PressContext(ctx context.Context, avc_key byte) error {
+	return a.client.CallWithContext(ctx, "Press", 0, avc_key).Store()
 }
 
 /*
@@ -741,7 +789,12 @@ Hold 			Press and hold a specific key to send as passthrough
 
 */
 func (a *MediaPlayer1) Hold(avc_key byte) error {
-	return a.client.Call("Hold", 0, avc_key).Store()
+	return a.HoldContext(context.Background(), avc_key)
+}
+
+// HoldContext is like Hold, the call is cancelled when ctx is done
+func (a *MediaPlayer1) HoldContext(ctx context.Context, avc_key byte) error {
+	return a.client.CallWithContext(ctx, "Hold", 0, avc_key).Store()
 }
 
 /*
@@ -751,5 +804,10 @@ Release 			Release the previously held key invoked using Hold().
 
 */
 func (a *MediaPlayer1) Release() error {
-	return a.client.Call("Release", 0).Store()
+	return a.ReleaseContext(context.Background())
+}
+
+// ReleaseContext is like Release, the call is cancelled when ctx is done
+func (a *MediaPlayer1) ReleaseContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Release", 0).Store()
 }
@@ -3,6 +3,7 @@
 package media
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var MediaTransport1Interface = "org.bluez.MediaTransport1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX/fdX
 func NewMediaTransport1(objectPath dbus.ObjectPath) (*MediaTransport1, error) {
+	return NewMediaTransport1WithConn(nil, objectPath)
+}
+
+// NewMediaTransport1WithConn create a new instance of MediaTransport1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMediaTransport1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*MediaTransport1, error) {
 	a := new(MediaTransport1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewMediaTransport1(objectPath dbus.ObjectPath) (*MediaTransport1, error) {
 			Iface: MediaTransport1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(MediaTransport1Properties)
@@ -256,7 +264,7 @@ func (a *MediaTransport1) GetObjectManagerSignal() (chan *dbus.Signal, func(), e
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -375,10 +383,15 @@ Acquire 			Acquire transport file descriptor and the MTU for read
 
 */
 func (a *MediaTransport1) Acquire() (dbus.UnixFD, uint16, uint16, error) {
+	return a.AcquireContext(context.Background())
+}
+
+// AcquireContext is like Acquire, the call is cancelled when ctx is done
+func (a *MediaTransport1) AcquireContext(ctx context.Context) (dbus.UnixFD, uint16, uint16, error) {
 	var val0 dbus.UnixFD
 	var val1 uint16
 	var val2 uint16
-	err := a.client.Call("Acquire", 0).Store(&val0, &val1, &val2)
+	err := a.client.CallWithContext(ctx, "Acquire", 0).Store(&val0, &val1, &val2)
 	return val0, val1, val2, err
 }
 
@@ -394,10 +407,15 @@ TryAcquire 			Acquire transport file descriptor only if the transport
 
 */
 func (a *MediaTransport1) TryAcquire() (dbus.UnixFD, uint16, uint16, error) {
+	return a.TryAcquireContext(context.Background())
+}
+
+// TryAcquireContext is like TryAcquire, the call is cancelled when ctx is done
+func (a *MediaTransport1) TryAcquireContext(ctx context.Context) (dbus.UnixFD, uint16, uint16, error) {
 	var val0 dbus.UnixFD
 	var val1 uint16
 	var val2 uint16
-	err := a.client.Call("TryAcquire", 0).Store(&val0, &val1, &val2)
+	err := a.client.CallWithContext(ctx, "TryAcquire", 0).Store(&val0, &val1, &val2)
 	return val0, val1, val2, err
 }
 
@@ -406,5 +424,10 @@ Release 			Releases file descriptor.
 
 */
 func (a *MediaTransport1) Release() error {
-	return a.client.Call("Release", 0).Store()
+	return a.ReleaseContext(context.Background())
+}
+
+// ReleaseContext is like Release, the call is cancelled when ctx is done
+func (a *MediaTransport1) ReleaseContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Release", 0).Store()
 }
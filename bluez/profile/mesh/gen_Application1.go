@@ -3,6 +3,7 @@
 package mesh
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -19,6 +20,12 @@ var Application1Interface = "org.bluez.mesh.Application1"
 // - servicePath: unique name
 // - objectPath: <app_root>
 func NewApplication1(servicePath string, objectPath dbus.ObjectPath) (*Application1, error) {
+	return NewApplication1WithConn(nil, servicePath, objectPath)
+}
+
+// NewApplication1WithConn create a new instance of Application1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewApplication1WithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*Application1, error) {
 	a := new(Application1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewApplication1(servicePath string, objectPath dbus.ObjectPath) (*Applicati
 			Iface: Application1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Application1Properties)
@@ -148,7 +156,7 @@ func (a *Application1) GetObjectManagerSignal() (chan *dbus.Signal, func(), erro
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -274,7 +282,12 @@ JoinComplete 		This method is called when the node provisioning initiated
 
 */
 func (a *Application1) JoinComplete(token uint64) error {
-	return a.client.Call("JoinComplete", 0, token).Store()
+	return a.JoinCompleteContext(context.Background(), token)
+}
+
+// JoinCompleteContext is like JoinComplete, the call is cancelled when ctx is done
+func (a *Application1) JoinCompleteContext(ctx context.Context, token uint64) error {
+	return a.client.CallWithContext(ctx, "JoinComplete", 0, token).Store()
 }
 
 /*
@@ -287,5 +300,10 @@ JoinFailed 		This method is called when the node provisioning initiated by
 
 */
 func (a *Application1) JoinFailed(reason string) error {
-	return a.client.Call("JoinFailed", 0, reason).Store()
+	return a.JoinFailedContext(context.Background(), reason)
+}
+
+// JoinFailedContext is like JoinFailed, the call is cancelled when ctx is done
+func (a *Application1) JoinFailedContext(ctx context.Context, reason string) error {
+	return a.client.CallWithContext(ctx, "JoinFailed", 0, reason).Store()
 }
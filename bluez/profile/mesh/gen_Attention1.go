@@ -3,6 +3,7 @@
 package mesh
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -19,6 +20,12 @@ var Attention1Interface = "org.bluez.mesh.Attention1"
 // - servicePath: unique name
 // - objectPath: freely definable
 func NewAttention1(servicePath string, objectPath dbus.ObjectPath) (*Attention1, error) {
+	return NewAttention1WithConn(nil, servicePath, objectPath)
+}
+
+// NewAttention1WithConn create a new instance of Attention1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewAttention1WithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*Attention1, error) {
 	a := new(Attention1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewAttention1(servicePath string, objectPath dbus.ObjectPath) (*Attention1,
 			Iface: Attention1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Attention1Properties)
@@ -91,7 +99,7 @@ func (a *Attention1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error)
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -212,7 +220,12 @@ SetTimer 		The element_index parameter is the element's index within the
 
 */
 func (a *Attention1) SetTimer(element_index uint8, time uint16) error {
-	return a.client.Call("SetTimer", 0, element_index, time).Store()
+	return a.SetTimerContext(context.Background(), element_index, time)
+}
+
+// SetTimerContext is like SetTimer, the call is cancelled when ctx is done
+func (a *Attention1) SetTimerContext(ctx context.Context, element_index uint8, time uint16) error {
+	return a.client.CallWithContext(ctx, "SetTimer", 0, element_index, time).Store()
 }
 
 /*
@@ -225,7 +238,12 @@ GetTimer 		The element parameter is the unicast address within the node
 
 */
 func (a *Attention1) GetTimer(element uint16) (uint16, error) {
+	return a.GetTimerContext(context.Background(), element)
+}
+
+// GetTimerContext is like GetTimer, the call is cancelled when ctx is done
+func (a *Attention1) GetTimerContext(ctx context.Context, element uint16) (uint16, error) {
 	var val0 uint16
-	err := a.client.Call("GetTimer", 0, element).Store(&val0)
+	err := a.client.CallWithContext(ctx, "GetTimer", 0, element).Store(&val0)
 	return val0, err
 }
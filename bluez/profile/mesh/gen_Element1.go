@@ -3,6 +3,7 @@
 package mesh
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -19,6 +20,12 @@ var Element1Interface = "org.bluez.mesh.Element1"
 // - servicePath: unique name
 // - objectPath: <app_defined_element_path>
 func NewElement1(servicePath string, objectPath dbus.ObjectPath) (*Element1, error) {
+	return NewElement1WithConn(nil, servicePath, objectPath)
+}
+
+// NewElement1WithConn create a new instance of Element1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewElement1WithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*Element1, error) {
 	a := new(Element1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewElement1(servicePath string, objectPath dbus.ObjectPath) (*Element1, err
 			Iface: Element1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Element1Properties)
@@ -187,7 +195,7 @@ func (a *Element1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -318,7 +326,12 @@ MessageReceived 		This method is called by bluetooth-meshd daemon when a message
 
 */
 func (a *Element1) MessageReceived(source uint16, key_index uint16, destination dbus.Variant, data []byte) error {
-	return a.client.Call("MessageReceived", 0, source, key_index, destination, data).Store()
+	return a.MessageReceivedContext(context.Background(), source, key_index, destination, data)
+}
+
+// MessageReceivedContext is like MessageReceived, the call is cancelled when ctx is done
+func (a *Element1) MessageReceivedContext(ctx context.Context, source uint16, key_index uint16, destination dbus.Variant, data []byte) error {
+	return a.client.CallWithContext(ctx, "MessageReceived", 0, source, key_index, destination, data).Store()
 }
 
 /*
@@ -338,7 +351,12 @@ DevKeyMessageReceived 		This method is called by meshd daemon when a message arr
 
 */
 func (a *Element1) DevKeyMessageReceived(source uint16, remote bool, net_index uint16, data []byte) error {
-	return a.client.Call("DevKeyMessageReceived", 0, source, remote, net_index, data).Store()
+	return a.DevKeyMessageReceivedContext(context.Background(), source, remote, net_index, data)
+}
+
+// DevKeyMessageReceivedContext is like DevKeyMessageReceived, the call is cancelled when ctx is done
+func (a *Element1) DevKeyMessageReceivedContext(ctx context.Context, source uint16, remote bool, net_index uint16, data []byte) error {
+	return a.client.CallWithContext(ctx, "DevKeyMessageReceived", 0, source, remote, net_index, data).Store()
 }
 
 /*
@@ -363,5 +381,10 @@ UpdateModelConfiguration 		This method is called by bluetooth-meshd daemon when
 
 */
 func (a *Element1) UpdateModelConfiguration(model_id uint16, config map[string]interface{}) error {
-	return a.client.Call("UpdateModelConfiguration", 0, model_id, config).Store()
+	return a.UpdateModelConfigurationContext(context.Background(), model_id, config)
+}
+
+// UpdateModelConfigurationContext is like UpdateModelConfiguration, the call is cancelled when ctx is done
+func (a *Element1) UpdateModelConfigurationContext(ctx context.Context, model_id uint16, config map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "UpdateModelConfiguration", 0, model_id, config).Store()
 }
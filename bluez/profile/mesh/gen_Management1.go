@@ -3,6 +3,7 @@
 package mesh
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var Management1Interface = "org.bluez.mesh.Management1"
 // Args:
 
 func NewManagement1(objectPath dbus.ObjectPath) (*Management1, error) {
+	return NewManagement1WithConn(nil, objectPath)
+}
+
+// NewManagement1WithConn create a new instance of Management1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewManagement1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*Management1, error) {
 	a := new(Management1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewManagement1(objectPath dbus.ObjectPath) (*Management1, error) {
 			Iface: Management1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Management1Properties)
@@ -90,7 +98,7 @@ func (a *Management1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -221,14 +229,24 @@ UnprovisionedScan 		This method is used by the application that supports
 
 */
 func (a *Management1) UnprovisionedScan(options map[string]interface{}) error {
-	return a.client.Call("UnprovisionedScan", 0, options).Store()
+	return a.UnprovisionedScanContext(context.Background(), options)
+}
+
+// UnprovisionedScanContext is like UnprovisionedScan, the call is cancelled when ctx is done
+func (a *Management1) UnprovisionedScanContext(ctx context.Context, options map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "UnprovisionedScan", 0, options).Store()
 }
 
 /*
 UnprovisionedScanCancel
 */
 func (a *Management1) UnprovisionedScanCancel() error {
-	return a.client.Call("UnprovisionedScanCancel", 0).Store()
+	return a.UnprovisionedScanCancelContext(context.Background())
+}
+
+// UnprovisionedScanCancelContext is like UnprovisionedScanCancel, the call is cancelled when ctx is done
+func (a *Management1) UnprovisionedScanCancelContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "UnprovisionedScanCancel", 0).Store()
 }
 
 /*
@@ -246,7 +264,12 @@ AddNode 		This method is used by the application that supports
 
 */
 func (a *Management1) AddNode(uuid []byte, options map[string]interface{}) error {
-	return a.client.Call("AddNode", 0, uuid, options).Store()
+	return a.AddNodeContext(context.Background(), uuid, options)
+}
+
+// AddNodeContext is like AddNode, the call is cancelled when ctx is done
+func (a *Management1) AddNodeContext(ctx context.Context, uuid []byte, options map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "AddNode", 0, uuid, options).Store()
 }
 
 /*
@@ -262,7 +285,12 @@ CreateSubnet 		This method is used by the application to generate and add a new
 
 */
 func (a *Management1) CreateSubnet(net_index uint16) error {
-	return a.client.Call("CreateSubnet", 0, net_index).Store()
+	return a.CreateSubnetContext(context.Background(), net_index)
+}
+
+// CreateSubnetContext is like CreateSubnet, the call is cancelled when ctx is done
+func (a *Management1) CreateSubnetContext(ctx context.Context, net_index uint16) error {
+	return a.client.CallWithContext(ctx, "CreateSubnet", 0, net_index).Store()
 }
 
 /*
@@ -280,7 +308,12 @@ ImportSubnet 		This method is used by the application to add a network subnet
 
 */
 func (a *Management1) ImportSubnet(net_index uint16, net_key []byte) error {
-	return a.client.Call("ImportSubnet", 0, net_index, net_key).Store()
+	return a.ImportSubnetContext(context.Background(), net_index, net_key)
+}
+
+// ImportSubnetContext is like ImportSubnet, the call is cancelled when ctx is done
+func (a *Management1) ImportSubnetContext(ctx context.Context, net_index uint16, net_key []byte) error {
+	return a.client.CallWithContext(ctx, "ImportSubnet", 0, net_index, net_key).Store()
 }
 
 /*
@@ -298,7 +331,12 @@ UpdateSubnet 		This method is used by the application to generate a new network
 
 */
 func (a *Management1) UpdateSubnet(net_index uint16) error {
-	return a.client.Call("UpdateSubnet", 0, net_index).Store()
+	return a.UpdateSubnetContext(context.Background(), net_index)
+}
+
+// UpdateSubnetContext is like UpdateSubnet, the call is cancelled when ctx is done
+func (a *Management1) UpdateSubnetContext(ctx context.Context, net_index uint16) error {
+	return a.client.CallWithContext(ctx, "UpdateSubnet", 0, net_index).Store()
 }
 
 /*
@@ -312,7 +350,12 @@ DeleteSubnet 		This method is used by the application that to delete a subnet.
 
 */
 func (a *Management1) DeleteSubnet(net_index uint16) error {
-	return a.client.Call("DeleteSubnet", 0, net_index).Store()
+	return a.DeleteSubnetContext(context.Background(), net_index)
+}
+
+// DeleteSubnetContext is like DeleteSubnet, the call is cancelled when ctx is done
+func (a *Management1) DeleteSubnetContext(ctx context.Context, net_index uint16) error {
+	return a.client.CallWithContext(ctx, "DeleteSubnet", 0, net_index).Store()
 }
 
 /*
@@ -342,7 +385,12 @@ SetKeyPhase 		This method is used to set the master key update phase of the
 
 */
 func (a *Management1) SetKeyPhase(net_index uint16, phase uint8) error {
-	return a.client.Call("SetKeyPhase", 0, net_index, phase).Store()
+	return a.SetKeyPhaseContext(context.Background(), net_index, phase)
+}
+
+// SetKeyPhaseContext is like SetKeyPhase, the call is cancelled when ctx is done
+func (a *Management1) SetKeyPhaseContext(ctx context.Context, net_index uint16, phase uint8) error {
+	return a.client.CallWithContext(ctx, "SetKeyPhase", 0, net_index, phase).Store()
 }
 
 /*
@@ -361,7 +409,12 @@ CreateAppKey 		This method is used by the application to generate and add a new
 
 */
 func (a *Management1) CreateAppKey(net_index uint16, app_index uint16) error {
-	return a.client.Call("CreateAppKey", 0, net_index, app_index).Store()
+	return a.CreateAppKeyContext(context.Background(), net_index, app_index)
+}
+
+// CreateAppKeyContext is like CreateAppKey, the call is cancelled when ctx is done
+func (a *Management1) CreateAppKeyContext(ctx context.Context, net_index uint16, app_index uint16) error {
+	return a.client.CallWithContext(ctx, "CreateAppKey", 0, net_index, app_index).Store()
 }
 
 /*
@@ -382,7 +435,12 @@ ImportAppKey 		This method is used by the application to add an application
 
 */
 func (a *Management1) ImportAppKey(net_index uint16, app_index uint16, app_key []byte) error {
-	return a.client.Call("ImportAppKey", 0, net_index, app_index, app_key).Store()
+	return a.ImportAppKeyContext(context.Background(), net_index, app_index, app_key)
+}
+
+// ImportAppKeyContext is like ImportAppKey, the call is cancelled when ctx is done
+func (a *Management1) ImportAppKeyContext(ctx context.Context, net_index uint16, app_index uint16, app_key []byte) error {
+	return a.client.CallWithContext(ctx, "ImportAppKey", 0, net_index, app_index, app_key).Store()
 }
 
 /*
@@ -400,7 +458,12 @@ UpdateAppKey 		This method is used by the application to generate a new
 
 */
 func (a *Management1) UpdateAppKey(app_index uint16) error {
-	return a.client.Call("UpdateAppKey", 0, app_index).Store()
+	return a.UpdateAppKeyContext(context.Background(), app_index)
+}
+
+// UpdateAppKeyContext is like UpdateAppKey, the call is cancelled when ctx is done
+func (a *Management1) UpdateAppKeyContext(ctx context.Context, app_index uint16) error {
+	return a.client.CallWithContext(ctx, "UpdateAppKey", 0, app_index).Store()
 }
 
 /*
@@ -414,7 +477,12 @@ DeleteAppKey 		This method is used by the application to delete an application
 
 */
 func (a *Management1) DeleteAppKey(app_index uint16) error {
-	return a.client.Call("DeleteAppKey", 0, app_index).Store()
+	return a.DeleteAppKeyContext(context.Background(), app_index)
+}
+
+// DeleteAppKeyContext is like DeleteAppKey, the call is cancelled when ctx is done
+func (a *Management1) DeleteAppKeyContext(ctx context.Context, app_index uint16) error {
+	return a.client.CallWithContext(ctx, "DeleteAppKey", 0, app_index).Store()
 }
 
 /*
@@ -435,7 +503,12 @@ ImportRemoteNode 		This method is used by the application to import a remote nod
 
 */
 func (a *Management1) ImportRemoteNode(primary uint16, count uint8, device_key []byte) error {
-	return a.client.Call("ImportRemoteNode", 0, primary, count, device_key).Store()
+	return a.ImportRemoteNodeContext(context.Background(), primary, count, device_key)
+}
+
+// ImportRemoteNodeContext is like ImportRemoteNode, the call is cancelled when ctx is done
+func (a *Management1) ImportRemoteNodeContext(ctx context.Context, primary uint16, count uint8, device_key []byte) error {
+	return a.client.CallWithContext(ctx, "ImportRemoteNode", 0, primary, count, device_key).Store()
 }
 
 /*
@@ -453,5 +526,10 @@ DeleteRemoteNode 		This method is used by the application to delete a remote nod
 
 */
 func (a *Management1) DeleteRemoteNode(primary uint16, count uint8) error {
-	return a.client.Call("DeleteRemoteNode", 0, primary, count).Store()
+	return a.DeleteRemoteNodeContext(context.Background(), primary, count)
+}
+
+// DeleteRemoteNodeContext is like DeleteRemoteNode, the call is cancelled when ctx is done
+func (a *Management1) DeleteRemoteNodeContext(ctx context.Context, primary uint16, count uint8) error {
+	return a.client.CallWithContext(ctx, "DeleteRemoteNode", 0, primary, count).Store()
 }
@@ -3,6 +3,7 @@
 package mesh
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var Network1Interface = "org.bluez.mesh.Network1"
 // Args:
 
 func NewNetwork1() (*Network1, error) {
+	return NewNetwork1WithConn(nil)
+}
+
+// NewNetwork1WithConn create a new instance of Network1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewNetwork1WithConn(conn *bluez.Conn) (*Network1, error) {
 	a := new(Network1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewNetwork1() (*Network1, error) {
 			Iface: Network1Interface,
 			Path:  dbus.ObjectPath("/org/bluez/mesh"),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Network1Properties)
@@ -90,7 +98,7 @@ func (a *Network1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -227,14 +235,24 @@ Join 		This is the first method that an application has to call to
 
 */
 func (a *Network1) Join(app_root dbus.ObjectPath, uuid []byte) error {
-	return a.client.Call("Join", 0, app_root, uuid).Store()
+	return a.JoinContext(context.Background(), app_root, uuid)
+}
+
+// JoinContext is like Join, the call is cancelled when ctx is done
+func (a *Network1) JoinContext(ctx context.Context, app_root dbus.ObjectPath, uuid []byte) error {
+	return a.client.CallWithContext(ctx, "Join", 0, app_root, uuid).Store()
 }
 
 /*
 Cancel
 */
 func (a *Network1) Cancel() error {
-	return a.client.Call("Cancel", 0).Store()
+	return a.CancelContext(context.Background())
+}
+
+// CancelContext is like Cancel, the call is cancelled when ctx is done
+func (a *Network1) CancelContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Cancel", 0).Store()
 }
 
 /*
@@ -293,9 +311,14 @@ Attach 		This is the first method that an application must call to get
 
 */
 func (a *Network1) Attach(app_root dbus.ObjectPath, token uint64) (dbus.ObjectPath, []ConfigurationItem, error) {
+	return a.AttachContext(context.Background(), app_root, token)
+}
+
+// AttachContext is like Attach, the call is cancelled when ctx is done
+func (a *Network1) AttachContext(ctx context.Context, app_root dbus.ObjectPath, token uint64) (dbus.ObjectPath, []ConfigurationItem, error) {
 	var val0 dbus.ObjectPath
 	val1 := []ConfigurationItem{}
-	err := a.client.Call("Attach", 0, app_root, token).Store(&val0, &val1)
+	err := a.client.CallWithContext(ctx, "Attach", 0, app_root, token).Store(&val0, &val1)
 	return val0, val1, err
 }
 
@@ -310,7 +333,12 @@ Leave 		This removes the configuration information about the mesh node
 
 */
 func (a *Network1) Leave(token uint64) error {
-	return a.client.Call("Leave", 0, token).Store()
+	return a.LeaveContext(context.Background(), token)
+}
+
+// LeaveContext is like Leave, the call is cancelled when ctx is done
+func (a *Network1) LeaveContext(ctx context.Context, token uint64) error {
+	return a.client.CallWithContext(ctx, "Leave", 0, token).Store()
 }
 
 /*
@@ -343,7 +371,12 @@ CreateNetwork 		This is the first method that an application calls to become
 
 */
 func (a *Network1) CreateNetwork(app_root dbus.ObjectPath, uuid []byte) error {
-	return a.client.Call("CreateNetwork", 0, app_root, uuid).Store()
+	return a.CreateNetworkContext(context.Background(), app_root, uuid)
+}
+
+// CreateNetworkContext is like CreateNetwork, the call is cancelled when ctx is done
+func (a *Network1) CreateNetworkContext(ctx context.Context, app_root dbus.ObjectPath, uuid []byte) error {
+	return a.client.CallWithContext(ctx, "CreateNetwork", 0, app_root, uuid).Store()
 }
 
 /*
@@ -385,5 +418,10 @@ Import 		This method creates a local mesh node based on node
 
 */
 func (a *Network1) Import(app_root dbus.ObjectPath, uuid []byte, dev_key []byte, net_key []byte, net_index uint16, flags map[string]interface{}, iv_index uint32, unicast uint16) error {
-	return a.client.Call("Import", 0, app_root, uuid, dev_key, net_key, net_index, flags, iv_index, unicast).Store()
+	return a.ImportContext(context.Background(), app_root, uuid, dev_key, net_key, net_index, flags, iv_index, unicast)
+}
+
+// ImportContext is like Import, the call is cancelled when ctx is done
+func (a *Network1) ImportContext(ctx context.Context, app_root dbus.ObjectPath, uuid []byte, dev_key []byte, net_key []byte, net_index uint16, flags map[string]interface{}, iv_index uint32, unicast uint16) error {
+	return a.client.CallWithContext(ctx, "Import", 0, app_root, uuid, dev_key, net_key, net_index, flags, iv_index, unicast).Store()
 }
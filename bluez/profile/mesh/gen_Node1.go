@@ -3,6 +3,7 @@
 package mesh
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var Node1Interface = "org.bluez.mesh.Node1"
 // Args:
 
 func NewNode1(objectPath dbus.ObjectPath) (*Node1, error) {
+	return NewNode1WithConn(nil, objectPath)
+}
+
+// NewNode1WithConn create a new instance of Node1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewNode1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*Node1, error) {
 	a := new(Node1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewNode1(objectPath dbus.ObjectPath) (*Node1, error) {
 			Iface: Node1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Node1Properties)
@@ -278,7 +286,7 @@ func (a *Node1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -418,7 +426,12 @@ Send 		This method is used to send a message originated by a local
 
 */
 func (a *Node1) Send(element_path dbus.ObjectPath, destination uint16, key_index uint16, options map[string]interface{}, data []byte) error {
-	return a.client.Call("Send", 0, element_path, destination, key_index, options, data).Store()
+	return a.SendContext(context.Background(), element_path, destination, key_index, options, data)
+}
+
+// SendContext is like Send, the call is cancelled when ctx is done
+func (a *Node1) SendContext(ctx context.Context, element_path dbus.ObjectPath, destination uint16, key_index uint16, options map[string]interface{}, data []byte) error {
+	return a.client.CallWithContext(ctx, "Send", 0, element_path, destination, key_index, options, data).Store()
 }
 
 /*
@@ -451,7 +464,12 @@ DevKeySend 		This method is used to send a message originated by a local
 
 */
 func (a *Node1) DevKeySend(element_path dbus.ObjectPath, destination uint16, remote bool, net_index uint16, options map[string]interface{}, data []byte) error {
-	return a.client.Call("DevKeySend", 0, element_path, destination, remote, net_index, options, data).Store()
+	return a.DevKeySendContext(context.Background(), element_path, destination, remote, net_index, options, data)
+}
+
+// DevKeySendContext is like DevKeySend, the call is cancelled when ctx is done
+func (a *Node1) DevKeySendContext(ctx context.Context, element_path dbus.ObjectPath, destination uint16, remote bool, net_index uint16, options map[string]interface{}, data []byte) error {
+	return a.client.CallWithContext(ctx, "DevKeySend", 0, element_path, destination, remote, net_index, options, data).Store()
 }
 
 /*
@@ -477,7 +495,12 @@ AddNetKey 		This method is used to send add or update network key originated
 
 */
 func (a *Node1) AddNetKey(element_path dbus.ObjectPath, destination uint16, subnet_index uint16, net_index uint16, update bool) error {
-	return a.client.Call("AddNetKey", 0, element_path, destination, subnet_index, net_index, update).Store()
+	return a.AddNetKeyContext(context.Background(), element_path, destination, subnet_index, net_index, update)
+}
+
+// AddNetKeyContext is like AddNetKey, the call is cancelled when ctx is done
+func (a *Node1) AddNetKeyContext(ctx context.Context, element_path dbus.ObjectPath, destination uint16, subnet_index uint16, net_index uint16, update bool) error {
+	return a.client.CallWithContext(ctx, "AddNetKey", 0, element_path, destination, subnet_index, net_index, update).Store()
 }
 
 /*
@@ -503,7 +526,12 @@ AddAppKey 		This method is used to send add or update network key originated
 
 */
 func (a *Node1) AddAppKey(element_path dbus.ObjectPath, destination uint16, app_index uint16, net_index uint16, update bool) error {
-	return a.client.Call("AddAppKey", 0, element_path, destination, app_index, net_index, update).Store()
+	return a.AddAppKeyContext(context.Background(), element_path, destination, app_index, net_index, update)
+}
+
+// AddAppKeyContext is like AddAppKey, the call is cancelled when ctx is done
+func (a *Node1) AddAppKeyContext(ctx context.Context, element_path dbus.ObjectPath, destination uint16, app_index uint16, net_index uint16, update bool) error {
+	return a.client.CallWithContext(ctx, "AddAppKey", 0, element_path, destination, app_index, net_index, update).Store()
 }
 
 /*
@@ -538,5 +566,10 @@ Publish 		This method is used to send a publication originated by a local
 
 */
 func (a *Node1) Publish(element_path dbus.ObjectPath, model uint16, options map[string]interface{}, data []byte) error {
-	return a.client.Call("Publish", 0, element_path, model, options, data).Store()
+	return a.PublishContext(context.Background(), element_path, model, options, data)
+}
+
+// PublishContext is like Publish, the call is cancelled when ctx is done
+func (a *Node1) PublishContext(ctx context.Context, element_path dbus.ObjectPath, model uint16, options map[string]interface{}, data []byte) error {
+	return a.client.CallWithContext(ctx, "Publish", 0, element_path, model, options, data).Store()
 }
@@ -3,6 +3,7 @@
 package mesh
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -19,6 +20,12 @@ var ProvisionAgent1Interface = "org.bluez.mesh.ProvisionAgent1"
 // - servicePath: unique name
 // - objectPath: freely definable
 func NewProvisionAgent1(servicePath string, objectPath dbus.ObjectPath) (*ProvisionAgent1, error) {
+	return NewProvisionAgent1WithConn(nil, servicePath, objectPath)
+}
+
+// NewProvisionAgent1WithConn create a new instance of ProvisionAgent1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewProvisionAgent1WithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*ProvisionAgent1, error) {
 	a := new(ProvisionAgent1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewProvisionAgent1(servicePath string, objectPath dbus.ObjectPath) (*Provis
 			Iface: ProvisionAgent1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(ProvisionAgent1Properties)
@@ -158,7 +166,7 @@ func (a *ProvisionAgent1) GetObjectManagerSignal() (chan *dbus.Signal, func(), e
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -283,8 +291,13 @@ PrivateKey 		This method is called during provisioning if the Provisioner
 
 */
 func (a *ProvisionAgent1) PrivateKey() ([]byte, error) {
+	return a.PrivateKeyContext(context.Background())
+}
+
+// PrivateKeyContext is like PrivateKey, the call is cancelled when ctx is done
+func (a *ProvisionAgent1) PrivateKeyContext(ctx context.Context) ([]byte, error) {
 	val0 := []byte{}
-	err := a.client.Call("PrivateKey", 0).Store(&val0)
+	err := a.client.CallWithContext(ctx, "PrivateKey", 0).Store(&val0)
 	return val0, err
 }
 
@@ -301,8 +314,13 @@ PublicKey 		This method is called during provisioning if the local device is
 
 */
 func (a *ProvisionAgent1) PublicKey() ([]byte, error) {
+	return a.PublicKeyContext(context.Background())
+}
+
+// PublicKeyContext is like PublicKey, the call is cancelled when ctx is done
+func (a *ProvisionAgent1) PublicKeyContext(ctx context.Context) ([]byte, error) {
 	val0 := []byte{}
-	err := a.client.Call("PublicKey", 0).Store(&val0)
+	err := a.client.CallWithContext(ctx, "PublicKey", 0).Store(&val0)
 	return val0, err
 }
 
@@ -313,7 +331,12 @@ DisplayString 		This method is called when the Daemon has something important
 
 */
 func (a *ProvisionAgent1) DisplayString(value string) error {
-	return a.client.Call("DisplayString", 0, value).Store()
+	return a.DisplayStringContext(context.Background(), value)
+}
+
+// DisplayStringContext is like DisplayString, the call is cancelled when ctx is done
+func (a *ProvisionAgent1) DisplayStringContext(ctx context.Context, value string) error {
+	return a.client.CallWithContext(ctx, "DisplayString", 0, value).Store()
 }
 
 /*
@@ -333,7 +356,12 @@ DisplayNumeric 		This method is called when the Daemon has something important
 
 */
 func (a *ProvisionAgent1) DisplayNumeric(type1 string, number uint32) error {
-	return a.client.Call("DisplayNumeric", 0, type1, number).Store()
+	return a.DisplayNumericContext(context.Background(), type1, number)
+}
+
+// DisplayNumericContext is like DisplayNumeric, the call is cancelled when ctx is done
+func (a *ProvisionAgent1) DisplayNumericContext(ctx context.Context, type1 string, number uint32) error {
+	return a.client.CallWithContext(ctx, "DisplayNumeric", 0, type1, number).Store()
 }
 
 /*
@@ -352,8 +380,13 @@ PromptNumeric 		This method is called when the Daemon requests the user to
 
 */
 func (a *ProvisionAgent1) PromptNumeric(type1 string) (uint32, error) {
+	return a.PromptNumericContext(context.Background(), type1)
+}
+
+// PromptNumericContext is like PromptNumeric, the call is cancelled when ctx is done
+func (a *ProvisionAgent1) PromptNumericContext(ctx context.Context, type1 string) (uint32, error) {
 	var val0 uint32
-	err := a.client.Call("PromptNumeric", 0, type1).Store(&val0)
+	err := a.client.CallWithContext(ctx, "PromptNumeric", 0, type1).Store(&val0)
 	return val0, err
 }
 
@@ -372,8 +405,13 @@ PromptStatic 		This method is called when the Daemon requires a 16 octet byte
 
 */
 func (a *ProvisionAgent1) PromptStatic(type1 string) ([]byte, error) {
+	return a.PromptStaticContext(context.Background(), type1)
+}
+
+// PromptStaticContext is like PromptStatic, the call is cancelled when ctx is done
+func (a *ProvisionAgent1) PromptStaticContext(ctx context.Context, type1 string) ([]byte, error) {
 	val0 := []byte{}
-	err := a.client.Call("PromptStatic", 0, type1).Store(&val0)
+	err := a.client.CallWithContext(ctx, "PromptStatic", 0, type1).Store(&val0)
 	return val0, err
 }
 
@@ -384,5 +422,10 @@ Cancel 		This method gets called by the daemon to cancel any existing
 
 */
 func (a *ProvisionAgent1) Cancel() error {
-	return a.client.Call("Cancel", 0).Store()
+	return a.CancelContext(context.Background())
+}
+
+// CancelContext is like Cancel, the call is cancelled when ctx is done
+func (a *ProvisionAgent1) CancelContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Cancel", 0).Store()
 }
@@ -3,6 +3,7 @@
 package mesh
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -19,6 +20,12 @@ var Provisioner1Interface = "org.bluez.mesh.Provisioner1"
 // - servicePath: unique name
 // - objectPath: freely definable
 func NewProvisioner1(servicePath string, objectPath dbus.ObjectPath) (*Provisioner1, error) {
+	return NewProvisioner1WithConn(nil, servicePath, objectPath)
+}
+
+// NewProvisioner1WithConn create a new instance of Provisioner1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewProvisioner1WithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*Provisioner1, error) {
 	a := new(Provisioner1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewProvisioner1(servicePath string, objectPath dbus.ObjectPath) (*Provision
 			Iface: Provisioner1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Provisioner1Properties)
@@ -91,7 +99,7 @@ func (a *Provisioner1) GetObjectManagerSignal() (chan *dbus.Signal, func(), erro
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -223,7 +231,12 @@ ScanResult 		The method is called from the bluetooth-meshd daemon when a
 
 */
 func (a *Provisioner1) ScanResult(rssi int16, data []byte, options map[string]interface{}) error {
-	return a.client.Call("ScanResult", 0, rssi, data, options).Store()
+	return a.ScanResultContext(context.Background(), rssi, data, options)
+}
+
+// ScanResultContext is like ScanResult, the call is cancelled when ctx is done
+func (a *Provisioner1) ScanResultContext(ctx context.Context, rssi int16, data []byte, options map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "ScanResult", 0, rssi, data, options).Store()
 }
 
 /*
@@ -240,8 +253,13 @@ RequestProvData 		This method is implemented by a Provisioner capable applicatio
 
 */
 func (a *Provisioner1) RequestProvData(count uint8) (uint16, error) {
+	return a.RequestProvDataContext(context.Background(), count)
+}
+
+// RequestProvDataContext is like RequestProvData, the call is cancelled when ctx is done
+func (a *Provisioner1) RequestProvDataContext(ctx context.Context, count uint8) (uint16, error) {
 	var val0 uint16
-	err := a.client.Call("RequestProvData", 0, count).Store(&val0)
+	err := a.client.CallWithContext(ctx, "RequestProvData", 0, count).Store(&val0)
 	return val0, err
 }
 
@@ -257,7 +275,12 @@ AddNodeComplete 		This method is called when the node provisioning initiated
 
 */
 func (a *Provisioner1) AddNodeComplete(uuid []byte, unicast uint16, count uint8) error {
-	return a.client.Call("AddNodeComplete", 0, uuid, unicast, count).Store()
+	return a.AddNodeCompleteContext(context.Background(), uuid, unicast, count)
+}
+
+// AddNodeCompleteContext is like AddNodeComplete, the call is cancelled when ctx is done
+func (a *Provisioner1) AddNodeCompleteContext(ctx context.Context, uuid []byte, unicast uint16, count uint8) error {
+	return a.client.CallWithContext(ctx, "AddNodeComplete", 0, uuid, unicast, count).Store()
 }
 
 /*
@@ -273,5 +296,10 @@ AddNodeFailed 		This method is called when the node provisioning initiated by
 
 */
 func (a *Provisioner1) AddNodeFailed(uuid []byte, reason string) error {
-	return a.client.Call("AddNodeFailed", 0, uuid, reason).Store()
+	return a.AddNodeFailedContext(context.Background(), uuid, reason)
+}
+
+// AddNodeFailedContext is like AddNodeFailed, the call is cancelled when ctx is done
+func (a *Provisioner1) AddNodeFailedContext(ctx context.Context, uuid []byte, reason string) error {
+	return a.client.CallWithContext(ctx, "AddNodeFailed", 0, uuid, reason).Store()
 }
@@ -3,6 +3,7 @@
 package network
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var Network1Interface = "org.bluez.Network1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX
 func NewNetwork1(objectPath dbus.ObjectPath) (*Network1, error) {
+	return NewNetwork1WithConn(nil, objectPath)
+}
+
+// NewNetwork1WithConn create a new instance of Network1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewNetwork1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*Network1, error) {
 	a := new(Network1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewNetwork1(objectPath dbus.ObjectPath) (*Network1, error) {
 			Iface: Network1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Network1Properties)
@@ -147,7 +155,7 @@ func (a *Network1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -273,8 +281,13 @@ Connect 			Connect to the network device and return the network
 
 */
 func (a *Network1) Connect(uuid string) (string, error) {
+	return a.ConnectContext(context.Background(), uuid)
+}
+
+// ConnectContext is like Connect, the call is cancelled when ctx is done
+func (a *Network1) ConnectContext(ctx context.Context, uuid string) (string, error) {
 	var val0 string
-	err := a.client.Call("Connect", 0, uuid).Store(&val0)
+	err := a.client.CallWithContext(ctx, "Connect", 0, uuid).Store(&val0)
 	return val0, err
 }
 
@@ -286,5 +299,10 @@ Disconnect 			Disconnect from the network device.
 
 */
 func (a *Network1) Disconnect() error {
-	return a.client.Call("Disconnect", 0).Store()
+	return a.DisconnectContext(context.Background())
+}
+
+// DisconnectContext is like Disconnect, the call is cancelled when ctx is done
+func (a *Network1) DisconnectContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Disconnect", 0).Store()
 }
@@ -3,6 +3,7 @@
 package network
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var NetworkServer1Interface = "org.bluez.NetworkServer1"
 // Args:
 // - objectPath: /org/bluez/{hci0,hci1,...}
 func NewNetworkServer1(objectPath dbus.ObjectPath) (*NetworkServer1, error) {
+	return NewNetworkServer1WithConn(nil, objectPath)
+}
+
+// NewNetworkServer1WithConn create a new instance of NetworkServer1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewNetworkServer1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*NetworkServer1, error) {
 	a := new(NetworkServer1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewNetworkServer1(objectPath dbus.ObjectPath) (*NetworkServer1, error) {
 			Iface: NetworkServer1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(NetworkServer1Properties)
@@ -90,7 +98,7 @@ func (a *NetworkServer1) GetObjectManagerSignal() (chan *dbus.Signal, func(), er
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -213,7 +221,12 @@ Register 			Register server for the provided UUID. Every new
 
 */
 func (a *NetworkServer1) Register(uuid string, bridge string) error {
-	return a.client.Call("Register", 0, uuid, bridge).Store()
+	return a.RegisterContext(context.Background(), uuid, bridge)
+}
+
+// RegisterContext is like Register, the call is cancelled when ctx is done
+func (a *NetworkServer1) RegisterContext(ctx context.Context, uuid string, bridge string) error {
+	return a.client.CallWithContext(ctx, "Register", 0, uuid, bridge).Store()
 }
 
 /*
@@ -223,5 +236,10 @@ Unregister 			Unregister the server for provided UUID.
 
 */
 func (a *NetworkServer1) Unregister(uuid string) error {
-	return a.client.Call("Unregister", 0, uuid).Store()
+	return a.UnregisterContext(context.Background(), uuid)
+}
+
+// UnregisterContext is like Unregister, the call is cancelled when ctx is done
+func (a *NetworkServer1) UnregisterContext(ctx context.Context, uuid string) error {
+	return a.client.CallWithContext(ctx, "Unregister", 0, uuid).Store()
 }
@@ -3,6 +3,7 @@
 package obex
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var FileTransferInterface = "org.bluez.obex.FileTransfer"
 // Args:
 // - objectPath: [Session object path]
 func NewFileTransfer(objectPath dbus.ObjectPath) (*FileTransfer, error) {
+	return NewFileTransferWithConn(nil, objectPath)
+}
+
+// NewFileTransferWithConn create a new instance of FileTransfer using the given connection.
+// The default system bus connection is used if conn is nil
+func NewFileTransferWithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*FileTransfer, error) {
 	a := new(FileTransfer)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewFileTransfer(objectPath dbus.ObjectPath) (*FileTransfer, error) {
 			Iface: FileTransferInterface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(FileTransferProperties)
@@ -90,7 +98,7 @@ func (a *FileTransfer) GetObjectManagerSignal() (chan *dbus.Signal, func(), erro
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -208,7 +216,12 @@ ChangeFolder 			Change the current folder of the remote device.
 
 */
 func (a *FileTransfer) ChangeFolder(folder string) error {
-	return a.client.Call("ChangeFolder", 0, folder).Store()
+	return a.ChangeFolderContext(context.Background(), folder)
+}
+
+// ChangeFolderContext is like ChangeFolder, the call is cancelled when ctx is done
+func (a *FileTransfer) ChangeFolderContext(ctx context.Context, folder string) error {
+	return a.client.CallWithContext(ctx, "ChangeFolder", 0, folder).Store()
 }
 
 /*
@@ -218,7 +231,12 @@ CreateFolder 			Create a new folder in the remote device.
 
 */
 func (a *FileTransfer) CreateFolder(folder string) error {
-	return a.client.Call("CreateFolder", 0, folder).Store()
+	return a.CreateFolderContext(context.Background(), folder)
+}
+
+// CreateFolderContext is like CreateFolder, the call is cancelled when ctx is done
+func (a *FileTransfer) CreateFolderContext(ctx context.Context, folder string) error {
+	return a.client.CallWithContext(ctx, "CreateFolder", 0, folder).Store()
 }
 
 /*
@@ -238,8 +256,13 @@ ListFolder 			Returns a dictionary containing information about
 
 */
 func (a *FileTransfer) ListFolder() ([]map[string]interface{}, error) {
+	return a.ListFolderContext(context.Background())
+}
+
+// ListFolderContext is like ListFolder, the call is cancelled when ctx is done
+func (a *FileTransfer) ListFolderContext(ctx context.Context) ([]map[string]interface{}, error) {
 	val0 := []map[string]interface{}{}
-	err := a.client.Call("ListFolder", 0).Store(&val0)
+	err := a.client.CallWithContext(ctx, "ListFolder", 0).Store(&val0)
 	return val0, err
 }
 
@@ -258,9 +281,14 @@ GetFile 			Copy the source file (from remote device) to the
 
 */
 func (a *FileTransfer) GetFile(targetfile string, sourcefile string) (dbus.ObjectPath, map[string]interface{}, error) {
+	return a.GetFileContext(context.Background(), targetfile, sourcefile)
+}
+
+// GetFileContext is like GetFile, the call is cancelled when ctx is done
+func (a *FileTransfer) GetFileContext(ctx context.Context, targetfile string, sourcefile string) (dbus.ObjectPath, map[string]interface{}, error) {
 	var val0 dbus.ObjectPath
 	var val1 map[string]interface{}
-	err := a.client.Call("GetFile", 0, targetfile, sourcefile).Store(&val0, &val1)
+	err := a.client.CallWithContext(ctx, "GetFile", 0, targetfile, sourcefile).Store(&val0, &val1)
 	return val0, val1, err
 }
 
@@ -277,9 +305,14 @@ PutFile 			Copy the source file (from local filesystem) to the
 
 */
 func (a *FileTransfer) PutFile(sourcefile string, targetfile string) (dbus.ObjectPath, map[string]interface{}, error) {
+	return a.PutFileContext(context.Background(), sourcefile, targetfile)
+}
+
+// PutFileContext is like PutFile, the call is cancelled when ctx is done
+func (a *FileTransfer) PutFileContext(ctx context.Context, sourcefile string, targetfile string) (dbus.ObjectPath, map[string]interface{}, error) {
 	var val0 dbus.ObjectPath
 	var val1 map[string]interface{}
-	err := a.client.Call("PutFile", 0, sourcefile, targetfile).Store(&val0, &val1)
+	err := a.client.CallWithContext(ctx, "PutFile", 0, sourcefile, targetfile).Store(&val0, &val1)
 	return val0, val1, err
 }
 
@@ -291,7 +324,12 @@ CopyFile 			Copy a file within the remote device from source file
 
 */
 func (a *FileTransfer) CopyFile(sourcefile string, targetfile string) error {
-	return a.client.Call("CopyFile", 0, sourcefile, targetfile).Store()
+	return a.CopyFileContext(context.Background(), sourcefile, targetfile)
+}
+
+// CopyFileContext is like CopyFile, the call is cancelled when ctx is done
+func (a *FileTransfer) CopyFileContext(ctx context.Context, sourcefile string, targetfile string) error {
+	return a.client.CallWithContext(ctx, "CopyFile", 0, sourcefile, targetfile).Store()
 }
 
 /*
@@ -302,7 +340,12 @@ MoveFile 			Move a file within the remote device from source file
 
 */
 func (a *FileTransfer) MoveFile(sourcefile string, targetfile string) error {
-	return a.client.Call("MoveFile", 0, sourcefile, targetfile).Store()
+	return a.MoveFileContext(context.Background(), sourcefile, targetfile)
+}
+
+// MoveFileContext is like MoveFile, the call is cancelled when ctx is done
+func (a *FileTransfer) MoveFileContext(ctx context.Context, sourcefile string, targetfile string) error {
+	return a.client.CallWithContext(ctx, "MoveFile", 0, sourcefile, targetfile).Store()
 }
 
 /*
@@ -312,5 +355,10 @@ Delete 			Deletes the specified file/folder.
 
 */
 func (a *FileTransfer) Delete(file string) error {
-	return a.client.Call("Delete", 0, file).Store()
+	return a.DeleteContext(context.Background(), file)
+}
+
+// DeleteContext is like Delete, the call is cancelled when ctx is done
+func (a *FileTransfer) DeleteContext(ctx context.Context, file string) error {
+	return a.client.CallWithContext(ctx, "Delete", 0, file).Store()
 }
@@ -3,6 +3,7 @@
 package obex
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var Message1Interface = "org.bluez.obex.Message1"
 // Args:
 // - objectPath: [Session object path]/{message0,...}
 func NewMessage1(objectPath dbus.ObjectPath) (*Message1, error) {
+	return NewMessage1WithConn(nil, objectPath)
+}
+
+// NewMessage1WithConn create a new instance of Message1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMessage1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*Message1, error) {
 	a := new(Message1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewMessage1(objectPath dbus.ObjectPath) (*Message1, error) {
 			Iface: Message1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Message1Properties)
@@ -385,7 +393,7 @@ func (a *Message1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -510,8 +518,13 @@ Get 			Download message and store it in the target file.
 
 */
 func (a *Message1) Get(targetfile string, attachment bool) (dbus.ObjectPath, map[string]interface{}, error) {
+	return a.GetContext(context.Background(), targetfile, attachment)
+}
+
+// GetContext is like Get, the call is cancelled when ctx is done
+func (a *Message1) GetContext(ctx context.Context, targetfile string, attachment bool) (dbus.ObjectPath, map[string]interface{}, error) {
 	var val0 dbus.ObjectPath
 	var val1 map[string]interface{}
-	err := a.client.Call("Get", 0, targetfile, attachment).Store(&val0, &val1)
+	err := a.client.CallWithContext(ctx, "Get", 0, targetfile, attachment).Store(&val0, &val1)
 	return val0, val1, err
 }
@@ -3,6 +3,7 @@
 package obex
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var MessageAccess1Interface = "org.bluez.obex.MessageAccess1"
 // Args:
 // - objectPath: [Session object path]
 func NewMessageAccess1(objectPath dbus.ObjectPath) (*MessageAccess1, error) {
+	return NewMessageAccess1WithConn(nil, objectPath)
+}
+
+// NewMessageAccess1WithConn create a new instance of MessageAccess1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewMessageAccess1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*MessageAccess1, error) {
 	a := new(MessageAccess1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewMessageAccess1(objectPath dbus.ObjectPath) (*MessageAccess1, error) {
 			Iface: MessageAccess1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(MessageAccess1Properties)
@@ -90,7 +98,7 @@ func (a *MessageAccess1) GetObjectManagerSignal() (chan *dbus.Signal, func(), er
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -209,7 +217,12 @@ SetFolder 			Set working directory for current session, *name* may
 
 */
 func (a *MessageAccess1) SetFolder(name string) error {
-	return a.client.Call("SetFolder", 0, name).Store()
+	return a.SetFolderContext(context.Background(), name)
+}
+
+// SetFolderContext is like SetFolder, the call is cancelled when ctx is done
+func (a *MessageAccess1) SetFolderContext(ctx context.Context, name string) error {
+	return a.client.CallWithContext(ctx, "SetFolder", 0, name).Store()
 }
 
 /*
@@ -223,8 +236,13 @@ ListFolders 			Returns a dictionary containing information about
 
 */
 func (a *MessageAccess1) ListFolders(filter map[string]interface{}) ([]map[string]interface{}, error) {
+	return a.ListFoldersContext(context.Background(), filter)
+}
+
+// ListFoldersContext is like ListFolders, the call is cancelled when ctx is done
+func (a *MessageAccess1) ListFoldersContext(ctx context.Context, filter map[string]interface{}) ([]map[string]interface{}, error) {
 	val0 := []map[string]interface{}{}
-	err := a.client.Call("ListFolders", 0, filter).Store(&val0)
+	err := a.client.CallWithContext(ctx, "ListFolders", 0, filter).Store(&val0)
 	return val0, err
 }
 
@@ -235,8 +253,13 @@ ListFilterFields 			Return all available fields that can be used in Fields
 
 */
 func (a *MessageAccess1) ListFilterFields() ([]string, error) {
+	return a.ListFilterFieldsContext(context.Background())
+}
+
+// ListFilterFieldsContext is like ListFilterFields, the call is cancelled when ctx is done
+func (a *MessageAccess1) ListFilterFieldsContext(ctx context.Context) ([]string, error) {
 	val0 := []string{}
-	err := a.client.Call("ListFilterFields", 0).Store(&val0)
+	err := a.client.CallWithContext(ctx, "ListFilterFields", 0).Store(&val0)
 	return val0, err
 }
 
@@ -296,8 +319,13 @@ ListMessages 			Returns an array containing the messages found in the
 
 */
 func (a *MessageAccess1) ListMessages(folder string, filter map[string]interface{}) ([]Message, error) {
+	return a.ListMessagesContext(context.Background(), folder, filter)
+}
+
+// ListMessagesContext is like ListMessages, the call is cancelled when ctx is done
+func (a *MessageAccess1) ListMessagesContext(ctx context.Context, folder string, filter map[string]interface{}) ([]Message, error) {
 	val0 := []Message{}
-	err := a.client.Call("ListMessages", 0, folder, filter).Store(&val0)
+	err := a.client.CallWithContext(ctx, "ListMessages", 0, folder, filter).Store(&val0)
 	return val0, err
 }
 
@@ -305,5 +333,10 @@ func (a *MessageAccess1) ListMessages(folder string, filter map[string]interface
 UpdateInbox
 */
 func (a *MessageAccess1) UpdateInbox() error {
-	return a.client.Call("UpdateInbox", 0).Store()
+	return a.UpdateInboxContext(context.Background())
+}
+
+// UpdateInboxContext is like UpdateInbox, the call is cancelled when ctx is done
+func (a *MessageAccess1) UpdateInboxContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "UpdateInbox", 0).Store()
 }
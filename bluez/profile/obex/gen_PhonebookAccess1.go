@@ -3,6 +3,7 @@
 package obex
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var PhonebookAccess1Interface = "org.bluez.obex.PhonebookAccess1"
 // Args:
 // - objectPath: [Session object path]
 func NewPhonebookAccess1(objectPath dbus.ObjectPath) (*PhonebookAccess1, error) {
+	return NewPhonebookAccess1WithConn(nil, objectPath)
+}
+
+// NewPhonebookAccess1WithConn create a new instance of PhonebookAccess1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewPhonebookAccess1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*PhonebookAccess1, error) {
 	a := new(PhonebookAccess1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewPhonebookAccess1(objectPath dbus.ObjectPath) (*PhonebookAccess1, error)
 			Iface: PhonebookAccess1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(PhonebookAccess1Properties)
@@ -197,7 +205,7 @@ func (a *PhonebookAccess1) GetObjectManagerSignal() (chan *dbus.Signal, func(),
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -330,7 +338,12 @@ Select 			Select the phonebook object for other operations. Should
 
 */
 func (a *PhonebookAccess1) Select(location string, phonebook string) error {
-	return a.client.Call("Select", 0, location, phonebook).Store()
+	return a.SelectContext(context.Background(), location, phonebook)
+}
+
+// SelectContext is like Select, the call is cancelled when ctx is done
+func (a *PhonebookAccess1) SelectContext(ctx context.Context, location string, phonebook string) error {
+	return a.client.CallWithContext(ctx, "Select", 0, location, phonebook).Store()
 }
 
 /*
@@ -351,9 +364,14 @@ PullAll 			Return the entire phonebook object from the PSE server
 
 */
 func (a *PhonebookAccess1) PullAll(targetfile string, filters map[string]interface{}) (dbus.ObjectPath, map[string]interface{}, error) {
+	return a.PullAllContext(context.Background(), targetfile, filters)
+}
+
+// PullAllContext is like PullAll, the call is cancelled when ctx is done
+func (a *PhonebookAccess1) PullAllContext(ctx context.Context, targetfile string, filters map[string]interface{}) (dbus.ObjectPath, map[string]interface{}, error) {
 	var val0 dbus.ObjectPath
 	var val1 map[string]interface{}
-	err := a.client.Call("PullAll", 0, targetfile, filters).Store(&val0, &val1)
+	err := a.client.CallWithContext(ctx, "PullAll", 0, targetfile, filters).Store(&val0, &val1)
 	return val0, val1, err
 }
 
@@ -368,8 +386,13 @@ List 			Return an array of vcard-listing data where every entry
 
 */
 func (a *PhonebookAccess1) List(filters map[string]interface{}) ([]VCardItem, error) {
+	return a.ListContext(context.Background(), filters)
+}
+
+// ListContext is like List, the call is cancelled when ctx is done
+func (a *PhonebookAccess1) ListContext(ctx context.Context, filters map[string]interface{}) ([]VCardItem, error) {
 	val0 := []VCardItem{}
-	err := a.client.Call("List", 0, filters).Store(&val0)
+	err := a.client.CallWithContext(ctx, "List", 0, filters).Store(&val0)
 	return val0, err
 }
 
@@ -390,9 +413,14 @@ Pull 			Given a vcard handle, retrieve the vcard in the current
 
 */
 func (a *PhonebookAccess1) Pull(vcard string, targetfile string, filters map[string]interface{}) (dbus.ObjectPath, map[string]interface{}, error) {
+	return a.PullContext(context.Background(), vcard, targetfile, filters)
+}
+
+// PullContext is like Pull, the call is cancelled when ctx is done
+func (a *PhonebookAccess1) PullContext(ctx context.Context, vcard string, targetfile string, filters map[string]interface{}) (dbus.ObjectPath, map[string]interface{}, error) {
 	var val0 dbus.ObjectPath
 	var val1 map[string]interface{}
-	err := a.client.Call("Pull", 0, vcard, targetfile, filters).Store(&val0, &val1)
+	err := a.client.CallWithContext(ctx, "Pull", 0, vcard, targetfile, filters).Store(&val0, &val1)
 	return val0, val1, err
 }
 
@@ -412,8 +440,13 @@ Search 			Search for entries matching the given condition and
 
 */
 func (a *PhonebookAccess1) Search(field string, value string, filters map[string]interface{}) ([]VCardItem, error) {
+	return a.SearchContext(context.Background(), field, value, filters)
+}
+
+// SearchContext is like Search, the call is cancelled when ctx is done
+func (a *PhonebookAccess1) SearchContext(ctx context.Context, field string, value string, filters map[string]interface{}) ([]VCardItem, error) {
 	val0 := []VCardItem{}
-	err := a.client.Call("Search", 0, field, value, filters).Store(&val0)
+	err := a.client.CallWithContext(ctx, "Search", 0, field, value, filters).Store(&val0)
 	return val0, err
 }
 
@@ -426,8 +459,13 @@ GetSize 			Return the number of entries in the selected phonebook
 
 */
 func (a *PhonebookAccess1) GetSize() (uint16, error) {
+	return a.GetSizeContext(context.Background())
+}
+
+// GetSizeContext is like GetSize, the call is cancelled when ctx is done
+func (a *PhonebookAccess1) GetSizeContext(ctx context.Context) (uint16, error) {
 	var val0 uint16
-	err := a.client.Call("GetSize", 0).Store(&val0)
+	err := a.client.CallWithContext(ctx, "GetSize", 0).Store(&val0)
 	return val0, err
 }
 
@@ -439,7 +477,12 @@ UpdateVersion 			Attempt to update PrimaryCounter and SecondaryCounter.
 
 */
 func (a *PhonebookAccess1) UpdateVersion() error {
-	return a.client.Call("UpdateVersion", 0).Store()
+	return a.UpdateVersionContext(context.Background())
+}
+
+// UpdateVersionContext is like UpdateVersion, the call is cancelled when ctx is done
+func (a *PhonebookAccess1) UpdateVersionContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "UpdateVersion", 0).Store()
 }
 
 /*
@@ -449,7 +492,12 @@ ListFilterFields 			Return All Available fields that can be used in Fields
 
 */
 func (a *PhonebookAccess1) ListFilterFields() ([]string, error) {
+	return a.ListFilterFieldsContext(context.Background())
+}
+
+// ListFilterFieldsContext is like ListFilterFields, the call is cancelled when ctx is done
+func (a *PhonebookAccess1) ListFilterFieldsContext(ctx context.Context) ([]string, error) {
 	val0 := []string{}
-	err := a.client.Call("ListFilterFields", 0).Store(&val0)
+	err := a.client.CallWithContext(ctx, "ListFilterFields", 0).Store(&val0)
 	return val0, err
 }
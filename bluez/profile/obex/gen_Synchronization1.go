@@ -3,6 +3,7 @@
 package obex
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var Synchronization1Interface = "org.bluez.obex.Synchronization1"
 // Args:
 // - objectPath: [Session object path]
 func NewSynchronization1(objectPath dbus.ObjectPath) (*Synchronization1, error) {
+	return NewSynchronization1WithConn(nil, objectPath)
+}
+
+// NewSynchronization1WithConn create a new instance of Synchronization1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewSynchronization1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*Synchronization1, error) {
 	a := new(Synchronization1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewSynchronization1(objectPath dbus.ObjectPath) (*Synchronization1, error)
 			Iface: Synchronization1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Synchronization1Properties)
@@ -90,7 +98,7 @@ func (a *Synchronization1) GetObjectManagerSignal() (chan *dbus.Signal, func(),
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -215,7 +223,12 @@ SetLocation 			Set the phonebook object store location for other
 
 */
 func (a *Synchronization1) SetLocation(location string) error {
-	return a.client.Call("SetLocation", 0, location).Store()
+	return a.SetLocationContext(context.Background(), location)
+}
+
+// SetLocationContext is like SetLocation, the call is cancelled when ctx is done
+func (a *Synchronization1) SetLocationContext(ctx context.Context, location string) error {
+	return a.client.CallWithContext(ctx, "SetLocation", 0, location).Store()
 }
 
 /*
@@ -233,9 +246,14 @@ GetPhonebook 			Retrieve an entire Phonebook Object store from remote
 
 */
 func (a *Synchronization1) GetPhonebook(targetfile string) (dbus.ObjectPath, map[string]interface{}, error) {
+	return a.GetPhonebookContext(context.Background(), targetfile)
+}
+
+// GetPhonebookContext is like GetPhonebook, the call is cancelled when ctx is done
+func (a *Synchronization1) GetPhonebookContext(ctx context.Context, targetfile string) (dbus.ObjectPath, map[string]interface{}, error) {
 	var val0 dbus.ObjectPath
 	var val1 map[string]interface{}
-	err := a.client.Call("GetPhonebook", 0, targetfile).Store(&val0, &val1)
+	err := a.client.CallWithContext(ctx, "GetPhonebook", 0, targetfile).Store(&val0, &val1)
 	return val0, val1, err
 }
 
@@ -251,8 +269,13 @@ PutPhonebook 			Send an entire Phonebook Object store to remote device.
 
 */
 func (a *Synchronization1) PutPhonebook(sourcefile string) (dbus.ObjectPath, map[string]interface{}, error) {
+	return a.PutPhonebookContext(context.Background(), sourcefile)
+}
+
+// PutPhonebookContext is like PutPhonebook, the call is cancelled when ctx is done
+func (a *Synchronization1) PutPhonebookContext(ctx context.Context, sourcefile string) (dbus.ObjectPath, map[string]interface{}, error) {
 	var val0 dbus.ObjectPath
 	var val1 map[string]interface{}
-	err := a.client.Call("PutPhonebook", 0, sourcefile).Store(&val0, &val1)
+	err := a.client.CallWithContext(ctx, "PutPhonebook", 0, sourcefile).Store(&val0, &val1)
 	return val0, val1, err
 }
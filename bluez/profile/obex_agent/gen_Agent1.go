@@ -3,6 +3,7 @@
 package obex_agent
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -19,6 +20,12 @@ var Agent1Interface = "org.bluez.obex.Agent1"
 // - servicePath: unique name
 // - objectPath: freely definable
 func NewAgent1(servicePath string, objectPath dbus.ObjectPath) (*Agent1, error) {
+	return NewAgent1WithConn(nil, servicePath, objectPath)
+}
+
+// NewAgent1WithConn create a new instance of Agent1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewAgent1WithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*Agent1, error) {
 	a := new(Agent1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewAgent1(servicePath string, objectPath dbus.ObjectPath) (*Agent1, error)
 			Iface: Agent1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Agent1Properties)
@@ -91,7 +99,7 @@ func (a *Agent1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -211,7 +219,12 @@ Release 			This method gets called when the service daemon
 
 */
 func (a *Agent1) Release() error {
-	return a.client.Call("Release", 0).Store()
+	return a.ReleaseContext(context.Background())
+}
+
+// ReleaseContext is like Release, the call is cancelled when ctx is done
+func (a *Agent1) ReleaseContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Release", 0).Store()
 }
 
 /*
@@ -226,8 +239,13 @@ AuthorizePush 			This method gets called when the service daemon
 
 */
 func (a *Agent1) AuthorizePush(transfer dbus.ObjectPath) (string, error) {
+	return a.AuthorizePushContext(context.Background(), transfer)
+}
+
+// AuthorizePushContext is like AuthorizePush, the call is cancelled when ctx is done
+func (a *Agent1) AuthorizePushContext(ctx context.Context, transfer dbus.ObjectPath) (string, error) {
 	var val0 string
-	err := a.client.Call("AuthorizePush", 0, transfer).Store(&val0)
+	err := a.client.CallWithContext(ctx, "AuthorizePush", 0, transfer).Store(&val0)
 	return val0, err
 }
 
@@ -238,5 +256,10 @@ Cancel 			This method gets called to indicate that the agent
 
 */
 func (a *Agent1) Cancel() error {
-	return a.client.Call("Cancel", 0).Store()
+	return a.CancelContext(context.Background())
+}
+
+// CancelContext is like Cancel, the call is cancelled when ctx is done
+func (a *Agent1) CancelContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Cancel", 0).Store()
 }
@@ -3,6 +3,7 @@
 package obex_agent
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var AgentManager1Interface = "org.bluez.obex.AgentManager1"
 // Args:
 
 func NewAgentManager1() (*AgentManager1, error) {
+	return NewAgentManager1WithConn(nil)
+}
+
+// NewAgentManager1WithConn create a new instance of AgentManager1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewAgentManager1WithConn(conn *bluez.Conn) (*AgentManager1, error) {
 	a := new(AgentManager1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewAgentManager1() (*AgentManager1, error) {
 			Iface: AgentManager1Interface,
 			Path:  dbus.ObjectPath("/org/bluez/obex"),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(AgentManager1Properties)
@@ -90,7 +98,7 @@ func (a *AgentManager1) GetObjectManagerSignal() (chan *dbus.Signal, func(), err
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -209,7 +217,12 @@ RegisterAgent 			Register an agent to request authorization of
 
 */
 func (a *AgentManager1) RegisterAgent(agent dbus.ObjectPath) error {
-	return a.client.Call("RegisterAgent", 0, agent).Store()
+	return a.RegisterAgentContext(context.Background(), agent)
+}
+
+// RegisterAgentContext is like RegisterAgent, the call is cancelled when ctx is done
+func (a *AgentManager1) RegisterAgentContext(ctx context.Context, agent dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "RegisterAgent", 0, agent).Store()
 }
 
 /*
@@ -220,5 +233,10 @@ UnregisterAgent 			This unregisters the agent that has been previously
 
 */
 func (a *AgentManager1) UnregisterAgent(agent dbus.ObjectPath) error {
-	return a.client.Call("UnregisterAgent", 0, agent).Store()
+	return a.UnregisterAgentContext(context.Background(), agent)
+}
+
+// UnregisterAgentContext is like UnregisterAgent, the call is cancelled when ctx is done
+func (a *AgentManager1) UnregisterAgentContext(ctx context.Context, agent dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "UnregisterAgent", 0, agent).Store()
 }
@@ -1,6 +1,8 @@
 package profile
 
-import "github.com/godbus/dbus/v5"
+import (
+	"github.com/godbus/dbus/v5"
+)
 
 // BluezApi is the shared interface for the Bluez API implmentation
 type BluezApi interface {
@@ -3,6 +3,7 @@
 package profile
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -17,6 +18,12 @@ var Profile1Interface = "org.bluez.Profile1"
 // - servicePath: unique name
 // - objectPath: freely definable
 func NewProfile1(servicePath string, objectPath dbus.ObjectPath) (*Profile1, error) {
+	return NewProfile1WithConn(nil, servicePath, objectPath)
+}
+
+// NewProfile1WithConn create a new instance of Profile1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewProfile1WithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*Profile1, error) {
 	a := new(Profile1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -24,6 +31,7 @@ func NewProfile1(servicePath string, objectPath dbus.ObjectPath) (*Profile1, err
 			Iface: Profile1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	return a, nil
@@ -82,7 +90,7 @@ func (a *Profile1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error) {
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -117,7 +125,12 @@ Release 			This method gets called when the service daemon
 
 */
 func (a *Profile1) Release() error {
-	return a.client.Call("Release", 0).Store()
+	return a.ReleaseContext(context.Background())
+}
+
+// ReleaseContext is like Release, the call is cancelled when ctx is done
+func (a *Profile1) ReleaseContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Release", 0).Store()
 }
 
 /*
@@ -131,7 +144,12 @@ NewConnection 			This method gets called when a new service level
 
 */
 func (a *Profile1) NewConnection(device dbus.ObjectPath, fd int32, fd_properties map[string]interface{}) error {
-	return a.client.Call("NewConnection", 0, device, fd, fd_properties).Store()
+	return a.NewConnectionContext(context.Background(), device, fd, fd_properties)
+}
+
+// NewConnectionContext is like NewConnection, the call is cancelled when ctx is done
+func (a *Profile1) NewConnectionContext(ctx context.Context, device dbus.ObjectPath, fd int32, fd_properties map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "NewConnection", 0, device, fd, fd_properties).Store()
 }
 
 /*
@@ -149,5 +167,10 @@ RequestDisconnection 			This method gets called when a profile gets
 
 */
 func (a *Profile1) RequestDisconnection(device dbus.ObjectPath) error {
-	return a.client.Call("RequestDisconnection", 0, device).Store()
+	return a.RequestDisconnectionContext(context.Background(), device)
+}
+
+// RequestDisconnectionContext is like RequestDisconnection, the call is cancelled when ctx is done
+func (a *Profile1) RequestDisconnectionContext(ctx context.Context, device dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "RequestDisconnection", 0, device).Store()
 }
@@ -3,6 +3,7 @@
 package profile
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -16,6 +17,12 @@ var ProfileManager1Interface = "org.bluez.ProfileManager1"
 // Args:
 
 func NewProfileManager1() (*ProfileManager1, error) {
+	return NewProfileManager1WithConn(nil)
+}
+
+// NewProfileManager1WithConn create a new instance of ProfileManager1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewProfileManager1WithConn(conn *bluez.Conn) (*ProfileManager1, error) {
 	a := new(ProfileManager1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -23,6 +30,7 @@ func NewProfileManager1() (*ProfileManager1, error) {
 			Iface: ProfileManager1Interface,
 			Path:  dbus.ObjectPath("/org/bluez"),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	return a, nil
@@ -81,7 +89,7 @@ func (a *ProfileManager1) GetObjectManagerSignal() (chan *dbus.Signal, func(), e
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -177,7 +185,12 @@ RegisterProfile 			This registers a profile implementation.
 
 */
 func (a *ProfileManager1) RegisterProfile(profile dbus.ObjectPath, uuid string, options map[string]interface{}) error {
-	return a.client.Call("RegisterProfile", 0, profile, uuid, options).Store()
+	return a.RegisterProfileContext(context.Background(), profile, uuid, options)
+}
+
+// RegisterProfileContext is like RegisterProfile, the call is cancelled when ctx is done
+func (a *ProfileManager1) RegisterProfileContext(ctx context.Context, profile dbus.ObjectPath, uuid string, options map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "RegisterProfile", 0, profile, uuid, options).Store()
 }
 
 /*
@@ -188,5 +201,10 @@ UnregisterProfile 			This unregisters the profile that has been previously
 
 */
 func (a *ProfileManager1) UnregisterProfile(profile dbus.ObjectPath) error {
-	return a.client.Call("UnregisterProfile", 0, profile).Store()
+	return a.UnregisterProfileContext(context.Background(), profile)
+}
+
+// UnregisterProfileContext is like UnregisterProfile, the call is cancelled when ctx is done
+func (a *ProfileManager1) UnregisterProfileContext(ctx context.Context, profile dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "UnregisterProfile", 0, profile).Store()
 }
@@ -3,6 +3,7 @@
 package sap
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var SimAccess1Interface = "org.bluez.SimAccess1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}
 func NewSimAccess1(objectPath dbus.ObjectPath) (*SimAccess1, error) {
+	return NewSimAccess1WithConn(nil, objectPath)
+}
+
+// NewSimAccess1WithConn create a new instance of SimAccess1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewSimAccess1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*SimAccess1, error) {
 	a := new(SimAccess1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewSimAccess1(objectPath dbus.ObjectPath) (*SimAccess1, error) {
 			Iface: SimAccess1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(SimAccess1Properties)
@@ -109,7 +117,7 @@ func (a *SimAccess1) GetObjectManagerSignal() (chan *dbus.Signal, func(), error)
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -226,5 +234,10 @@ Disconnect 			Disconnects SAP client from the server.
 
 */
 func (a *SimAccess1) Disconnect() error {
-	return a.client.Call("Disconnect", 0).Store()
+	return a.DisconnectContext(context.Background())
+}
+
+// DisconnectContext is like Disconnect, the call is cancelled when ctx is done
+func (a *SimAccess1) DisconnectContext(ctx context.Context) error {
+	return a.client.CallWithContext(ctx, "Disconnect", 0).Store()
 }
@@ -18,6 +18,12 @@ var Thermometer1Interface = "org.bluez.Thermometer1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}/dev_XX_XX_XX_XX_XX_XX
 func NewThermometer1(objectPath dbus.ObjectPath) (*Thermometer1, error) {
+	return NewThermometer1WithConn(nil, objectPath)
+}
+
+// NewThermometer1WithConn create a new instance of Thermometer1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewThermometer1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*Thermometer1, error) {
 	a := new(Thermometer1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +31,7 @@ func NewThermometer1(objectPath dbus.ObjectPath) (*Thermometer1, error) {
 			Iface: Thermometer1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(Thermometer1Properties)
@@ -174,7 +181,7 @@ func (a *Thermometer1) GetObjectManagerSignal() (chan *dbus.Signal, func(), erro
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
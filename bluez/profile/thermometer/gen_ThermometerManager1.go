@@ -3,6 +3,7 @@
 package thermometer
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -18,6 +19,12 @@ var ThermometerManager1Interface = "org.bluez.ThermometerManager1"
 // Args:
 // - objectPath: [variable prefix]/{hci0,hci1,...}
 func NewThermometerManager1(objectPath dbus.ObjectPath) (*ThermometerManager1, error) {
+	return NewThermometerManager1WithConn(nil, objectPath)
+}
+
+// NewThermometerManager1WithConn create a new instance of ThermometerManager1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewThermometerManager1WithConn(conn *bluez.Conn, objectPath dbus.ObjectPath) (*ThermometerManager1, error) {
 	a := new(ThermometerManager1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -25,6 +32,7 @@ func NewThermometerManager1(objectPath dbus.ObjectPath) (*ThermometerManager1, e
 			Iface: ThermometerManager1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(ThermometerManager1Properties)
@@ -90,7 +98,7 @@ func (a *ThermometerManager1) GetObjectManagerSignal() (chan *dbus.Signal, func(
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -209,7 +217,12 @@ RegisterWatcher 			Registers a watcher to monitor scanned measurements.
 
 */
 func (a *ThermometerManager1) RegisterWatcher(agent dbus.ObjectPath) error {
-	return a.client.Call("RegisterWatcher", 0, agent).Store()
+	return a.RegisterWatcherContext(context.Background(), agent)
+}
+
+// RegisterWatcherContext is like RegisterWatcher, the call is cancelled when ctx is done
+func (a *ThermometerManager1) RegisterWatcherContext(ctx context.Context, agent dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "RegisterWatcher", 0, agent).Store()
 }
 
 /*
@@ -217,7 +230,12 @@ UnregisterWatcher 			Unregisters a watcher.
 
 */
 func (a *ThermometerManager1) UnregisterWatcher(agent dbus.ObjectPath) error {
-	return a.client.Call("UnregisterWatcher", 0, agent).Store()
+	return a.UnregisterWatcherContext(context.Background(), agent)
+}
+
+// UnregisterWatcherContext is like UnregisterWatcher, the call is cancelled when ctx is done
+func (a *ThermometerManager1) UnregisterWatcherContext(ctx context.Context, agent dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "UnregisterWatcher", 0, agent).Store()
 }
 
 /*
@@ -228,7 +246,12 @@ EnableIntermediateMeasurement 			Enables intermediate measurement notifications
 
 */
 func (a *ThermometerManager1) EnableIntermediateMeasurement(agent dbus.ObjectPath) error {
-	return a.client.Call("EnableIntermediateMeasurement", 0, agent).Store()
+	return a.EnableIntermediateMeasurementContext(context.Background(), agent)
+}
+
+// EnableIntermediateMeasurementContext is like EnableIntermediateMeasurement, the call is cancelled when ctx is done
+func (a *ThermometerManager1) EnableIntermediateMeasurementContext(ctx context.Context, agent dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "EnableIntermediateMeasurement", 0, agent).Store()
 }
 
 /*
@@ -241,5 +264,10 @@ DisableIntermediateMeasurement 			Disables intermediate measurement notification
 
 */
 func (a *ThermometerManager1) DisableIntermediateMeasurement(agent dbus.ObjectPath) error {
-	return a.client.Call("DisableIntermediateMeasurement", 0, agent).Store()
+	return a.DisableIntermediateMeasurementContext(context.Background(), agent)
+}
+
+// DisableIntermediateMeasurementContext is like DisableIntermediateMeasurement, the call is cancelled when ctx is done
+func (a *ThermometerManager1) DisableIntermediateMeasurementContext(ctx context.Context, agent dbus.ObjectPath) error {
+	return a.client.CallWithContext(ctx, "DisableIntermediateMeasurement", 0, agent).Store()
 }
@@ -3,6 +3,7 @@
 package thermometer
 
 import (
+	"context"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
@@ -19,6 +20,12 @@ var ThermometerWatcher1Interface = "org.bluez.ThermometerWatcher1"
 // - servicePath: unique name
 // - objectPath: freely definable
 func NewThermometerWatcher1(servicePath string, objectPath dbus.ObjectPath) (*ThermometerWatcher1, error) {
+	return NewThermometerWatcher1WithConn(nil, servicePath, objectPath)
+}
+
+// NewThermometerWatcher1WithConn create a new instance of ThermometerWatcher1 using the given connection.
+// The default system bus connection is used if conn is nil
+func NewThermometerWatcher1WithConn(conn *bluez.Conn, servicePath string, objectPath dbus.ObjectPath) (*ThermometerWatcher1, error) {
 	a := new(ThermometerWatcher1)
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -26,6 +33,7 @@ func NewThermometerWatcher1(servicePath string, objectPath dbus.ObjectPath) (*Th
 			Iface: ThermometerWatcher1Interface,
 			Path:  dbus.ObjectPath(objectPath),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	a.Properties = new(ThermometerWatcher1Properties)
@@ -91,7 +99,7 @@ func (a *ThermometerWatcher1) GetObjectManagerSignal() (chan *dbus.Signal, func(
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -241,5 +249,10 @@ MeasurementReceived 			This callback gets called when a measurement has been
 
 */
 func (a *ThermometerWatcher1) MeasurementReceived(measurement map[string]interface{}) error {
-	return a.client.Call("MeasurementReceived", 0, measurement).Store()
+	return a.MeasurementReceivedContext(context.Background(), measurement)
+}
+
+// MeasurementReceivedContext is like MeasurementReceived, the call is cancelled when ctx is done
+func (a *ThermometerWatcher1) MeasurementReceivedContext(ctx context.Context, measurement map[string]interface{}) error {
+	return a.client.CallWithContext(ctx, "MeasurementReceived", 0, measurement).Store()
 }
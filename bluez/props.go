@@ -1,6 +1,8 @@
 package bluez
 
 import (
+	"context"
+	"errors"
 	"reflect"
 
 	"github.com/godbus/dbus/v5"
@@ -16,73 +18,77 @@ type WatchableClient interface {
 	SetWatchPropertiesChannel(chan *dbus.Signal)
 }
 
-// WatchProperties updates on property changes
+// WatchProperties updates on property changes. A client has one watch at a time, watching
+// again ends the previous watch. The channel is closed by UnwatchProperties
 func WatchProperties(wprop WatchableClient) (chan *PropertyChanged, error) {
 
-	channel, err := wprop.Client().Register(wprop.Path(), PropertiesInterface)
+	conn, err := wprop.Client().Conn()
 	if err != nil {
 		return nil, err
 	}
 
-	wprop.SetWatchPropertiesChannel(channel)
-	ch := make(chan *PropertyChanged)
+	sub, err := conn.Subscribe(context.Background(), SignalMatch{
+		Path:      wprop.Path(),
+		Interface: PropertiesInterface,
+		Member:    "PropertiesChanged",
+	}, SubscribeOptions{})
+	if err != nil {
+		return nil, err
+	}
 
-	go (func() {
-		for {
+	// the watch is stopped closing the channel stored on the client
+	if prev := wprop.GetWatchPropertiesChannel(); prev != nil {
+		close(prev)
+	}
+	stop := make(chan *dbus.Signal)
+	wprop.SetWatchPropertiesChannel(stop)
 
-			if channel == nil {
-				break
-			}
+	ch := make(chan *PropertyChanged)
 
-			sig := <-channel
+	go (func() {
+		// ch is closed only here, after the last send
+		defer close(ch)
+		defer sub.Cancel()
 
-			if sig == nil {
+		for {
+			var sig *dbus.Signal
+			select {
+			case s, ok := <-sub.C():
+				if !ok {
+					return
+				}
+				sig = s
+			case <-stop:
 				return
 			}
 
-			if sig.Name != PropertiesChanged {
+			if len(sig.Body) < 2 {
 				continue
 			}
-			if sig.Path != wprop.Path() {
+			iface, ok := sig.Body[0].(string)
+			if !ok {
+				continue
+			}
+			changes, ok := sig.Body[1].(map[string]dbus.Variant)
+			if !ok {
 				continue
 			}
-
-			iface := sig.Body[0].(string)
-			changes := sig.Body[1].(map[string]dbus.Variant)
 
 			for field, val := range changes {
 
 				// updates [*]Properties struct when a property change
-				s := reflect.ValueOf(wprop.ToProps()).Elem()
-				// exported field
-				f := s.FieldByName(field)
-				if f.IsValid() {
-					// A Value can be changed only if it is
-					// addressable and was not obtained by
-					// the use of unexported struct fields.
-					if f.CanSet() {
-						x := reflect.ValueOf(val.Value())
-						wprop.ToProps().Lock()
-						// map[*]variant -> map[*]interface{}
-						ok, err := util.AssignMapVariantToInterface(f, x)
-						if err != nil {
-							log.Errorf("Failed to set %s: %s", f.String(), err)
-							continue
-						}
-						// direct assignment
-						if !ok {
-							f.Set(x)
-						}
-						wprop.ToProps().Unlock()
-					}
-				}
+				updateProperty(wprop.ToProps(), field, val)
 
 				propChanged := &PropertyChanged{
 					Interface: iface,
 					Name:      field,
 					Value:     val.Value(),
 				}
-				ch <- propChanged
+				select {
+				case ch <- propChanged:
+				case <-stop:
+					return
+				}
 			}
 
 		}
@@ -91,15 +97,49 @@ func WatchProperties(wprop WatchableClient) (chan *PropertyChanged, error) {
 	return ch, nil
 }
 
+// updateProperty set a field of a Properties struct from a property change
+func updateProperty(props Properties, field string, val dbus.Variant) {
+	s := reflect.ValueOf(props).Elem()
+	// exported field
+	f := s.FieldByName(field)
+	if !f.IsValid() {
+		return
+	}
+	// A Value can be changed only if it is
+	// addressable and was not obtained by
+	// the use of unexported struct fields.
+	if !f.CanSet() {
+		return
+	}
+	x := reflect.ValueOf(val.Value())
+	props.Lock()
+	defer props.Unlock()
+	// map[*]variant -> map[*]interface{}
+	ok, err := util.AssignMapVariantToInterface(f, x)
+	if err != nil {
+		log.Errorf("Failed to set %s: %s", f.String(), err)
+		return
+	}
+	// direct assignment
+	if !ok {
+		f.Set(x)
+	}
+}
+
+// UnwatchProperties stop the updates of WatchProperties and close ch, waiting
+// for the watching goroutine to exit. Pending changes not yet received are discarded
 func UnwatchProperties(wprop WatchableClient, ch chan *PropertyChanged) error {
-	if wprop.GetWatchPropertiesChannel() != nil {
-		wprop.GetWatchPropertiesChannel() <- nil
-		err := wprop.Client().Unregister(wprop.Path(), PropertiesInterface, wprop.GetWatchPropertiesChannel())
-		if err != nil {
-			return err
+	stop := wprop.GetWatchPropertiesChannel()
+	if stop == nil {
+		return errors.New("UnwatchProperties: properties not watched")
+	}
+	wprop.SetWatchPropertiesChannel(nil)
+	close(stop)
+
+	// ch is closed once the goroutine exits
+	if ch != nil {
+		for range ch {
 		}
 	}
-	ch <- nil
-	close(ch)
 	return nil
 }
@@ -0,0 +1,210 @@
+package bluez
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/godbus/dbus/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultSubscriptionBuffer is the default Subscription buffer size
+const DefaultSubscriptionBuffer = 16
+
+// OverflowPolicy select what a Subscription does when its buffer is full
+type OverflowPolicy uint8
+
+const (
+	// OverflowBlock wait for the consumer, delaying the following signals
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDrop discard the signal, counting it in Dropped
+	OverflowDrop
+)
+
+// SignalMatch select the signals of a Subscription. Empty fields match any value
+type SignalMatch struct {
+	// Path match the object path exactly
+	Path dbus.ObjectPath
+	// PathNamespace match the object path and its children
+	PathNamespace dbus.ObjectPath
+	Interface     string
+	Member        string
+	// Arg0 match the first string argument, eg. the interface of PropertiesChanged
+	Arg0 string
+}
+
+// rule return the bus match rule
+func (m SignalMatch) rule() string {
+	rule := []string{"type='signal'"}
+	if m.Path != "" {
+		rule = append(rule, fmt.Sprintf("path='%s'", m.Path))
+	}
+	if m.PathNamespace != "" {
+		rule = append(rule, fmt.Sprintf("path_namespace='%s'", m.PathNamespace))
+	}
+	if m.Interface != "" {
+		rule = append(rule, fmt.Sprintf("interface='%s'", m.Interface))
+	}
+	if m.Member != "" {
+		rule = append(rule, fmt.Sprintf("member='%s'", m.Member))
+	}
+	if m.Arg0 != "" {
+		rule = append(rule, fmt.Sprintf("arg0='%s'", m.Arg0))
+	}
+	return strings.Join(rule, ",")
+}
+
+// Matches report if a signal matches. The bus delivers the signals matching any
+// rule of the connection to all its channels, so they are filtered again locally
+func (m SignalMatch) Matches(sig *dbus.Signal) bool {
+	if sig == nil {
+		return false
+	}
+	if m.Path != "" && sig.Path != m.Path {
+		return false
+	}
+	if m.PathNamespace != "" && sig.Path != m.PathNamespace &&
+		!strings.HasPrefix(string(sig.Path), strings.TrimSuffix(string(m.PathNamespace), "/")+"/") {
+		return false
+	}
+	dot := strings.LastIndex(sig.Name, ".")
+	if dot < 0 {
+		return false
+	}
+	if m.Interface != "" && sig.Name[:dot] != m.Interface {
+		return false
+	}
+	if m.Member != "" && sig.Name[dot+1:] != m.Member {
+		return false
+	}
+	if m.Arg0 != "" {
+		if len(sig.Body) == 0 {
+			return false
+		}
+		if arg0, ok := sig.Body[0].(string); !ok || arg0 != m.Arg0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SubscribeOptions configure a Subscription
+type SubscribeOptions struct {
+	// BufferSize default to DefaultSubscriptionBuffer
+	BufferSize int
+	// Overflow policy when the buffer is full
+	Overflow OverflowPolicy
+}
+
+// Subscription delivers the signals matching a SignalMatch until it is cancelled.
+// The channel returned by C is closed once the subscription ends, after which no
+// goroutine of the subscription is left running
+type Subscription struct {
+	// dropped is first to be 64-bit aligned for atomic operations
+	dropped uint64
+
+	conn    *Conn
+	match   SignalMatch
+	options SubscribeOptions
+
+	raw    chan *dbus.Signal
+	out    chan *dbus.Signal
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Subscribe start delivering the signals matching match, until ctx is done or Cancel is called
+func (c *Conn) Subscribe(ctx context.Context, match SignalMatch, options SubscribeOptions) (*Subscription, error) {
+
+	if options.BufferSize <= 0 {
+		options.BufferSize = DefaultSubscriptionBuffer
+	}
+
+	err := c.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, match.rule()).Err
+	if err != nil {
+		return nil, fmt.Errorf("AddMatch: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Subscription{
+		conn:    c,
+		match:   match,
+		options: options,
+		raw:     make(chan *dbus.Signal, options.BufferSize),
+		out:     make(chan *dbus.Signal, options.BufferSize),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	c.conn.Signal(s.raw)
+
+	go s.run(ctx)
+
+	return s, nil
+}
+
+// C return the signals channel, closed when the subscription ends
+func (s *Subscription) C() <-chan *dbus.Signal {
+	return s.out
+}
+
+// Done is closed when the subscription ended and released its resources
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Dropped return the number of signals discarded with OverflowDrop
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Cancel end the subscription and wait for its release. It is safe to call Cancel
+// multiple times and concurrently with a consumer of C
+func (s *Subscription) Cancel() {
+	s.cancel()
+	<-s.done
+}
+
+// run forward the matching signals until ctx is done
+func (s *Subscription) run(ctx context.Context) {
+	defer func() {
+		// RemoveSignal waits for any pending delivery to raw
+		s.conn.conn.RemoveSignal(s.raw)
+		s.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, s.match.rule())
+		close(s.out)
+		close(s.done)
+	}()
+
+	for {
+		select {
+		case sig, ok := <-s.raw:
+			if !ok {
+				// the connection has been closed
+				return
+			}
+			if !s.match.Matches(sig) {
+				continue
+			}
+			select {
+			case s.out <- sig:
+				continue
+			case <-ctx.Done():
+				return
+			default:
+			}
+			if s.options.Overflow == OverflowDrop {
+				atomic.AddUint64(&s.dropped, 1)
+				log.Tracef("Subscription buffer full, dropping %s from %s", sig.Name, sig.Path)
+				continue
+			}
+			select {
+			case s.out <- sig:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
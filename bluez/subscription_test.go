@@ -0,0 +1,34 @@
+package bluez
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignalMatch(t *testing.T) {
+	sig := &dbus.Signal{
+		Path: "/org/bluez/hci0/dev_11_22_33_44_55_66",
+		Name: PropertiesChanged,
+		Body: []interface{}{"org.bluez.Device1", map[string]dbus.Variant{}, []string{}},
+	}
+
+	assert.True(t, SignalMatch{}.Matches(sig))
+	assert.False(t, SignalMatch{}.Matches(nil))
+	assert.True(t, SignalMatch{Path: sig.Path}.Matches(sig))
+	assert.False(t, SignalMatch{Path: "/org/bluez/hci0"}.Matches(sig))
+	assert.True(t, SignalMatch{PathNamespace: "/org/bluez/hci0"}.Matches(sig))
+	assert.True(t, SignalMatch{PathNamespace: sig.Path}.Matches(sig))
+	assert.False(t, SignalMatch{PathNamespace: "/org/bluez/hci"}.Matches(sig))
+	assert.True(t, SignalMatch{Interface: PropertiesInterface, Member: "PropertiesChanged"}.Matches(sig))
+	assert.False(t, SignalMatch{Interface: ObjectManagerInterface}.Matches(sig))
+	assert.False(t, SignalMatch{Member: "InterfacesAdded"}.Matches(sig))
+	assert.True(t, SignalMatch{Arg0: "org.bluez.Device1"}.Matches(sig))
+	assert.False(t, SignalMatch{Arg0: "org.bluez.Adapter1"}.Matches(sig))
+
+	assert.Equal(t,
+		"type='signal',path_namespace='/org/bluez/hci0',interface='org.freedesktop.DBus.Properties',arg0='org.bluez.Device1'",
+		SignalMatch{PathNamespace: "/org/bluez/hci0", Interface: PropertiesInterface, Arg0: "org.bluez.Device1"}.rule(),
+	)
+}
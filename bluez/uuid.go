@@ -0,0 +1,73 @@
+package bluez
+
+import (
+	"github.com/muka/go-bluetooth/assigned/uuid"
+)
+
+// UUID is a Bluetooth UUID in any of the 16, 32 or 128 bit forms, eg. "180F",
+// "0000180f" or "0000180f-0000-1000-8000-00805f9b34fb". Use String for the
+// canonical lower case 128 bit form and Equal to compare UUIDs in different forms
+type UUID string
+
+// ParseUUID parse a 16, 32 or 128 bit UUID, returning its canonical form
+func ParseUUID(s string) (UUID, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return UUID(u), nil
+}
+
+// MustParseUUID is like ParseUUID but panics if the UUID cannot be parsed
+func MustParseUUID(s string) UUID {
+	u, err := ParseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// UUIDFromUint16 return the UUID of a 16 bit alias of the Bluetooth Base UUID
+func UUIDFromUint16(v uint16) UUID {
+	return UUID(uuid.FromUint16(v))
+}
+
+// String return the canonical lower case 128 bit form, or the UUID as is if invalid
+func (u UUID) String() string {
+	s, err := uuid.Parse(string(u))
+	if err != nil {
+		return string(u)
+	}
+	return s
+}
+
+// Valid check if the UUID can be parsed
+func (u UUID) Valid() bool {
+	_, err := uuid.Parse(string(u))
+	return err == nil
+}
+
+// Equal compare two UUIDs in any form
+func (u UUID) Equal(other UUID) bool {
+	return u.String() == other.String()
+}
+
+// Uint16 return the 16 bit alias of an UUID based on the Bluetooth Base UUID
+func (u UUID) Uint16() (uint16, bool) {
+	return uuid.Short(string(u))
+}
+
+// Short return the 16 bit form, eg. "180f", of an UUID based on the Bluetooth
+// Base UUID, the canonical form otherwise
+func (u UUID) Short() string {
+	s := u.String()
+	if _, ok := uuid.Short(s); ok {
+		return s[4:8]
+	}
+	return s
+}
+
+// Name return the name assigned by the Bluetooth SIG, or an empty string if unknown
+func (u UUID) Name() string {
+	return uuid.Name(string(u))
+}
@@ -0,0 +1,39 @@
+package bluez
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUUID(t *testing.T) {
+	u, err := ParseUUID("180F")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, UUID("0000180f-0000-1000-8000-00805f9b34fb"), u)
+
+	_, err = ParseUUID("18F")
+	assert.Error(t, err)
+	assert.Panics(t, func() {
+		MustParseUUID("xyz")
+	})
+
+	assert.True(t, UUID("AA01").Equal("0000aa01-0000-1000-8000-00805F9B34FB"))
+	assert.True(t, UUID("0000AA01").Equal("aa01"))
+	assert.False(t, UUID("AA01").Equal("AA02"))
+	// invalid UUIDs compare as is
+	assert.True(t, UUID("xyz").Equal("xyz"))
+	assert.False(t, UUID("xyz").Valid())
+
+	assert.Equal(t, "0000aa01-0000-1000-8000-00805f9b34fb", UUID("AA01").String())
+	assert.Equal(t, "aa01", UUID("0000AA01-0000-1000-8000-00805F9B34FB").Short())
+	assert.Equal(t, "12345678-1234-5678-1234-56789abcdef0", UUID("12345678-1234-5678-1234-56789ABCDEF0").Short())
+
+	v, ok := UUID("2a19").Uint16()
+	assert.True(t, ok)
+	assert.Equal(t, uint16(0x2a19), v)
+	assert.Equal(t, UUID("00002a19-0000-1000-8000-00805f9b34fb"), UUIDFromUint16(0x2a19))
+
+	assert.Equal(t, "Battery Level", UUID("2A19").Name())
+}
@@ -0,0 +1,262 @@
+package bluez
+
+import (
+	"errors"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+)
+
+// ErrVersionUnknown returned when the version of the daemon cannot be detected
+var ErrVersionUnknown = errors.New("bluez version unknown")
+
+// VersionCommands are run, in order, by Version to read the version of the
+// local daemon, eg. bluetoothctl --version
+var VersionCommands = [][]string{
+	{"bluetoothctl", "--version"},
+	{"/usr/libexec/bluetooth/bluetoothd", "--version"},
+	{"/usr/lib/bluetooth/bluetoothd", "--version"},
+}
+
+var versionRegexp = regexp.MustCompile(`(\d+\.\d+(\.\d+)?)`)
+
+// Version return the version of the bluez daemon on the system bus, as reported
+// by VersionCommands. ErrVersionUnknown is returned if none is available, see
+// MinVersion for a lower bound
+func Version() (string, error) {
+	if version, ok := commandVersion(); ok {
+		return version, nil
+	}
+	return "", ErrVersionUnknown
+}
+
+// MinVersion return the version of the bluez daemon on the system bus the daemon is
+// at least. The version reported by VersionCommands is used if available, otherwise
+// the lower bound inferred by introspecting the daemon objects, see Conn.MinVersion
+func MinVersion() (string, error) {
+	if version, ok := commandVersion(); ok {
+		return version, nil
+	}
+	conn, err := DefaultConn(SystemBus)
+	if err != nil {
+		return "", err
+	}
+	return conn.MinVersion()
+}
+
+// Supports report if the bluez daemon on the system bus implements a member
+// (method, signal or property) of an interface, see Conn.Supports
+func Supports(iface, member string) (bool, error) {
+	conn, err := DefaultConn(SystemBus)
+	if err != nil {
+		return false, err
+	}
+	return conn.Supports(iface, member)
+}
+
+// MinVersion return the newest bluez version, according to Since, introducing
+// the interfaces and members exposed by the daemon objects. This is a lower
+// bound of the daemon version: the members of a newer version may not be
+// exposed, eg. with no device found, and daemons newer than the API
+// definitions report the latest version known
+func (c *Conn) MinVersion() (string, error) {
+
+	nodes, err := c.introspectInterfaces()
+	if err != nil {
+		return "", err
+	}
+
+	version := ""
+	newer := func(key string) {
+		if since, ok := Since[key]; ok && CompareVersions(since, version) > 0 {
+			version = since
+		}
+	}
+
+	for iface, node := range nodes {
+		newer(iface)
+		for _, m := range node.Methods {
+			newer(iface + "." + m.Name)
+		}
+		for _, s := range node.Signals {
+			newer(iface + "." + s.Name)
+		}
+		for _, p := range node.Properties {
+			newer(iface + "." + p.Name)
+		}
+	}
+
+	if version == "" {
+		return "", ErrVersionUnknown
+	}
+	return version, nil
+}
+
+// Supports report if the daemon implements a member (method, signal or
+// property) of an interface, introspecting the objects it exposes. Pass an
+// empty member to check the interface only.
+// Only the live objects are inspected, an interface with no object exposing
+// it, eg. Device1 before any device is found, is reported as not supported
+func (c *Conn) Supports(iface, member string) (bool, error) {
+
+	nodes, err := c.introspectInterfaces()
+	if err != nil {
+		return false, err
+	}
+
+	node, ok := nodes[iface]
+	if !ok {
+		return false, nil
+	}
+	if member == "" {
+		return true, nil
+	}
+
+	for _, m := range node.Methods {
+		if m.Name == member {
+			return true, nil
+		}
+	}
+	for _, s := range node.Signals {
+		if s.Name == member {
+			return true, nil
+		}
+	}
+	for _, p := range node.Properties {
+		if p.Name == member {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// introspectInterfaces return the introspection data of the interfaces
+// exposed by the daemon objects. Each interface is introspected once, as
+// the objects implementing it share the same definition
+func (c *Conn) introspectInterfaces() (map[string]introspect.Interface, error) {
+
+	om, err := c.ObjectManager()
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := om.GetManagedObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := map[string]introspect.Interface{}
+	for path, ifaces := range objects {
+
+		missing := false
+		for iface := range ifaces {
+			if _, ok := nodes[iface]; !ok {
+				missing = true
+				break
+			}
+		}
+		if !missing {
+			continue
+		}
+
+		node, err := introspect.Call(c.conn.Object(OrgBluezInterface, path))
+		if err != nil {
+			return nil, ConvertError(err)
+		}
+		for _, i := range node.Interfaces {
+			if _, ok := ifaces[i.Name]; ok {
+				nodes[i.Name] = i
+			}
+		}
+	}
+
+	return nodes, nil
+}
+
+// commandVersion read the version of the local daemon running VersionCommands
+func commandVersion() (string, bool) {
+	for _, command := range VersionCommands {
+		if len(command) == 0 {
+			continue
+		}
+		out, err := exec.Command(command[0], command[1:]...).Output()
+		if err != nil {
+			continue
+		}
+		if version := versionRegexp.FindString(string(out)); version != "" {
+			return version, true
+		}
+	}
+	return "", false
+}
+
+// CompareVersions compare two bluez versions, eg. 5.9 and 5.50, returning -1, 0 or 1
+// if a is older, the same or newer than b. A suffix as from git describe,
+// eg. 5.55-10-gabcdef, is ignored
+func CompareVersions(a, b string) int {
+	pa := versionParts(a)
+	pb := versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		va, vb := 0, 0
+		if i < len(pa) {
+			va = pa[i]
+		}
+		if i < len(pb) {
+			vb = pb[i]
+		}
+		if va < vb {
+			return -1
+		}
+		if va > vb {
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []int {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+ "); i > -1 {
+		version = version[:i]
+	}
+	parts := []int{}
+	for _, p := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// notSupportedErrors are the DBus errors replied for a missing method,
+// property or interface, by bluetoothd and by godbus exported objects
+var notSupportedErrors = []string{
+	"org.freedesktop.DBus.Error.UnknownMethod",
+	"org.freedesktop.DBus.Error.UnknownInterface",
+	"org.freedesktop.DBus.Error.UnknownProperty",
+	"org.freedesktop.DBus.Properties.Error.PropertyNotFound",
+	"org.freedesktop.DBus.Properties.Error.InterfaceNotFound",
+}
+
+// isNotSupported report if err is a DBus error replied for a missing member
+func isNotSupported(err dbus.Error) bool {
+	for _, name := range notSupportedErrors {
+		if err.Name == name {
+			return true
+		}
+	}
+	// bluetoothd replies InvalidArgs to Get and Set of unknown properties
+	if err.Name == "org.freedesktop.DBus.Error.InvalidArgs" {
+		message := err.Error()
+		return strings.HasPrefix(message, "No such property") ||
+			strings.HasPrefix(message, "No such interface")
+	}
+	return false
+}
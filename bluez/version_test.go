@@ -0,0 +1,37 @@
+package bluez
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, -1, CompareVersions("5.9", "5.50"))
+	assert.Equal(t, 1, CompareVersions("5.66", "5.55"))
+	assert.Equal(t, 0, CompareVersions("5.55", "5.55-10-gabcdef"))
+	assert.Equal(t, 1, CompareVersions("5.55.1", "5.55"))
+}
+
+func TestVersionCommands(t *testing.T) {
+	commands := VersionCommands
+	defer func() {
+		VersionCommands = commands
+	}()
+
+	VersionCommands = [][]string{{"echo", "5.66"}}
+	version, err := Version()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "5.66", version)
+	version, err = MinVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "5.66", version)
+
+	VersionCommands = nil
+	_, err = Version()
+	assert.Equal(t, ErrVersionUnknown, err)
+}
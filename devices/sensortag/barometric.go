@@ -38,7 +38,7 @@ func newBarometricSensor(tag *SensorTag) (*BarometricSensor, error) {
 			return nil, err
 		}
 		if data == nil {
-			return nil, errors.New("Cannot find BarometerData characteristic " + BarometerDataUUID)
+			return nil, errors.New("Cannot find BarometerData characteristic " + string(BarometerDataUUID))
 		}
 
 		period, err := dev.GetCharByUUID(BarometerPeriodUUID)
@@ -46,7 +46,7 @@ func newBarometricSensor(tag *SensorTag) (*BarometricSensor, error) {
 			return nil, err
 		}
 		if period == nil {
-			return nil, errors.New("Cannot find BarometerPeriod characteristic " + BarometerPeriodUUID)
+			return nil, errors.New("Cannot find BarometerPeriod characteristic " + string(BarometerPeriodUUID))
 		}
 
 		return &BarometricSensor{tag, cfg, data, period}, err
@@ -37,7 +37,7 @@ func newHumiditySensor(tag *SensorTag) (*HumiditySensor, error) {
 			return nil, err
 		}
 		if data == nil {
-			return nil, errors.New("Cannot find HumidityData characteristic " + HumidityDataUUID)
+			return nil, errors.New("Cannot find HumidityData characteristic " + string(HumidityDataUUID))
 		}
 
 		period, err := dev.GetCharByUUID(HumidityPeriodUUID)
@@ -45,7 +45,7 @@ func newHumiditySensor(tag *SensorTag) (*HumiditySensor, error) {
 			return nil, err
 		}
 		if period == nil {
-			return nil, errors.New("Cannot find HumidityPeriod characteristic " + HumidityPeriodUUID)
+			return nil, errors.New("Cannot find HumidityPeriod characteristic " + string(HumidityPeriodUUID))
 		}
 
 		return &HumiditySensor{tag, cfg, data, period}, err
@@ -41,7 +41,7 @@ func newLuxometerSensor(tag *SensorTag) (*LuxometerSensor, error) {
 			return nil, err
 		}
 		if data == nil {
-			return nil, errors.New("Cannot find LuxometerDataUUID  characteristic " + LuxometerDataUUID)
+			return nil, errors.New("Cannot find LuxometerDataUUID  characteristic " + string(LuxometerDataUUID))
 		}
 
 		period, err := dev.GetCharByUUID(LuxometerPeriodUUID)
@@ -49,7 +49,7 @@ func newLuxometerSensor(tag *SensorTag) (*LuxometerSensor, error) {
 			return nil, err
 		}
 		if period == nil {
-			return nil, errors.New("Cannot find LuxometerPeriodUUID  characteristic " + LuxometerPeriodUUID)
+			return nil, errors.New("Cannot find LuxometerPeriodUUID  characteristic " + string(LuxometerPeriodUUID))
 		}
 
 		return &LuxometerSensor{tag, cfg, data, period}, err
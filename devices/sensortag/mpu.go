@@ -40,7 +40,7 @@ func newMpuSensor(tag *SensorTag) (*MpuSensor, error) {
 			return nil, err
 		}
 		if data == nil {
-			return nil, errors.New("Cannot find MpuData characteristic " + MpuDataUUID)
+			return nil, errors.New("Cannot find MpuData characteristic " + string(MpuDataUUID))
 		}
 
 		period, err := dev.GetCharByUUID(MpuPeriodUUID)
@@ -48,7 +48,7 @@ func newMpuSensor(tag *SensorTag) (*MpuSensor, error) {
 			return nil, err
 		}
 		if period == nil {
-			return nil, errors.New("Cannot find MpuPeriod characteristic " + MpuPeriodUUID)
+			return nil, errors.New("Cannot find MpuPeriod characteristic " + string(MpuPeriodUUID))
 		}
 
 		return &MpuSensor{tag, cfg, data, period}, err
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/godbus/dbus/v5"
+	"github.com/muka/go-bluetooth/bluez"
 	"github.com/muka/go-bluetooth/bluez/profile/device"
 	"github.com/muka/go-bluetooth/bluez/profile/gatt"
 	log "github.com/sirupsen/logrus"
@@ -124,18 +125,18 @@ const (
 	TemperaturePeriodLow    = 0x128 // 2000 ms,
 )
 
-func getUUID(name string) (string, error) {
+func getUUID(name string) (bluez.UUID, error) {
 	if sensorTagUUIDs[name] == "" {
 		return "", fmt.Errorf("Not found %s", name)
 	}
-	return fmt.Sprintf("F000%s-0451-4000-B000-000000000000", sensorTagUUIDs[name]), nil
+	return bluez.UUID(fmt.Sprintf("F000%s-0451-4000-B000-000000000000", sensorTagUUIDs[name])), nil
 }
 
-func getDeviceInfoUUID(name string) string {
+func getDeviceInfoUUID(name string) bluez.UUID {
 	if sensorTagUUIDs[name] == "" {
 		panic("Not found " + name)
 	}
-	return "0000" + sensorTagUUIDs[name] + "-0000-1000-8000-00805F9B34FB"
+	return bluez.UUID(sensorTagUUIDs[name])
 }
 
 //retryCall n. times, sleep millis, callback
@@ -270,7 +271,7 @@ func newDeviceInfo(tag *SensorTag) (SensorTagDeviceInfo, error) {
 			return SensorTagDeviceInfo{}, err
 		}
 		if firmwareInfo == nil {
-			return SensorTagDeviceInfo{}, errors.New("Cannot find DeviceFirmwareUUID characteristic " + DeviceFirmwareUUID)
+			return SensorTagDeviceInfo{}, errors.New("Cannot find DeviceFirmwareUUID characteristic " + string(DeviceFirmwareUUID))
 		}
 
 		hardwareInfo, err := dev.GetCharByUUID(DeviceHardwareUUID)
@@ -278,7 +279,7 @@ func newDeviceInfo(tag *SensorTag) (SensorTagDeviceInfo, error) {
 			return SensorTagDeviceInfo{}, err
 		}
 		if hardwareInfo == nil {
-			return SensorTagDeviceInfo{}, errors.New("Cannot find DeviceHardwareUUID characteristic " + DeviceHardwareUUID)
+			return SensorTagDeviceInfo{}, errors.New("Cannot find DeviceHardwareUUID characteristic " + string(DeviceHardwareUUID))
 		}
 
 		manufacturerInfo, err := dev.GetCharByUUID(DeviceManufacturerUUID)
@@ -286,7 +287,7 @@ func newDeviceInfo(tag *SensorTag) (SensorTagDeviceInfo, error) {
 			return SensorTagDeviceInfo{}, err
 		}
 		if manufacturerInfo == nil {
-			return SensorTagDeviceInfo{}, errors.New("Cannot find DeviceManufacturerUUID characteristic " + DeviceManufacturerUUID)
+			return SensorTagDeviceInfo{}, errors.New("Cannot find DeviceManufacturerUUID characteristic " + string(DeviceManufacturerUUID))
 		}
 
 		modelInfo, err := dev.GetCharByUUID(DeviceModelUUID)
@@ -294,7 +295,7 @@ func newDeviceInfo(tag *SensorTag) (SensorTagDeviceInfo, error) {
 			return SensorTagDeviceInfo{}, err
 		}
 		if modelInfo == nil {
-			return SensorTagDeviceInfo{}, errors.New("Cannot find DeviceModelUUID characteristic " + DeviceModelUUID)
+			return SensorTagDeviceInfo{}, errors.New("Cannot find DeviceModelUUID characteristic " + string(DeviceModelUUID))
 		}
 
 		return SensorTagDeviceInfo{tag, modelInfo, manufacturerInfo, hardwareInfo, firmwareInfo}, err
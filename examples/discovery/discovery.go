@@ -8,6 +8,9 @@ import (
 
 	"github.com/muka/go-bluetooth/api"
 	"github.com/muka/go-bluetooth/api/beacon"
+	"github.com/muka/go-bluetooth/assigned/appearance"
+	"github.com/muka/go-bluetooth/assigned/company"
+	"github.com/muka/go-bluetooth/assigned/uuid"
 	"github.com/muka/go-bluetooth/bluez/profile/adapter"
 	"github.com/muka/go-bluetooth/bluez/profile/device"
 	log "github.com/sirupsen/logrus"
@@ -57,6 +60,7 @@ func Run(adapterID string, onlyBeacon bool) error {
 			}
 
 			log.Infof("name=%s addr=%s rssi=%d", dev.Properties.Name, dev.Properties.Address, dev.Properties.RSSI)
+			logAssigned(dev)
 
 			go func(ev *adapter.DeviceDiscovered) {
 				err = handleBeacon(dev)
@@ -77,6 +81,21 @@ func Run(adapterID string, onlyBeacon bool) error {
 	return nil
 }
 
+// logAssigned log the SIG assigned names of the device appearance, manufacturer and services
+func logAssigned(dev *device.Device1) {
+	if dev.Properties.Appearance != 0 {
+		log.Infof("  appearance=%s", appearance.Name(dev.Properties.Appearance))
+	}
+	for id := range dev.Properties.ManufacturerData {
+		log.Infof("  manufacturer=%s (0x%04X)", company.Name(id), id)
+	}
+	for _, u := range dev.Properties.UUIDs {
+		if name := uuid.Name(u); name != "" {
+			log.Infof("  service=%s (%s)", name, u)
+		}
+	}
+}
+
 func handleBeacon(dev *device.Device1) error {
 
 	b, err := beacon.NewBeacon(dev)
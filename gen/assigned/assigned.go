@@ -0,0 +1,235 @@
+// Package assigned generates the Go tables of the Bluetooth SIG assigned numbers,
+// from the YAML files of https://bitbucket.org/bluetooth-SIG/public/src/main/assigned_numbers.
+//
+// The data directory holds a copy of the Files used, Update refreshes it from a
+// checkout of the assigned_numbers directory (see make gen/assigned).
+package assigned
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Files lists the YAML files used, relative to the assigned_numbers directory
+var Files = []string{
+	"uuids/service_uuids.yaml",
+	"uuids/characteristic_uuids.yaml",
+	"uuids/descriptors.yaml",
+	"company_identifiers/company_identifiers.yaml",
+	"core/appearance_values.yaml",
+}
+
+// UUID is an assigned 16 bit UUID
+type UUID struct {
+	UUID uint16 `yaml:"uuid"`
+	Name string `yaml:"name"`
+	ID   string `yaml:"id"`
+}
+
+// Company is an assigned company identifier
+type Company struct {
+	Value uint16 `yaml:"value"`
+	Name  string `yaml:"name"`
+}
+
+// Subcategory is an appearance subcategory
+type Subcategory struct {
+	Value uint16 `yaml:"value"`
+	Name  string `yaml:"name"`
+}
+
+// Appearance is an appearance category
+type Appearance struct {
+	Category    uint16        `yaml:"category"`
+	Name        string        `yaml:"name"`
+	Subcategory []Subcategory `yaml:"subcategory"`
+}
+
+// Numbers holds the assigned numbers
+type Numbers struct {
+	Services        []UUID
+	Characteristics []UUID
+	Descriptors     []UUID
+	Companies       []Company
+	Appearance      []Appearance
+}
+
+// Load the assigned numbers from the YAML files in dir
+func Load(dir string) (*Numbers, error) {
+
+	n := new(Numbers)
+
+	uuids := []struct {
+		file string
+		dst  *[]UUID
+	}{
+		{"uuids/service_uuids.yaml", &n.Services},
+		{"uuids/characteristic_uuids.yaml", &n.Characteristics},
+		{"uuids/descriptors.yaml", &n.Descriptors},
+	}
+	for _, u := range uuids {
+		doc := struct {
+			UUIDs []UUID `yaml:"uuids"`
+		}{}
+		err := loadFile(filepath.Join(dir, u.file), &doc)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(doc.UUIDs, func(i, j int) bool {
+			return doc.UUIDs[i].UUID < doc.UUIDs[j].UUID
+		})
+		*u.dst = doc.UUIDs
+	}
+
+	companies := struct {
+		Companies []Company `yaml:"company_identifiers"`
+	}{}
+	err := loadFile(filepath.Join(dir, "company_identifiers/company_identifiers.yaml"), &companies)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(companies.Companies, func(i, j int) bool {
+		return companies.Companies[i].Value < companies.Companies[j].Value
+	})
+	n.Companies = companies.Companies
+
+	appearance := struct {
+		Values []Appearance `yaml:"appearance_values"`
+	}{}
+	err = loadFile(filepath.Join(dir, "core/appearance_values.yaml"), &appearance)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(appearance.Values, func(i, j int) bool {
+		return appearance.Values[i].Category < appearance.Values[j].Category
+	})
+	n.Appearance = appearance.Values
+
+	return n, nil
+}
+
+// Update copy the Files from the assigned_numbers directory srcDir to dataDir
+func Update(srcDir string, dataDir string) error {
+	for _, file := range Files {
+		data, err := ioutil.ReadFile(filepath.Join(srcDir, file))
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(dataDir, file)
+		err = os.MkdirAll(filepath.Dir(dst), 0755)
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(dst, data, 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadFile(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	err = yaml.Unmarshal(data, v)
+	if err != nil {
+		return fmt.Errorf("%s: %s", path, err)
+	}
+	return nil
+}
+
+// Generate write the uuid, company and appearance tables in outDir
+func Generate(n *Numbers, outDir string) error {
+	files := []struct {
+		path string
+		tpl  *template.Template
+	}{
+		{"uuid/gen_uuids.go", uuidsTpl},
+		{"company/gen_companies.go", companiesTpl},
+		{"appearance/gen_appearance.go", appearanceTpl},
+	}
+	for _, f := range files {
+		err := generateFile(filepath.Join(outDir, f.path), f.tpl, n)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func generateFile(path string, tpl *template.Template, n *Numbers) error {
+	buf := new(bytes.Buffer)
+	err := tpl.Execute(buf, n)
+	if err != nil {
+		return fmt.Errorf("%s: %s", path, err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: format: %s", path, err)
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, src, 0644)
+}
+
+const header = "// Code generated by go-bluetooth generator DO NOT EDIT.\n\n"
+
+var funcs = template.FuncMap{
+	"hex4": func(v uint16) string {
+		return fmt.Sprintf("0x%04X", v)
+	},
+}
+
+var uuidsTpl = template.Must(template.New("uuids").Funcs(funcs).Parse(header + `package uuid
+
+var assigned = map[uint16]Info{
+{{- range .Services }}
+	{{ hex4 .UUID }}: {Name: {{ printf "%q" .Name }}, ID: {{ printf "%q" .ID }}, Type: TypeService},
+{{- end }}
+{{- range .Characteristics }}
+	{{ hex4 .UUID }}: {Name: {{ printf "%q" .Name }}, ID: {{ printf "%q" .ID }}, Type: TypeCharacteristic},
+{{- end }}
+{{- range .Descriptors }}
+	{{ hex4 .UUID }}: {Name: {{ printf "%q" .Name }}, ID: {{ printf "%q" .ID }}, Type: TypeDescriptor},
+{{- end }}
+}
+`))
+
+var companiesTpl = template.Must(template.New("companies").Funcs(funcs).Parse(header + `package company
+
+var companies = map[uint16]string{
+{{- range .Companies }}
+	{{ hex4 .Value }}: {{ printf "%q" .Name }},
+{{- end }}
+}
+`))
+
+var appearanceTpl = template.Must(template.New("appearance").Funcs(funcs).Parse(header + `package appearance
+
+var categories = map[uint16]category{
+{{- range .Appearance }}
+	{{ hex4 .Category }}: {
+		name: {{ printf "%q" .Name }},
+		{{- if .Subcategory }}
+		subcategories: map[uint16]string{
+			{{- range .Subcategory }}
+			{{ hex4 .Value }}: {{ printf "%q" .Name }},
+			{{- end }}
+		},
+		{{- end }}
+	},
+{{- end }}
+}
+`))
@@ -0,0 +1,84 @@
+package assigned
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	n, err := Load("data")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, n.Services)
+	assert.NotEmpty(t, n.Characteristics)
+	assert.NotEmpty(t, n.Descriptors)
+	assert.NotEmpty(t, n.Companies)
+	assert.NotEmpty(t, n.Appearance)
+
+	assert.Equal(t, UUID{0x1800, "GAP", "org.bluetooth.service.gap"}, n.Services[0])
+	assert.Equal(t, Company{0x0000, "Ericsson AB"}, n.Companies[0])
+
+	_, err = Load("missing")
+	assert.Error(t, err)
+}
+
+func TestUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "assigned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = Update("data", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := Load("data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, expected, n)
+
+	assert.Error(t, Update("missing", dir))
+}
+
+// TestGenerate checks the committed tables match the data files
+func TestGenerate(t *testing.T) {
+	n, err := Load("data")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "assigned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = Generate(n, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range []string{"uuid/gen_uuids.go", "company/gen_companies.go", "appearance/gen_appearance.go"} {
+		generated, err := ioutil.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		committed, err := ioutil.ReadFile(filepath.Join("../../assigned", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, string(committed), string(generated), "%s is outdated, run make gen/assigned", file)
+	}
+}
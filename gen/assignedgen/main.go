@@ -0,0 +1,52 @@
+// Generate the Bluetooth SIG assigned numbers tables.
+//
+// Usage: go run gen/assignedgen/main.go [assigned_numbers dir]
+//
+// When the assigned_numbers directory is passed, the data files are updated
+// from it before generating the tables.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/muka/go-bluetooth/gen/assigned"
+	log "github.com/sirupsen/logrus"
+)
+
+const dataDir = "gen/assigned/data"
+const outputDir = "assigned"
+
+func getBaseDir() string {
+	baseDir := os.Getenv("BASEDIR")
+	if baseDir == "" {
+		baseDir = "."
+	}
+	return baseDir
+}
+
+func main() {
+
+	srcDir := fmt.Sprintf("%s/%s", getBaseDir(), dataDir)
+	if len(os.Args) > 1 {
+		err := assigned.Update(os.Args[1], srcDir)
+		if err != nil {
+			log.Fatalf("Update: %s", err)
+		}
+	}
+
+	n, err := assigned.Load(srcDir)
+	if err != nil {
+		log.Fatalf("Load: %s", err)
+	}
+
+	err = assigned.Generate(n, fmt.Sprintf("%s/%s", getBaseDir(), outputDir))
+	if err != nil {
+		log.Fatalf("Generate: %s", err)
+	}
+
+	log.Infof(
+		"Generated %d services, %d characteristics, %d descriptors, %d companies, %d appearance categories",
+		len(n.Services), len(n.Characteristics), len(n.Descriptors), len(n.Companies), len(n.Appearance),
+	)
+}
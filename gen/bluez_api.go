@@ -2,8 +2,12 @@ package gen
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"sort"
 
+	"github.com/muka/go-bluetooth/bluez"
 	"github.com/muka/go-bluetooth/gen/types"
 )
 
@@ -39,3 +43,90 @@ func LoadJSON(srcFile string) (*BluezAPI, error) {
 
 	return a, nil
 }
+
+// SetSince tag the interfaces, methods, signals and properties with the version
+// introducing them, the oldest of the history listing them. Elements missing in
+// the history are tagged with the API version. As the history starts from a
+// version, eg. 5.50, the oldest tag means the element is available up to then
+func (g *BluezAPI) SetSince(history ...*BluezAPI) {
+
+	versions := append([]*BluezAPI{}, history...)
+	versions = append(versions, g)
+	sort.SliceStable(versions, func(i, j int) bool {
+		return bluez.CompareVersions(versions[i].Version, versions[j].Version) < 0
+	})
+
+	since := map[string]string{}
+	tag := func(key, version string) {
+		if _, ok := since[key]; !ok {
+			since[key] = version
+		}
+	}
+
+	for _, v := range versions {
+		for _, group := range v.Api {
+			if group == nil {
+				continue
+			}
+			for _, api := range group.Api {
+				if api == nil {
+					continue
+				}
+				tag(api.Interface, v.Version)
+				for _, m := range api.Methods {
+					tag(api.Interface+"."+m.Name, v.Version)
+				}
+				for _, s := range api.Signals {
+					tag(api.Interface+"."+s.Name, v.Version)
+				}
+				for _, p := range api.Properties {
+					tag(api.Interface+"."+p.Name, v.Version)
+				}
+			}
+		}
+	}
+
+	for _, group := range g.Api {
+		if group == nil {
+			continue
+		}
+		for _, api := range group.Api {
+			if api == nil {
+				continue
+			}
+			api.Since = since[api.Interface]
+			for _, m := range api.Methods {
+				m.Since = since[api.Interface+"."+m.Name]
+			}
+			for _, s := range api.Signals {
+				s.Since = since[api.Interface+"."+s.Name]
+			}
+			for _, p := range api.Properties {
+				p.Since = since[api.Interface+"."+p.Name]
+			}
+		}
+	}
+}
+
+// LoadHistory load the API definitions, eg. bluez-5.50.json, found in dir older than version
+func LoadHistory(dir string, version string) ([]*BluezAPI, error) {
+
+	files, err := filepath.Glob(filepath.Join(dir, "bluez-*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	history := []*BluezAPI{}
+	for _, file := range files {
+		api, err := LoadJSON(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", file, err)
+		}
+		if bluez.CompareVersions(api.Version, version) >= 0 {
+			continue
+		}
+		history = append(history, api)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,56 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/gen/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAPI(version string, props ...string) *BluezAPI {
+	api := &types.Api{
+		Interface: "org.bluez.Adapter1",
+		Methods: []*types.Method{
+			{Name: "StartDiscovery"},
+		},
+	}
+	for _, name := range props {
+		api.Properties = append(api.Properties, &types.Property{Name: name})
+	}
+	return &BluezAPI{
+		Version: version,
+		Api: []*types.ApiGroup{
+			{FileName: "adapter-api.txt", Api: []*types.Api{api}},
+		},
+	}
+}
+
+func TestSetSince(t *testing.T) {
+
+	v9 := newTestAPI("5.9", "Address")
+	v50 := newTestAPI("5.50", "Address", "Roles")
+	v66 := newTestAPI("5.66", "Address", "Roles", "PowerState")
+
+	// unordered history
+	v66.SetSince(v50, v9)
+
+	api := v66.Api[0].Api[0]
+	assert.Equal(t, "5.9", api.Since)
+	assert.Equal(t, "5.9", api.Methods[0].Since)
+	assert.Equal(t, "5.9", api.Properties[0].Since)
+	assert.Equal(t, "5.50", api.Properties[1].Since)
+	assert.Equal(t, "5.66", api.Properties[2].Since)
+}
+
+func TestLoadHistory(t *testing.T) {
+
+	history, err := LoadHistory("..", "5.55")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(t, history)
+	for _, api := range history {
+		assert.Equal(t, -1, bluez.CompareVersions(api.Version, "5.55"))
+	}
+}
@@ -23,6 +23,22 @@ func Generate(bluezApi gen.BluezAPI, outDir string, debug bool, forceOverwrite b
 		return err
 	}
 
+	bluezErrorsFile := path.Join(outDir, "gen_errors.go")
+	if forceOverwrite || !util.Exists(bluezErrorsFile) {
+		err = BluezErrorsTemplate(bluezErrorsFile, apiGroups)
+		if err != nil {
+			return err
+		}
+	}
+
+	sinceFile := path.Join(outDir, "gen_since.go")
+	if forceOverwrite || !util.Exists(sinceFile) {
+		err = SinceTemplate(sinceFile, apiGroups)
+		if err != nil {
+			return err
+		}
+	}
+
 	outDir += "/profile"
 	err = util.Mkdir(outDir)
 	if err != nil {
@@ -32,12 +32,36 @@ func RootTemplate(filename string, api *types.ApiGroup) error {
 	return nil
 }
 
-func ErrorsTemplate(filename string, apis []*types.ApiGroup) error {
+// knownErrors are documented by bluez but not always listed in the methods Possible errors
+var knownErrors = []string{
+	"InvalidArguments",
+	"InProgress",
+	"AlreadyExists",
+	"NotSupported",
+	"NotConnected",
+	"AlreadyConnected",
+	"NotAvailable",
+	"DoesNotExist",
+	"NotAuthorized",
+	"NotPermitted",
+	"NoSuchAdapter",
+	"AgentNotAvailable",
+	"NotReady",
+	"Failed",
+	"Rejected",
+	"Canceled",
+	"NotAcquired",
+	"InvalidOffset",
+	"InvalidValueLength",
+	"AuthenticationCanceled",
+	"AuthenticationFailed",
+	"AuthenticationRejected",
+	"AuthenticationTimeout",
+	"ConnectionAttemptFailed",
+}
 
-	fw, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("create file: %s", err)
-	}
+// collectErrors list the errors returned by the API methods, followed by the known ones
+func collectErrors(apis []*types.ApiGroup) types.BluezErrors {
 
 	errors := []string{}
 	for _, apiGroup := range apis {
@@ -53,24 +77,48 @@ func ErrorsTemplate(filename string, apis []*types.ApiGroup) error {
 					continue
 				}
 				for _, err := range method.Errors {
-					errors = appendIfMissing(errors, err)
+					errors = appendIfMissing(errors, strings.Replace(err, "org.bluez.Error.", "", 1))
 				}
 			}
 		}
 	}
 
+	for _, err := range knownErrors {
+		errors = appendIfMissing(errors, err)
+	}
+
 	errorsList := types.BluezErrors{
 		List: make([]types.BluezError, len(errors)),
 	}
 
 	for i, err := range errors {
 		errorsList.List[i] = types.BluezError{
-			Name: strings.Replace(err, "org.bluez.Error.", "", 1),
+			Name: err,
 		}
 	}
 
-	tmpl := loadtpl("errors")
-	err = tmpl.Execute(fw, errorsList)
+	return errorsList
+}
+
+// ErrorsTemplate generate the errors as dbus.Error, to be returned by exposed services
+func ErrorsTemplate(filename string, apis []*types.ApiGroup) error {
+	return errorsTemplate("errors", filename, apis)
+}
+
+// BluezErrorsTemplate generate the errors as bluez.Error, to be matched with errors.Is
+func BluezErrorsTemplate(filename string, apis []*types.ApiGroup) error {
+	return errorsTemplate("bluez_errors", filename, apis)
+}
+
+func errorsTemplate(tplName string, filename string, apis []*types.ApiGroup) error {
+
+	fw, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("create file: %s", err)
+	}
+
+	tmpl := loadtpl(tplName)
+	err = tmpl.Execute(fw, collectErrors(apis))
 	if err != nil {
 		return fmt.Errorf("tpl write: %s", err)
 	}
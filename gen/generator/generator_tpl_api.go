@@ -205,6 +205,11 @@ func ApiTemplate(filename string, api *types.Api, apiGroup *types.ApiGroup) erro
 		imports = append(imports, "github.com/godbus/dbus/v5")
 	}
 
+	// methods have a context.Context variant
+	if len(methods) > 0 {
+		imports = append(imports, "context")
+	}
+
 	api.Description = prepareDocs(api.Description, false, 0)
 	api.Title = strings.Trim(api.Title, "\n \t")
 
@@ -26,8 +26,10 @@ func createConstructors(api *types.Api) []types.Constructor {
 	for i, c := range constructors {
 
 		args := []string{}
+		names := []string{}
 		if c.Service == "" {
 			args = append(args, "servicePath string")
+			names = append(names, "servicePath")
 			c.Service = "servicePath"
 		} else {
 			c.Service = fmt.Sprintf(`"%s"`, c.Service)
@@ -35,12 +37,14 @@ func createConstructors(api *types.Api) []types.Constructor {
 
 		if c.ObjectPath == "" {
 			args = append(args, "objectPath dbus.ObjectPath")
+			names = append(names, "objectPath")
 			c.ObjectPath = "objectPath"
 		} else {
 			c.ObjectPath = fmt.Sprintf(`"%s"`, c.ObjectPath)
 		}
 
 		c.Args = strings.Join(args, ", ")
+		c.ArgsNames = strings.Join(names, ", ")
 
 		docs := []string{}
 		for _, doc := range c.Docs {
@@ -70,6 +74,7 @@ func createConstructors(api *types.Api) []types.Constructor {
 
 					c := types.Constructor{
 						Args:       "adapterID string",
+						ArgsNames:  "adapterID",
 						ArgsDocs:   "// adapterID: ID of an adapter eg. hci0",
 						Docs:       c1.Docs,
 						ObjectPath: `fmt.Sprintf("/org/bluez/%s", adapterID)`,
@@ -12,8 +12,10 @@ func toType(t string) string {
 	switch strings.Trim(t, " \t\r\n") {
 	case "boolean":
 		return "bool"
-	case "int16":
+	case "int16", "Int16":
 		return "int16"
+	case "Uint16":
+		return "uint16"
 	case "uint16_t":
 		return "uint16"
 	case "uint32_t":
@@ -160,7 +162,12 @@ func getRawTypeInitializer(t string) string {
 		// return "[]uint8{}"
 	case "dbus.ObjectPath":
 		return "dbus.ObjectPath(\"\")"
-	default:
-		panic(fmt.Sprintf("Unknown type: %s", t))
 	}
+
+	// struct types declared in the API package, eg. mapped by override
+	if len(t) > 0 && t[0] >= 'A' && t[0] <= 'Z' {
+		return t + "{}"
+	}
+
+	panic(fmt.Sprintf("Unknown type: %s", t))
 }
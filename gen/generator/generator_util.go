@@ -6,7 +6,9 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/muka/go-bluetooth/gen/override"
 	"github.com/muka/go-bluetooth/gen/types"
+	"github.com/muka/go-bluetooth/gen/util"
 )
 
 var TplPath = "gen/generator/tpl/%s.go.tpl"
@@ -61,6 +63,9 @@ func prepareDocs(src string, skipFirstComment bool, leftpad int) string {
 }
 
 func getApiPackage(apiGroup *types.ApiGroup) string {
+	if util.IsRst(apiGroup.FileName) {
+		return override.GetRstPackage(apiGroup.FileName)
+	}
 	apiName := strings.Replace(apiGroup.FileName, "-api.txt", "", -1)
 	apiName = strings.Replace(apiName, "-", "_", -1)
 	return apiName
@@ -2,7 +2,12 @@ package generator
 
 import (
 	"fmt"
+	"go/format"
 	"io/ioutil"
+	"sort"
+
+	"github.com/muka/go-bluetooth/bluez"
+	"github.com/muka/go-bluetooth/gen/types"
 )
 
 // VersionTemplate generate the version file
@@ -22,3 +27,78 @@ const Version = %s
 
 	return nil
 }
+
+// SinceTemplate generate the table of the versions introducing the interfaces and their members
+func SinceTemplate(filename string, apiGroups []*types.ApiGroup) error {
+
+	since := map[string]string{}
+	for _, apiGroup := range apiGroups {
+		if apiGroup == nil {
+			continue
+		}
+		for _, api := range apiGroup.Api {
+			if api == nil {
+				continue
+			}
+			if api.Since != "" {
+				since[api.Interface] = api.Since
+			}
+			for _, m := range api.Methods {
+				if m.Since != "" {
+					since[api.Interface+"."+m.Name] = m.Since
+				}
+			}
+			for _, s := range api.Signals {
+				if s.Since != "" {
+					since[api.Interface+"."+s.Name] = s.Since
+				}
+			}
+			for _, p := range api.Properties {
+				if p.Since != "" {
+					since[api.Interface+"."+p.Name] = p.Since
+				}
+			}
+		}
+	}
+
+	keys := []string{}
+	oldest := ""
+	for key, version := range since {
+		keys = append(keys, key)
+		if oldest == "" || bluez.CompareVersions(version, oldest) < 0 {
+			oldest = version
+		}
+	}
+	sort.Strings(keys)
+
+	entries := ""
+	for _, key := range keys {
+		entries += fmt.Sprintf("\t%q: %q,\n", key, since[key])
+	}
+
+	tpl := `// Code generated by go-bluetooth generator DO NOT EDIT.
+
+package bluez
+
+// SinceOldest is the oldest version of the API definitions, tagging everything
+// available up to then: a member tagged with it is available since bluez <= SinceOldest
+const SinceOldest = %q
+
+// Since map the interfaces, and their members as <interface>.<member>, to the
+// bluez version introducing them, see SinceOldest
+var Since = map[string]string{
+%s}
+`
+
+	code, err := format.Source([]byte(fmt.Sprintf(tpl, oldest, entries)))
+	if err != nil {
+		return fmt.Errorf("tpl format: %s", err)
+	}
+
+	err = ioutil.WriteFile(filename, code, 0644)
+	if err != nil {
+		return fmt.Errorf("tpl write: %s", err)
+	}
+
+	return nil
+}
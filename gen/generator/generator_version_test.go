@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/muka/go-bluetooth/gen/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSinceTemplate(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "since")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "gen_since.go")
+	err = SinceTemplate(filename, []*types.ApiGroup{
+		{
+			Api: []*types.Api{
+				{
+					Interface: "org.bluez.Device1",
+					Since:     "5.9",
+					Methods: []*types.Method{
+						{Name: "Connect", Since: "5.9"},
+					},
+					Properties: []*types.Property{
+						{Name: "WakeAllowed", Since: "5.55"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, string(code), `const SinceOldest = "5.9"`)
+	assert.Contains(t, string(code), `"org.bluez.Device1.WakeAllowed": "5.55",`)
+}
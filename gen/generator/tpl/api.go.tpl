@@ -12,6 +12,12 @@ var {{.InterfaceName}}Interface = "{{.Api.Interface}}"
 // New{{$InterfaceName}}{{.Role}} create a new instance of {{$InterfaceName}}
 {{.ArgsDocs}}
 func New{{$InterfaceName}}{{.Role}}({{.Args}}) (*{{$InterfaceName}}, error) {
+	return New{{$InterfaceName}}{{.Role}}WithConn(nil{{if .ArgsNames}}, {{.ArgsNames}}{{end}})
+}
+
+// New{{$InterfaceName}}{{.Role}}WithConn create a new instance of {{$InterfaceName}} using the given connection.
+// The default system bus connection is used if conn is nil
+func New{{$InterfaceName}}{{.Role}}WithConn(conn *bluez.Conn{{if .Args}}, {{.Args}}{{end}}) (*{{$InterfaceName}}, error) {
 	a := new({{$InterfaceName}})
 	a.client = bluez.NewClient(
 		&bluez.Config{
@@ -19,6 +25,7 @@ func New{{$InterfaceName}}{{.Role}}({{.Args}}) (*{{$InterfaceName}}, error) {
 			Iface: {{$InterfaceName}}Interface,
 			Path:  dbus.ObjectPath({{.ObjectPath}}),
 			Bus:   bluez.SystemBus,
+			Conn:  conn,
 		},
 	)
 	{{- if $ExposeProperties }}
@@ -118,7 +125,7 @@ func (a *{{.InterfaceName}}) GetObjectManagerSignal() (chan *dbus.Signal, func()
 
 	if a.objectManagerSignal == nil {
 		if a.objectManager == nil {
-			om, err := bluez.GetObjectManager()
+			om, err := a.client.ObjectManager()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -239,11 +246,16 @@ func (a *{{.InterfaceName}}) UnwatchProperties(ch chan *bluez.PropertyChanged) e
 {{.Name}} {{.Docs}}
 */
 func (a *{{$InterfaceName}}) {{.Name}}({{.ArgsList}}) {{.Method.ReturnType}} {
+	return a.{{.Name}}Context(context.Background(), {{.ParamsList}})
+}
+
+// {{.Name}}Context is like {{.Name}}, the call is cancelled when ctx is done
+func (a *{{$InterfaceName}}) {{.Name}}Context(ctx context.Context{{if .ArgsList}}, {{.ArgsList}}{{end}}) {{.Method.ReturnType}} {
 	{{- if .SingleReturn}}
-	return a.client.Call("{{.Name}}", 0, {{.ParamsList}}).Store()
+	return a.client.CallWithContext(ctx, "{{.Name}}", 0, {{.ParamsList}}).Store()
 	{{- else}}
 	{{.ReturnVarsDefinition}}
-	err := a.client.Call("{{.Name}}", 0, {{.ParamsList}}).Store({{.ReturnVarsRefs}})
+	err := a.client.CallWithContext(ctx, "{{.Name}}", 0, {{.ParamsList}}).Store({{.ReturnVarsRefs}})
 	return {{.ReturnVarsList}}, err{{end}}
 }
 {{- end}}
@@ -10,6 +10,11 @@ var constructorOverrides = map[string][]ConstructorOverride{
 			AdapterAsArgument: true,
 		},
 	},
+	"org.bluez.AdvertisementMonitorManager1": {
+		ConstructorOverride{
+			AdapterAsArgument: true,
+		},
+	},
 	"org.bluez.GattManager1": {
 		ConstructorOverride{
 			AdapterAsArgument: true,
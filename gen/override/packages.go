@@ -0,0 +1,60 @@
+package override
+
+import "strings"
+
+// rstPackages map the rst docs, by interface name prefix, to the package of the
+// equivalent txt doc. Longer prefixes are checked first
+var rstPackages = map[string]string{
+	"obex.AgentManager":    "obex_agent",
+	"obex.Agent":           "obex_agent",
+	"obex.":                "obex",
+	"mesh.":                "mesh",
+	"AdvertisementMonitor": "advertisement_monitor",
+	"LEAdvertis":           "advertising",
+	"AgentManager":         "agent",
+	"Agent":                "agent",
+	"Battery":              "battery",
+	"Gatt":                 "gatt",
+	"Health":               "health",
+	"Media":                "media",
+	"Network":              "network",
+	"ProfileManager":       "profile",
+	"Profile":              "profile",
+	"SimAccess":            "sap",
+	"Thermometer":          "thermometer",
+	"Input":                "input",
+	"Device":               "device",
+	"Adapter":              "adapter",
+}
+
+// GetRstPackage return the package of a rst doc, eg. gatt for org.bluez.GattService.rst
+func GetRstPackage(fileName string) string {
+
+	name := strings.TrimSuffix(strings.TrimPrefix(fileName, "org.bluez."), ".rst")
+
+	match := ""
+	for prefix := range rstPackages {
+		if strings.HasPrefix(name, prefix) && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+	if match != "" {
+		return rstPackages[match]
+	}
+
+	// eg. AdminPolicySet to admin_policy_set
+	pkg := ""
+	for i, c := range name {
+		if c >= 'A' && c <= 'Z' {
+			if i > 0 {
+				pkg += "_"
+			}
+			c += 'a' - 'A'
+		}
+		if c == '.' {
+			c = '_'
+		}
+		pkg += string(c)
+	}
+	return pkg
+}
@@ -15,8 +15,8 @@ var PropertyTypes = map[string]map[string]string{
 	"org.bluez.GattCharacteristic1": {
 		"Value":          "[]byte `dbus:\"emit\"`",
 		"Descriptors":    "[]dbus.ObjectPath",
-		"WriteAcquired":  "bool `dbus:\"ignore\"`",
-		"NotifyAcquired": "bool `dbus:\"ignore\"`",
+		"WriteAcquired":  "bool `dbus:\"omitEmpty\"`",
+		"NotifyAcquired": "bool `dbus:\"omitEmpty\"`",
 	},
 	"org.bluez.GattDescriptor1": {
 		"Value":          "[]byte `dbus:\"emit\"`",
@@ -28,6 +28,11 @@ var PropertyTypes = map[string]map[string]string{
 		"Device":          "dbus.ObjectPath `dbus:\"ignore=IsService\"`",
 		"IsService":       "bool `dbus:\"ignore\"`",
 	},
+	"org.bluez.AdvertisementMonitor1": {
+		// optional, bluez refuses a zero value
+		"RSSIThresholdsAndTimers": "RSSIThresholdsAndTimers `dbus:\"omitEmpty\"`",
+		"Patterns":                "[]Pattern `dbus:\"omitEmpty\"`",
+	},
 	"org.bluez.LEAdvertisement1": {
 		// dbus type: (yv) dict of byte variant (array of bytes)
 		"Data": "map[byte]interface{}",
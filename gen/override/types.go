@@ -21,6 +21,11 @@ var typesMap = map[string]string{
 	"array{objects, properties}": "[]Item",
 	// media-api fd, uint16, uint16 Acquire()
 	"fd, uint16, uint16": "dbus.UnixFD, uint16, uint16",
+	// advertisement-monitor-api (Int16, Uint16, Int16, Uint16) RSSIThresholdsAndTimers [read-only, optional]
+	"(Int16, Uint16, Int16, Uint16)": "RSSIThresholdsAndTimers",
+	// advertisement-monitor-api array{(uint8, uint8, string)} Patterns [read-only, optional]
+	// the pattern content is a byte array
+	"array{(uint8, uint8, string)}": "[]Pattern",
 }
 
 //MapType map a raw type literal otherwise difficult to parse
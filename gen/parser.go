@@ -40,8 +40,14 @@ func Parse(docsDir string, filtersList []filters.Filter, debug bool) (BluezAPI,
 			continue
 		}
 
-		apiGroupParser := parser.NewApiGroupParser(debug, filtersList)
-		apiGroup, err := apiGroupParser.Parse(file)
+		var apiGroup *types.ApiGroup
+		if util.IsRst(file) {
+			rstParser := parser.NewRstParser(debug, filtersList)
+			apiGroup, err = rstParser.Parse(file)
+		} else {
+			apiGroupParser := parser.NewApiGroupParser(debug, filtersList)
+			apiGroup, err = apiGroupParser.Parse(file)
+		}
 		if err != nil {
 			log.Errorf("Failed to load %s, skipped", file)
 			continue
@@ -71,6 +71,10 @@ func (g *ApiParser) Parse(raw []byte) (*types.Api, error) {
 
 	api.Service = string(raw[matches[2]:matches[3]])
 	api.Interface = string(raw[matches[4]:matches[5]])
+	// drop flags eg. org.bluez.AdvertisementMonitor1 [experimental]
+	if i := strings.Index(api.Interface, " ["); i > -1 {
+		api.Interface = api.Interface[:i]
+	}
 	api.ObjectPath = string(raw[matches[6]:matches[7]])
 
 	if g.debug {
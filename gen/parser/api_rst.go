@@ -0,0 +1,273 @@
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/muka/go-bluetooth/gen/filters"
+	"github.com/muka/go-bluetooth/gen/types"
+	"github.com/muka/go-bluetooth/gen/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// rstAdornments are the characters underlining the rst docs section titles
+const rstAdornments = "=-`~^*+#"
+
+var rstFieldRegexp = regexp.MustCompile(`^:(Service|Interface|Object path):\s*(.*)$`)
+
+// RstParser parse the reStructuredText docs of newer bluez releases, eg.
+// org.bluez.Adapter.rst. Those describe an interface per file, as
+//
+//	:Interface:	org.bluez.Adapter1
+//
+//	Methods
+//	-------
+//
+//	void StartDiscovery()
+//	`````````````````````
+//
+//	This method starts the device discovery session...
+type RstParser struct {
+	model  *types.ApiGroup
+	debug  bool
+	filter []filters.Filter
+
+	api   *types.Api
+	kind  string
+	title string
+	docs  []string
+}
+
+// NewRstParser parser for rst docs
+func NewRstParser(debug bool, filtersList []filters.Filter) RstParser {
+	return RstParser{
+		debug:  debug,
+		filter: filtersList,
+		model: &types.ApiGroup{
+			Api: make([]*types.Api, 0),
+		},
+	}
+}
+
+// Parse load a rst documentation file and parse the content
+func (g *RstParser) Parse(srcFile string) (*types.ApiGroup, error) {
+
+	if g.debug {
+		log.Debugf("------------------- Parsing %s -------------------", srcFile)
+	}
+
+	raw, err := util.ReadFile(srcFile)
+	if err != nil {
+		return g.model, err
+	}
+
+	apiGroup, err := g.ParseRaw(raw)
+	apiGroup.FileName = filepath.Base(srcFile)
+	if err != nil {
+		return apiGroup, fmt.Errorf("%s: %s", srcFile, err)
+	}
+	return apiGroup, nil
+}
+
+// ParseRaw parse the content of a rst doc
+func (g *RstParser) ParseRaw(raw []byte) (*types.ApiGroup, error) {
+
+	lines := strings.Split(strings.Replace(string(raw), "\r\n", "\n", -1), "\n")
+	headings := 0
+	name := ""
+
+	for i := 0; i < len(lines); i++ {
+
+		line := strings.TrimRight(lines[i], " \t")
+
+		if isRstAdornment(line) {
+			continue
+		}
+
+		// section title, underlined
+		if line != "" && i+1 < len(lines) && isRstAdornment(strings.TrimRight(lines[i+1], " \t")) {
+			headings++
+			title := strings.TrimSpace(line)
+			switch headings {
+			case 1:
+				name = title
+			case 2:
+				g.model.Name = title
+			}
+			g.heading(title, lines[i+1][0])
+			i++
+			continue
+		}
+
+		if m := rstFieldRegexp.FindStringSubmatch(line); m != nil && (g.kind == "" || g.kind == "other") {
+			g.field(m[1], strings.TrimSpace(m[2]), name)
+			continue
+		}
+
+		g.docs = append(g.docs, line)
+	}
+	g.flush()
+
+	if g.model.Name == "" {
+		g.model.Name = name
+	}
+
+	apis := make([]*types.Api, 0)
+	for _, api := range g.model.Api {
+		if g.skipApi(api.Title) {
+			log.Debugf("Skip filtered API %s", api.Title)
+			continue
+		}
+		apis = append(apis, api)
+	}
+	g.model.Api = apis
+
+	if len(g.model.Api) == 0 {
+		return g.model, fmt.Errorf("no interface defined?")
+	}
+
+	return g.model, nil
+}
+
+// heading handle a section title
+func (g *RstParser) heading(title string, adornment byte) {
+
+	g.flush()
+
+	switch title {
+	case "Description":
+		g.kind = "description"
+		return
+	case "Interface":
+		g.kind = ""
+		g.api = nil
+		return
+	case "Methods", "Signals", "Properties":
+		g.kind = strings.ToLower(title)
+		return
+	}
+
+	// members are titled in the Methods, Signals and Properties sections
+	if adornment == '`' && g.api != nil &&
+		(g.kind == "methods" || g.kind == "signals" || g.kind == "properties") {
+		g.title = title
+		return
+	}
+
+	g.kind = "other"
+}
+
+// field handle the Service, Interface and Object path fields of an interface
+func (g *RstParser) field(key, value, name string) {
+
+	if g.api == nil || (key == "Interface" && g.api.Interface != "") {
+		g.api = &types.Api{
+			Title:      strings.TrimPrefix(name, "org.bluez.") + " hierarchy",
+			Methods:    make([]*types.Method, 0),
+			Signals:    make([]*types.Method, 0),
+			Properties: make([]*types.Property, 0),
+		}
+		g.model.Api = append(g.model.Api, g.api)
+	}
+
+	switch key {
+	case "Service":
+		g.api.Service = value
+	case "Interface":
+		// drop flags eg. org.bluez.AdvertisementMonitor1 [experimental]
+		if i := strings.Index(value, " ["); i > -1 {
+			value = value[:i]
+		}
+		g.api.Interface = value
+		if g.debug {
+			log.Debugf("= %s", g.api.Title)
+			log.Debugf("\tInterface %s", value)
+		}
+	case "Object path":
+		g.api.ObjectPath = value
+	}
+}
+
+// flush store the member or description collected so far
+func (g *RstParser) flush() {
+
+	docs := strings.Trim(strings.Join(g.docs, "\n"), "\n")
+	title := g.title
+	g.docs = []string{}
+	g.title = ""
+
+	if g.kind == "description" {
+		if docs != "" {
+			g.model.Description += docs + "\n"
+		}
+		return
+	}
+
+	if title == "" || g.api == nil {
+		return
+	}
+
+	raw := []byte(fmt.Sprintf("\t%s\n%s\n", title, docs))
+
+	switch g.kind {
+	case "methods", "signals":
+		methodParser := NewMethodParser(g.debug)
+		method, err := methodParser.Parse(raw)
+		if err != nil {
+			log.Warnf("[%s] method parse error: %s", g.api.Interface, err)
+			return
+		}
+		if g.kind == "signals" {
+			g.api.Signals = append(g.api.Signals, method)
+			return
+		}
+		if g.skipMethod(method.Name) {
+			return
+		}
+		g.api.Methods = append(g.api.Methods, method)
+	case "properties":
+		propertyParser := NewPropertyParser(g.debug)
+		prop, err := propertyParser.Parse(raw)
+		if err != nil {
+			log.Warnf("[%s] skipped property %s: %s", g.api.Interface, title, err)
+			return
+		}
+		g.api.Properties = append(g.api.Properties, prop)
+	}
+}
+
+// skipApi apply the API filters
+func (g *RstParser) skipApi(title string) bool {
+	for _, f := range g.filter {
+		if f.Context != filters.FilterApi {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(title), strings.ToLower(f.Value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipMethod apply the method filters
+func (g *RstParser) skipMethod(name string) bool {
+	for _, f := range g.filter {
+		if f.Context != filters.FilterMethod {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(name), strings.ToLower(f.Value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRstAdornment report if a line underlines or overlines a section title
+func isRstAdornment(line string) bool {
+	if len(line) < 2 || !strings.ContainsRune(rstAdornments, rune(line[0])) {
+		return false
+	}
+	return strings.Count(line, line[:1]) == len(line)
+}
@@ -0,0 +1,168 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/muka/go-bluetooth/gen/filters"
+	"github.com/muka/go-bluetooth/gen/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const adapterRst = `=================
+org.bluez.Adapter
+=================
+
+-------------------------------------
+BlueZ D-Bus Adapter API documentation
+-------------------------------------
+
+:Version: BlueZ
+:Date: September 2023
+:Manual section: 5
+:Manual group: Linux System Administration
+
+Interface
+=========
+
+:Service:	org.bluez
+:Interface:	org.bluez.Adapter1
+:Object path:	[variable prefix]/{hci0,hci1,...}
+
+Methods
+-------
+
+void StartDiscovery()
+` + "`````````````````````" + `
+
+Starts device discovery session which may include starting an inquiry and/or
+scanning procedures and remote device name resolving.
+
+Possible errors:
+
+:org.bluez.Error.NotReady:
+:org.bluez.Error.Failed:
+:org.bluez.Error.InProgress:
+
+void RemoveDevice(object device)
+` + "````````````````````````````````" + `
+
+Removes the remote device object at the given path including cached
+information such as bonding information.
+
+array{string} GetDiscoveryFilters()
+` + "```````````````````````````````````" + `
+
+Returns available filters that can be given to **SetDiscoveryFilter()**.
+
+Properties
+----------
+
+string Address [readonly]
+` + "`````````````````````````" + `
+
+The Bluetooth device address.
+
+boolean Powered [readwrite]
+` + "```````````````````````````" + `
+
+Switch an adapter on or off.
+
+array{string} Roles [readonly]
+` + "``````````````````````````````" + `
+
+List of supported roles.
+
+Possible values:
+
+:"central":
+
+	Supports the central role.
+
+uint8 Type [readonly, experimental]
+` + "```````````````````````````````````" + `
+
+A type, not part of the adapter API.
+`
+
+func TestRstParse(t *testing.T) {
+
+	p := NewRstParser(false, []filters.Filter{})
+	group, err := p.ParseRaw([]byte(adapterRst))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "BlueZ D-Bus Adapter API documentation", group.Name)
+	assert.Len(t, group.Api, 1)
+
+	api := group.Api[0]
+	assert.Equal(t, "Adapter hierarchy", api.Title)
+	assert.Equal(t, "org.bluez", api.Service)
+	assert.Equal(t, "org.bluez.Adapter1", api.Interface)
+	assert.Equal(t, "[variable prefix]/{hci0,hci1,...}", api.ObjectPath)
+
+	assert.Len(t, api.Methods, 3)
+	assert.Equal(t, "StartDiscovery", api.Methods[0].Name)
+	assert.Equal(t, "void", api.Methods[0].ReturnType)
+	assert.Contains(t, api.Methods[0].Errors, "org.bluez.Error.NotReady")
+	assert.Equal(t, "RemoveDevice", api.Methods[1].Name)
+	assert.Equal(t, []types.Arg{{Type: "object", Name: "device"}}, api.Methods[1].Args)
+	assert.Equal(t, "array{string}", api.Methods[2].ReturnType)
+
+	assert.Len(t, api.Properties, 4)
+	assert.Equal(t, "Address", api.Properties[0].Name)
+	assert.Equal(t, []types.Flag{types.FlagReadOnly}, api.Properties[0].Flags)
+	assert.Equal(t, "The Bluetooth device address.", api.Properties[0].Docs)
+	assert.Equal(t, "Powered", api.Properties[1].Name)
+	assert.Equal(t, "boolean", api.Properties[1].Type)
+	assert.Equal(t, []types.Flag{types.FlagReadWrite}, api.Properties[1].Flags)
+	assert.Equal(t, "Roles", api.Properties[2].Name)
+	assert.Equal(t, "array{string}", api.Properties[2].Type)
+	assert.Equal(t, "uint8", api.Properties[3].Type)
+	assert.Equal(t, []types.Flag{types.FlagReadOnly, types.FlagExperimental}, api.Properties[3].Flags)
+}
+
+func TestRstParseFilter(t *testing.T) {
+
+	p := NewRstParser(false, []filters.Filter{
+		{Context: filters.FilterMethod, Value: "discovery"},
+	})
+	group, err := p.ParseRaw([]byte(adapterRst))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, group.Api[0].Methods, 2)
+
+	p = NewRstParser(false, []filters.Filter{})
+	_, err = p.ParseRaw([]byte("Title\n=====\n\nNo interface here.\n"))
+	assert.Error(t, err)
+}
+
+// TestRstParseFile parse a doc as shipped by bluez, doc/org.bluez.AgentManager.rst
+func TestRstParseFile(t *testing.T) {
+
+	p := NewRstParser(false, []filters.Filter{})
+	group, err := p.Parse("testdata/org.bluez.AgentManager.rst")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "org.bluez.AgentManager.rst", group.FileName)
+	assert.Equal(t, "BlueZ D-Bus AgentManager API documentation", group.Name)
+	assert.Len(t, group.Api, 1)
+
+	api := group.Api[0]
+	assert.Equal(t, "org.bluez", api.Service)
+	assert.Equal(t, "org.bluez.AgentManager1", api.Interface)
+	assert.Equal(t, "/org/bluez", api.ObjectPath)
+	assert.Empty(t, api.Properties)
+
+	assert.Len(t, api.Methods, 3)
+	assert.Equal(t, "RegisterAgent", api.Methods[0].Name)
+	assert.Equal(t, []types.Arg{{Type: "object", Name: "agent"}, {Type: "string", Name: "capability"}}, api.Methods[0].Args)
+	assert.Equal(t, []string{"org.bluez.Error.InvalidArguments", "org.bluez.Error.AlreadyExists"}, api.Methods[0].Errors)
+	assert.Equal(t, "UnregisterAgent", api.Methods[1].Name)
+	assert.Equal(t, []string{"org.bluez.Error.DoesNotExist"}, api.Methods[1].Errors)
+	assert.Equal(t, "RequestDefaultAgent", api.Methods[2].Name)
+	assert.Equal(t, "void", api.Methods[2].ReturnType)
+}
@@ -97,10 +97,8 @@ func (g *MethodParser) Parse(raw []byte) (*types.Method, error) {
 	re2 := regexp.MustCompile(`(?s)(org\.bluez\.Error\.\w+)`)
 	matches2 := re2.FindAllSubmatch(raw, -1)
 
-	if len(matches2) >= 1 {
-		for _, merr := range matches2[0] {
-			method.Errors = append(method.Errors, string(merr))
-		}
+	for _, merr := range matches2 {
+		method.Errors = append(method.Errors, string(merr[1]))
 	}
 
 	if method.Name == "" {
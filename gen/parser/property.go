@@ -9,7 +9,12 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-const propBaseRegexp = `(bool|boolean|byte|string|int16|uint16|uint16_t|uint32|dict|object|array\{.*?) ([A-Z].+?)`
+// propBaseRegexp match the property type and name. Struct types, eg. (int16, uint16), are supported
+const propBaseRegexp = `(bool|boolean|byte|string|int16|Int16|uint16|Uint16|uint16_t|uint32|dict|object|array\{.*?|\(.*?\)) ([A-Z].+?)`
+
+// propRegexp match the type and name of a single property, including the types
+// of newer docs. Those are not used to split the properties, as they appear in the txt docs text
+const propRegexp = `(bool|boolean|byte|string|int8|uint8|int16|Int16|uint16|Uint16|uint16_t|int32|uint32|uint64|dict|object|variant|array\{.*?|\(.*?\)) ([A-Z].+?)`
 
 type PropertyParser struct {
 	model *types.Property
@@ -30,13 +35,13 @@ func (g *PropertyParser) Parse(raw []byte) (*types.Property, error) {
 	property := g.model
 	// log.Debugf("prop raw -> %s", raw)
 
-	re1 := regexp.MustCompile(`[ \t]*?` + propBaseRegexp + `( \[[^\]]*\].*)?\n((?s).+)`)
+	re1 := regexp.MustCompile(`[ \t]*?` + propRegexp + `( \[[^\]]*\].*)?\n((?s).+)`)
 	matches2 := re1.FindAllSubmatch(raw, -1)
 
 	// log.Warnf("m1 %s", matches2)
 
 	if len(matches2) == 0 || len(matches2[0]) == 1 {
-		re1 = regexp.MustCompile(`[ \t]*?` + propBaseRegexp + `\n((?s).+)`)
+		re1 = regexp.MustCompile(`[ \t]*?` + propRegexp + `\n((?s).+)`)
 		matches2 = re1.FindAllSubmatch(raw, -1)
 		// log.Warnf("m2 %s", matches2)
 	}
@@ -66,18 +71,13 @@ func (g *PropertyParser) Parse(raw []byte) (*types.Property, error) {
 		if f != "" {
 			var flag types.Flag = 0
 			switch f {
-			case "readonly":
-			case "read-only":
+			case "readonly", "read-only":
 				flag = types.FlagReadOnly
-			case "writeonly":
-			case "write-only":
+			case "writeonly", "write-only":
 				flag = types.FlagWriteOnly
-			case "readwrite":
-			case "read-write":
-			case "read/write":
+			case "readwrite", "read-write", "read/write":
 				flag = types.FlagReadWrite
-			case "experimental":
-			case "Experimental":
+			case "experimental", "Experimental":
 				flag = types.FlagExperimental
 			case "optional":
 				flag = types.FlagOptional
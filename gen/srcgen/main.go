@@ -125,6 +125,14 @@ func Parse(filters []filters.Filter, debug bool) error {
 		baseDir = "."
 	}
 
+	// tag the API with the versions introducing each element
+	history, err := gen.LoadHistory(baseDir, api.Version)
+	if err != nil {
+		log.Fatalf("Failed to load previous API versions: %s", err)
+		return err
+	}
+	api.SetSince(history...)
+
 	apiFile := fmt.Sprintf("%s/bluez-%s.json", baseDir, api.Version)
 	log.Infof("Saving to %s\n", apiFile)
 	err = api.Serialize(apiFile)
@@ -59,6 +59,7 @@ type Constructor struct {
 	Role       string
 	ObjectPath string
 	Args       string
+	ArgsNames  string
 	ArgsDocs   string
 	Docs       []string
 }
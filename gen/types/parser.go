@@ -23,6 +23,8 @@ type Api struct {
 	// those are currently avail only in health-api
 	Signals    []*Method
 	Properties []*Property
+	// Since is the bluez version introducing the interface, see BluezAPI.SetSince
+	Since string `json:",omitempty"`
 }
 
 type Flag int
@@ -51,6 +53,8 @@ type Method struct {
 	Args       []Arg
 	Errors     []string
 	Docs       string
+	// Since is the bluez version introducing the method or signal
+	Since string `json:",omitempty"`
 }
 
 func (m *Method) String() string {
@@ -66,6 +70,8 @@ type Property struct {
 	Type  string
 	Docs  string
 	Flags []Flag
+	// Since is the bluez version introducing the property
+	Since string `json:",omitempty"`
 }
 
 func (p *Property) String() string {
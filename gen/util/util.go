@@ -20,7 +20,8 @@ func Mkdir(dirpath string) error {
 	return nil
 }
 
-// ListFiles return a list of bluez api txt
+// ListFiles return a list of bluez api docs, the txt ones, eg. adapter-api.txt,
+// and the reStructuredText ones of newer releases, eg. org.bluez.Adapter.rst
 func ListFiles(dir string) ([]string, error) {
 
 	list := make([]string, 0)
@@ -39,6 +40,11 @@ func ListFiles(dir string) ([]string, error) {
 			return nil
 		}
 
+		if IsRst(path) {
+			list = append(list, path)
+			return nil
+		}
+
 		if !strings.HasSuffix(path, "-api.txt") {
 			return nil
 		}
@@ -55,6 +61,12 @@ func ListFiles(dir string) ([]string, error) {
 	return list, nil
 }
 
+// IsRst report if path is a reStructuredText D-Bus API doc, eg. org.bluez.Adapter.rst
+func IsRst(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasPrefix(name, "org.bluez.") && strings.HasSuffix(name, ".rst")
+}
+
 // ReadFile read a file content
 func ReadFile(srcFile string) ([]byte, error) {
 	file, err := os.Open(srcFile)